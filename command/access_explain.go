@@ -0,0 +1,428 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// AccessExplainCommand is a command to explain a user's effective
+// permissions, or who holds a permission on a workspace
+type AccessExplainCommand struct {
+	Meta
+	organization     string
+	user             string
+	workspace        string
+	projectID        string
+	permission       string
+	format           string
+	teamSvc          teamLister
+	projectSvc       projectLister
+	workspaceSvc     workspaceListRefReader
+	projectAccessSvc projectTeamAccessLister
+	teamAccessSvc    teamAccessLister
+}
+
+// accessExplanation is a user's effective access to one project or
+// workspace and every grant that contributes to it.
+type accessExplanation struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Project string        `json:"project,omitempty"`
+	Access  string        `json:"access"`
+	Runs    string        `json:"runs"`
+	Grants  []accessGrant `json:"grants"`
+}
+
+// accessHolder is one user who holds a permission on a workspace through a
+// team.
+type accessHolder struct {
+	User   string `json:"user"`
+	Email  string `json:"email,omitempty"`
+	Team   string `json:"team"`
+	Via    string `json:"via"`
+	Access string `json:"access"`
+	Runs   string `json:"runs"`
+}
+
+// Run executes the access explain command
+func (c *AccessExplainCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("access explain")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.user, "user", "", "Username or email to explain")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name or ID to list holders of -permission for")
+	flags.StringVar(&c.projectID, "project-id", "", "Only explain access within this project (with -user)")
+	flags.StringVar(&c.permission, "permission", "apply", "Run permission to look for with -workspace: read, plan, or apply")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if (c.user == "") == (c.workspace == "") {
+		c.Ui.Error("Error: exactly one of -user or -workspace is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if _, ok := runsRank[c.permission]; !ok {
+		c.Ui.Error("Error: -permission must be one of: read, plan, apply")
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" {
+		c.Ui.Error("Error: -output must be one of: table, json")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	teams, err := listAllTeams(client.Context(), c.teamService(client), c.organization, &tfe.TeamListOptions{
+		Include: []tfe.TeamIncludeOpt{tfe.TeamUsers},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+		return 1
+	}
+
+	if c.workspace != "" {
+		return c.explainWorkspace(client, teams)
+	}
+	return c.explainUser(client, teams)
+}
+
+// explainUser prints the user's organization permissions and effective
+// access to every project and workspace.
+func (c *AccessExplainCommand) explainUser(client *client.Client, teams []*tfe.Team) int {
+	ctx := client.Context()
+
+	memberOf := map[string]bool{}
+	var teamNames []string
+	for _, team := range teams {
+		for _, u := range team.Users {
+			if u.Username == c.user || (u.Email != "" && strings.EqualFold(u.Email, c.user)) {
+				memberOf[team.ID] = true
+				teamNames = append(teamNames, team.Name)
+				break
+			}
+		}
+	}
+	if len(teamNames) == 0 {
+		c.Ui.Error(fmt.Sprintf("Error: %s is not a member of any team in %s", c.user, c.organization))
+		return 1
+	}
+	sort.Strings(teamNames)
+
+	orgPermissions := map[string][]string{}
+	for _, team := range teams {
+		if !memberOf[team.ID] {
+			continue
+		}
+		for _, name := range organizationPermissionNames(team) {
+			orgPermissions[name] = append(orgPermissions[name], team.Name)
+		}
+	}
+
+	projects, err := listAllProjects(ctx, c.projectService(client), c.organization)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing projects: %s", err))
+		return 1
+	}
+	projectNames := map[string]string{}
+	var projectIDs []string
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+		if c.projectID == "" || p.ID == c.projectID {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	inv, err := loadAccessInventory(ctx, c.projectAccessService(client), c.teamAccessService(client), teams, projectIDs, workspaces)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	explanations := []*accessExplanation{}
+	for _, id := range projectIDs {
+		if e := explainGrants(inv.projectGrants[id], memberOf); e != nil {
+			e.Type, e.ID, e.Name = "project", id, projectNames[id]
+			explanations = append(explanations, e)
+		}
+	}
+	sort.SliceStable(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+	for _, ws := range workspaces {
+		if e := explainGrants(inv.workspaceGrants[ws.ID], memberOf); e != nil {
+			e.Type, e.ID, e.Name = "workspace", ws.ID, ws.Name
+			if ws.Project != nil {
+				e.Project = projectNames[ws.Project.ID]
+			}
+			explanations = append(explanations, e)
+		}
+	}
+
+	permissionNames := make([]string, 0, len(orgPermissions))
+	for name := range orgPermissions {
+		permissionNames = append(permissionNames, name)
+	}
+	sort.Strings(permissionNames)
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		permissions := []map[string]interface{}{}
+		for _, name := range permissionNames {
+			permissions = append(permissions, map[string]interface{}{"permission": name, "teams": orgPermissions[name]})
+		}
+		formatter.JSON(map[string]interface{}{
+			"user":                     c.user,
+			"teams":                    teamNames,
+			"organization_permissions": permissions,
+			"resources":                explanations,
+		})
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("%s is a member of: %s", c.user, strings.Join(teamNames, ", ")))
+	if len(permissionNames) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output("Organization permissions:")
+		rows := make([][]string, 0, len(permissionNames))
+		for _, name := range permissionNames {
+			rows = append(rows, []string{name, strings.Join(orgPermissions[name], ", ")})
+		}
+		formatter.Table([]string{"Permission", "Granted By"}, rows)
+	}
+
+	c.Ui.Output("")
+	if len(explanations) == 0 {
+		c.Ui.Output("No project or workspace access")
+		return 0
+	}
+	rows := make([][]string, 0, len(explanations))
+	for _, e := range explanations {
+		project := e.Project
+		if project == "" {
+			project = "-"
+		}
+		rows = append(rows, []string{e.Type, e.Name, project, e.Access, e.Runs, describeGrants(e.Grants)})
+	}
+	formatter.Table([]string{"Type", "Name", "Project", "Access", "Runs", "Granted By"}, rows)
+	return 0
+}
+
+// explainWorkspace prints every user who holds at least -permission on
+// the workspace, with the team and grant that gives it.
+func (c *AccessExplainCommand) explainWorkspace(client *client.Client, teams []*tfe.Team) int {
+	ctx := client.Context()
+
+	ws, err := resolveWorkspaceRef(ctx, c.workspaceService(client), c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	workspaces := []*tfe.Workspace{ws}
+	inv, err := loadAccessInventory(ctx, c.projectAccessService(client), c.teamAccessService(client), teams, workspaceProjectIDs(workspaces), workspaces)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	holders := []*accessHolder{}
+	for _, g := range inv.workspaceGrants[ws.ID] {
+		if !g.allows(c.permission) {
+			continue
+		}
+		team := inv.teams[g.TeamID]
+		if team == nil {
+			continue
+		}
+		for _, u := range team.Users {
+			holders = append(holders, &accessHolder{
+				User:   u.Username,
+				Email:  u.Email,
+				Team:   team.Name,
+				Via:    g.Via,
+				Access: g.Access,
+				Runs:   g.Runs,
+			})
+		}
+	}
+	sort.SliceStable(holders, func(i, j int) bool {
+		if holders[i].User != holders[j].User {
+			return holders[i].User < holders[j].User
+		}
+		return holders[i].Team < holders[j].Team
+	})
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		formatter.JSON(holders)
+		return 0
+	}
+
+	if len(holders) == 0 {
+		c.Ui.Output(fmt.Sprintf("No users can %s in workspace '%s'", c.permission, ws.Name))
+		return 0
+	}
+
+	users := map[string]bool{}
+	rows := make([][]string, 0, len(holders))
+	for _, h := range holders {
+		users[h.User] = true
+		email := h.Email
+		if email == "" {
+			email = "-"
+		}
+		rows = append(rows, []string{h.User, email, h.Team, h.Via, h.Access, h.Runs})
+	}
+	c.Ui.Output(fmt.Sprintf("%d users can %s in workspace '%s':", len(users), c.permission, ws.Name))
+	formatter.Table([]string{"User", "Email", "Team", "Via", "Access", "Runs"}, rows)
+	return 0
+}
+
+// explainGrants returns the effective access given by the grants of the
+// member teams, or nil if none of them grant anything.
+func explainGrants(grants []accessGrant, memberOf map[string]bool) *accessExplanation {
+	var mine []accessGrant
+	for _, g := range grants {
+		if memberOf[g.TeamID] {
+			mine = append(mine, g)
+		}
+	}
+	best, ok := strongestGrant(mine)
+	if !ok {
+		return nil
+	}
+
+	runs := best.Runs
+	for _, g := range mine {
+		if runsRank[g.Runs] > runsRank[runs] {
+			runs = g.Runs
+		}
+	}
+	sort.SliceStable(mine, func(i, j int) bool { return mine[i].rank() > mine[j].rank() })
+	return &accessExplanation{Access: best.Access, Runs: runs, Grants: mine}
+}
+
+// describeGrants renders grants as "team: access (via)", strongest first.
+func describeGrants(grants []accessGrant) string {
+	parts := make([]string, 0, len(grants))
+	for _, g := range grants {
+		parts = append(parts, fmt.Sprintf("%s: %s (%s)", g.Team, g.Access, g.Via))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c *AccessExplainCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *AccessExplainCommand) projectService(client *client.Client) projectLister {
+	if c.projectSvc != nil {
+		return c.projectSvc
+	}
+	return client.Projects
+}
+
+func (c *AccessExplainCommand) workspaceService(client *client.Client) workspaceListRefReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *AccessExplainCommand) projectAccessService(client *client.Client) projectTeamAccessLister {
+	if c.projectAccessSvc != nil {
+		return c.projectAccessSvc
+	}
+	return client.TeamProjectAccess
+}
+
+func (c *AccessExplainCommand) teamAccessService(client *client.Client) teamAccessLister {
+	if c.teamAccessSvc != nil {
+		return c.teamAccessSvc
+	}
+	return client.TeamAccess
+}
+
+// Help returns help text for the access explain command
+func (c *AccessExplainCommand) Help() string {
+	helpText := `
+Usage: hcptf access explain [options]
+
+  Explain effective permissions for access reviews.
+
+  With -user, show the teams the user belongs to, the organization
+  permissions those teams give, and the user's effective access to every
+  project and workspace, with each team grant that contributes to it.
+
+  With -workspace, list every user who holds at least -permission on the
+  workspace (by default, everyone who can apply), with the team and grant
+  that gives it.
+
+  Access is combined from three places:
+
+    organization  Team organization permissions: the owners team, manage
+                  or read all projects, manage or read all workspaces
+    project       Project team access, which also applies to every
+                  workspace in the project
+    workspace     Workspace team access
+
+  The effective access is the strongest grant. Custom access counts by the
+  run permission it gives.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -user=<name>          Username or email to explain
+  -workspace=<name>     Workspace name or ID to list users for
+                        (one of -user or -workspace is required)
+  -project-id=<id>      Only explain access within this project (with -user)
+  -permission=<level>   Run permission to look for with -workspace: read,
+                        plan, or apply (default: apply)
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf access explain -org=my-org -user=alice
+  hcptf access explain -org=my-org -user=alice@example.com -project-id=prj-abc123
+  hcptf access explain -org=my-org -workspace=prod
+  hcptf access explain -org=my-org -workspace=prod -permission=plan -output=json
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the access explain command
+func (c *AccessExplainCommand) Synopsis() string {
+	return "Explain a user's effective permissions or who can apply to a workspace"
+}
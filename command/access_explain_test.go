@@ -0,0 +1,189 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+// accessFixture is an organization with an owners team, a team with
+// project admin, a team with workspace access, and a team that can read
+// every workspace.
+type accessFixture struct {
+	teams         *mockTeamListService
+	projects      *mockProjectListService
+	workspaces    *mockWorkspaceAccessService
+	projectAccess *mockProjectTeamAccessByProject
+	teamAccess    *mockTeamAccessByWorkspace
+}
+
+func newAccessFixture() *accessFixture {
+	owners := &tfe.Team{ID: "team-owners", Name: "owners", Users: []*tfe.User{{Username: "root"}}}
+	platform := &tfe.Team{ID: "team-platform", Name: "platform", OrganizationAccess: &tfe.OrganizationAccess{},
+		Users: []*tfe.User{{Username: "alice", Email: "alice@example.com"}, {Username: "bob"}}}
+	ci := &tfe.Team{ID: "team-ci", Name: "ci", OrganizationAccess: &tfe.OrganizationAccess{ManageRunTasks: true},
+		Users: []*tfe.User{{Username: "alice", Email: "alice@example.com"}, {Username: "carol"}}}
+	auditors := &tfe.Team{ID: "team-aud", Name: "auditors", OrganizationAccess: &tfe.OrganizationAccess{ReadWorkspaces: true},
+		Users: []*tfe.User{{Username: "dave"}}}
+
+	return &accessFixture{
+		teams: &mockTeamListService{response: &tfe.TeamList{Items: []*tfe.Team{owners, platform, ci, auditors}}},
+		projects: &mockProjectListService{response: &tfe.ProjectList{Items: []*tfe.Project{
+			{ID: "prj-apps", Name: "apps"},
+			{ID: "prj-infra", Name: "infra"},
+		}}},
+		workspaces: &mockWorkspaceAccessService{workspaces: []*tfe.Workspace{
+			{ID: "ws-prod", Name: "prod", Project: &tfe.Project{ID: "prj-apps"}},
+			{ID: "ws-net", Name: "network", Project: &tfe.Project{ID: "prj-infra"}},
+			{ID: "ws-dev", Name: "dev", Project: &tfe.Project{ID: "prj-apps"}},
+		}},
+		projectAccess: &mockProjectTeamAccessByProject{access: map[string][]*tfe.TeamProjectAccess{
+			"prj-infra": {{ID: "tprj-1", Access: tfe.TeamProjectAccessAdmin, Team: &tfe.Team{ID: "team-platform"}}},
+		}},
+		teamAccess: &mockTeamAccessByWorkspace{access: map[string][]*tfe.TeamAccess{
+			"ws-prod": {{ID: "tws-1", Access: tfe.AccessWrite, Team: &tfe.Team{ID: "team-ci"}}},
+			"ws-dev":  {{ID: "tws-2", Access: tfe.AccessCustom, Runs: tfe.RunsPermissionPlan, Team: &tfe.Team{ID: "team-ci"}}},
+		}},
+	}
+}
+
+func (f *accessFixture) explainCommand(ui cli.Ui) *AccessExplainCommand {
+	return &AccessExplainCommand{
+		Meta:             newTestMeta(ui),
+		teamSvc:          f.teams,
+		projectSvc:       f.projects,
+		workspaceSvc:     f.workspaces,
+		projectAccessSvc: f.projectAccess,
+		teamAccessSvc:    f.teamAccess,
+	}
+}
+
+func TestAccessExplainRequiresUserOrWorkspace(t *testing.T) {
+	for _, args := range [][]string{
+		{"-org=my-org"},
+		{"-org=my-org", "-user=alice", "-workspace=prod"},
+	} {
+		ui := cli.NewMockUi()
+		if code := newAccessFixture().explainCommand(ui).Run(args); code != 1 {
+			t.Fatalf("%v: expected exit 1, got %d", args, code)
+		}
+		if !strings.Contains(ui.ErrorWriter.String(), "exactly one of -user or -workspace") {
+			t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestAccessExplainUser(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newAccessFixture().explainCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-user=alice@example.com", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		Teams       []string `json:"teams"`
+		Permissions []struct {
+			Permission string   `json:"permission"`
+			Teams      []string `json:"teams"`
+		} `json:"organization_permissions"`
+		Resources []accessExplanation `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+
+	if strings.Join(result.Teams, ",") != "ci,platform" {
+		t.Fatalf("unexpected teams: %v", result.Teams)
+	}
+	if len(result.Permissions) != 1 || result.Permissions[0].Permission != "manage-run-tasks" {
+		t.Fatalf("unexpected organization permissions: %+v", result.Permissions)
+	}
+
+	got := map[string]string{}
+	for _, r := range result.Resources {
+		got[r.Type+"/"+r.Name] = r.Access + " " + r.Runs + " " + describeGrants(r.Grants)
+	}
+	want := map[string]string{
+		"project/infra":     "admin apply platform: admin (project)",
+		"workspace/network": "admin apply platform: admin (project)",
+		"workspace/prod":    "write apply ci: write (workspace)",
+		"workspace/dev":     "custom plan ci: custom (workspace)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected resources: %v", got)
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s: got %q, want %q", k, got[k], w)
+		}
+	}
+}
+
+func TestAccessExplainUnknownUser(t *testing.T) {
+	ui := cli.NewMockUi()
+	if code := newAccessFixture().explainCommand(ui).Run([]string{"-org=my-org", "-user=nobody"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "not a member of any team") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestAccessExplainWorkspace(t *testing.T) {
+	tests := []struct {
+		permission string
+		want       string
+	}{
+		{permission: "apply", want: "alice/ci/workspace,carol/ci/workspace,root/owners/organization"},
+		{permission: "read", want: "alice/ci/workspace,carol/ci/workspace,dave/auditors/organization,root/owners/organization"},
+	}
+
+	for _, tt := range tests {
+		ui := cli.NewMockUi()
+		cmd := newAccessFixture().explainCommand(ui)
+
+		output, code := captureStdout(t, func() int {
+			return cmd.Run([]string{"-org=my-org", "-workspace=prod", "-permission=" + tt.permission, "-output=json"})
+		})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+		}
+
+		var holders []accessHolder
+		if err := json.Unmarshal([]byte(output), &holders); err != nil {
+			t.Fatalf("invalid JSON %q: %v", output, err)
+		}
+		var got []string
+		for _, h := range holders {
+			got = append(got, h.User+"/"+h.Team+"/"+h.Via)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("-permission=%s: got %v, want %s", tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestAccessExplainUserTable(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newAccessFixture().explainCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-user=alice", "-project-id=prj-infra"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "alice is a member of: ci, platform") {
+		t.Fatalf("expected team summary, got %q", ui.OutputWriter.String())
+	}
+	if !strings.Contains(output, "network") || strings.Contains(output, "prod") {
+		t.Fatalf("expected only infra resources, got %q", output)
+	}
+}
@@ -0,0 +1,333 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+const (
+	accessViaOrganization = "organization"
+	accessViaProject      = "project"
+	accessViaWorkspace    = "workspace"
+
+	accessCustom = "custom"
+)
+
+// accessRank orders access levels from weakest to strongest.
+var accessRank = map[string]int{
+	"read":     1,
+	"plan":     2,
+	"write":    3,
+	"maintain": 4,
+	"admin":    5,
+}
+
+// runsRank orders workspace run permissions from weakest to strongest.
+var runsRank = map[string]int{
+	"read":  1,
+	"plan":  2,
+	"apply": 3,
+}
+
+// accessGrant is one team's access to a project or workspace and where it
+// comes from: an organization permission, the project's team access, or
+// the workspace's own team access.
+type accessGrant struct {
+	TeamID string            `json:"team_id"`
+	Team   string            `json:"team"`
+	Via    string            `json:"via"`
+	Access string            `json:"access"`
+	Runs   string            `json:"runs,omitempty"`
+	Custom map[string]string `json:"custom,omitempty"`
+}
+
+// rank orders grants by strength. Custom access ranks by the run
+// permission it gives, just below the fixed level that gives the same.
+func (g accessGrant) rank() int {
+	if g.Access != accessCustom {
+		return accessRank[g.Access] * 2
+	}
+	switch g.Runs {
+	case "apply":
+		return accessRank["write"]*2 - 1
+	case "plan":
+		return accessRank["plan"]*2 - 1
+	}
+	return accessRank["read"]*2 - 1
+}
+
+// allows reports whether the grant gives at least the given run permission.
+func (g accessGrant) allows(runs string) bool {
+	return runsRank[g.Runs] >= runsRank[runs]
+}
+
+// describe returns the access level, with custom permissions listed when
+// expand is set.
+func (g accessGrant) describe(expand bool) string {
+	if g.Access != accessCustom || !expand || len(g.Custom) == 0 {
+		return g.Access
+	}
+	keys := make([]string, 0, len(g.Custom))
+	for k := range g.Custom {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+g.Custom[k])
+	}
+	return fmt.Sprintf("custom(%s)", strings.Join(parts, " "))
+}
+
+// runsForAccess returns the workspace run permission a fixed access level
+// gives.
+func runsForAccess(access string) string {
+	switch access {
+	case "admin", "maintain", "write":
+		return "apply"
+	case "plan":
+		return "plan"
+	}
+	return "read"
+}
+
+// strongestGrant returns the strongest of the grants. Earlier grants win
+// ties.
+func strongestGrant(grants []accessGrant) (accessGrant, bool) {
+	if len(grants) == 0 {
+		return accessGrant{}, false
+	}
+	best := grants[0]
+	for _, g := range grants[1:] {
+		if g.rank() > best.rank() {
+			best = g
+		}
+	}
+	return best, true
+}
+
+// isOwnersTeam reports whether the team is the organization's owners team,
+// which has every permission.
+func isOwnersTeam(team *tfe.Team) bool {
+	return team.Name == "owners"
+}
+
+// organizationPermissionNames lists the organization permissions a team
+// has.
+func organizationPermissionNames(team *tfe.Team) []string {
+	if isOwnersTeam(team) {
+		return []string{"owners"}
+	}
+	a := team.OrganizationAccess
+	if a == nil {
+		return nil
+	}
+	var names []string
+	for _, p := range []struct {
+		name string
+		set  bool
+	}{
+		{"manage-policies", a.ManagePolicies},
+		{"manage-policy-overrides", a.ManagePolicyOverrides},
+		{"manage-workspaces", a.ManageWorkspaces},
+		{"manage-vcs-settings", a.ManageVCSSettings},
+		{"manage-providers", a.ManageProviders},
+		{"manage-modules", a.ManageModules},
+		{"manage-run-tasks", a.ManageRunTasks},
+		{"manage-projects", a.ManageProjects},
+		{"read-workspaces", a.ReadWorkspaces},
+		{"read-projects", a.ReadProjects},
+		{"manage-membership", a.ManageMembership},
+		{"manage-teams", a.ManageTeams},
+		{"manage-organization-access", a.ManageOrganizationAccess},
+		{"access-secret-teams", a.AccessSecretTeams},
+		{"manage-agent-pools", a.ManageAgentPools},
+	} {
+		if p.set {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// organizationAccessGrants returns the access a team's organization
+// permissions give on every project and on every workspace.
+func organizationAccessGrants(team *tfe.Team) (project, workspace *accessGrant) {
+	grant := func(access string) *accessGrant {
+		return &accessGrant{TeamID: team.ID, Team: team.Name, Via: accessViaOrganization, Access: access, Runs: runsForAccess(access)}
+	}
+
+	a := team.OrganizationAccess
+	switch {
+	case isOwnersTeam(team):
+		return grant("admin"), grant("admin")
+	case a == nil:
+		return nil, nil
+	}
+
+	if a.ManageProjects {
+		project, workspace = grant("admin"), grant("admin")
+	} else if a.ReadProjects {
+		project = grant("read")
+	}
+	if workspace == nil {
+		if a.ManageWorkspaces {
+			workspace = grant("admin")
+		} else if a.ReadWorkspaces {
+			workspace = grant("read")
+		}
+	}
+	return project, workspace
+}
+
+// projectAccessGrant converts a project team access, which applies to the
+// project and to every workspace in it.
+func projectAccessGrant(pta *tfe.TeamProjectAccess, teamName string) accessGrant {
+	g := accessGrant{Team: teamName, Via: accessViaProject, Access: string(pta.Access)}
+	if pta.Team != nil {
+		g.TeamID = pta.Team.ID
+	}
+	if pta.Access != tfe.TeamProjectAccessCustom {
+		g.Runs = runsForAccess(g.Access)
+		return g
+	}
+
+	g.Runs = "read"
+	g.Custom = map[string]string{}
+	if p := pta.ProjectAccess; p != nil {
+		g.Custom["project-settings"] = string(p.ProjectSettingsPermission)
+		g.Custom["project-teams"] = string(p.ProjectTeamsPermission)
+	}
+	if w := pta.WorkspaceAccess; w != nil {
+		g.Runs = string(w.WorkspaceRunsPermission)
+		g.Custom["runs"] = string(w.WorkspaceRunsPermission)
+		g.Custom["variables"] = string(w.WorkspaceVariablesPermission)
+		g.Custom["state-versions"] = string(w.WorkspaceStateVersionsPermission)
+		g.Custom["sentinel-mocks"] = string(w.WorkspaceSentinelMocksPermission)
+		g.Custom["create"] = fmt.Sprintf("%t", w.WorkspaceCreatePermission)
+		g.Custom["delete"] = fmt.Sprintf("%t", w.WorkspaceDeletePermission)
+		g.Custom["move"] = fmt.Sprintf("%t", w.WorkspaceMovePermission)
+		g.Custom["locking"] = fmt.Sprintf("%t", w.WorkspaceLockingPermission)
+		g.Custom["run-tasks"] = fmt.Sprintf("%t", w.WorkspaceRunTasksPermission)
+	}
+	return g
+}
+
+// workspaceAccessGrant converts a workspace team access.
+func workspaceAccessGrant(ta *tfe.TeamAccess, teamName string) accessGrant {
+	g := accessGrant{Team: teamName, Via: accessViaWorkspace, Access: string(ta.Access)}
+	if ta.Team != nil {
+		g.TeamID = ta.Team.ID
+	}
+	if ta.Access != tfe.AccessCustom {
+		g.Runs = runsForAccess(g.Access)
+		return g
+	}
+
+	g.Runs = string(ta.Runs)
+	g.Custom = map[string]string{
+		"runs":           string(ta.Runs),
+		"variables":      string(ta.Variables),
+		"state-versions": string(ta.StateVersions),
+		"sentinel-mocks": string(ta.SentinelMocks),
+		"locking":        fmt.Sprintf("%t", ta.WorkspaceLocking),
+		"run-tasks":      fmt.Sprintf("%t", ta.RunTasks),
+	}
+	return g
+}
+
+// accessInventory holds every team's grants on a set of projects and
+// workspaces. Workspace grants include those inherited from the
+// organization and from the workspace's project.
+type accessInventory struct {
+	teams           map[string]*tfe.Team
+	projectGrants   map[string][]accessGrant
+	workspaceGrants map[string][]accessGrant
+}
+
+// loadAccessInventory reads the team access of each project and workspace
+// and combines it with the teams' organization permissions.
+func loadAccessInventory(ctx context.Context, projectAccess projectTeamAccessLister, teamAccess teamAccessLister, teams []*tfe.Team, projectIDs []string, workspaces []*tfe.Workspace) (*accessInventory, error) {
+	inv := &accessInventory{
+		teams:           map[string]*tfe.Team{},
+		projectGrants:   map[string][]accessGrant{},
+		workspaceGrants: map[string][]accessGrant{},
+	}
+	for _, team := range teams {
+		inv.teams[team.ID] = team
+	}
+
+	var orgProject, orgWorkspace []accessGrant
+	for _, team := range teams {
+		p, w := organizationAccessGrants(team)
+		if p != nil {
+			orgProject = append(orgProject, *p)
+		}
+		if w != nil {
+			orgWorkspace = append(orgWorkspace, *w)
+		}
+	}
+
+	for _, id := range projectIDs {
+		list, err := listAllProjectTeamAccess(ctx, projectAccess, id)
+		if err != nil {
+			return nil, fmt.Errorf("listing team access for project %s: %w", id, err)
+		}
+		grants := append([]accessGrant{}, orgProject...)
+		for _, pta := range list {
+			grants = append(grants, projectAccessGrant(pta, inv.teamName(pta.Team)))
+		}
+		inv.projectGrants[id] = grants
+	}
+
+	for _, ws := range workspaces {
+		list, err := listAllTeamAccess(ctx, teamAccess, ws.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing team access for workspace %s: %w", ws.Name, err)
+		}
+		grants := append([]accessGrant{}, orgWorkspace...)
+		if ws.Project != nil {
+			for _, g := range inv.projectGrants[ws.Project.ID] {
+				if g.Via == accessViaProject {
+					grants = append(grants, g)
+				}
+			}
+		}
+		for _, ta := range list {
+			grants = append(grants, workspaceAccessGrant(ta, inv.teamName(ta.Team)))
+		}
+		inv.workspaceGrants[ws.ID] = grants
+	}
+	return inv, nil
+}
+
+func (inv *accessInventory) teamName(team *tfe.Team) string {
+	if team == nil {
+		return ""
+	}
+	if t, ok := inv.teams[team.ID]; ok {
+		return t.Name
+	}
+	if team.Name != "" {
+		return team.Name
+	}
+	return team.ID
+}
+
+// workspaceProjectIDs returns the distinct project IDs of the workspaces.
+func workspaceProjectIDs(workspaces []*tfe.Workspace) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, ws := range workspaces {
+		if ws.Project == nil || seen[ws.Project.ID] {
+			continue
+		}
+		seen[ws.Project.ID] = true
+		ids = append(ids, ws.Project.ID)
+	}
+	return ids
+}
@@ -0,0 +1,288 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// AccessMatrixCommand is a command to export a team-by-project or
+// team-by-workspace access matrix
+type AccessMatrixCommand struct {
+	Meta
+	organization     string
+	projectID        string
+	columns          string
+	expandCustom     bool
+	format           string
+	teamSvc          teamLister
+	projectSvc       projectLister
+	workspaceSvc     workspaceLister
+	projectAccessSvc projectTeamAccessLister
+	teamAccessSvc    teamAccessLister
+}
+
+// accessMatrixColumn is one project or workspace in the matrix.
+type accessMatrixColumn struct {
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+}
+
+// accessMatrixRow is one team's effective access to every column. Cells
+// are keyed by column ID and omitted where the team has no access.
+type accessMatrixRow struct {
+	TeamID string                  `json:"team_id"`
+	Team   string                  `json:"team"`
+	Cells  map[string]*accessGrant `json:"cells"`
+}
+
+// Run executes the access matrix command
+func (c *AccessMatrixCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("access matrix")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "project-id", "", "Only include this project and its workspaces")
+	flags.StringVar(&c.columns, "columns", "workspaces", "Matrix columns: workspaces or projects")
+	flags.BoolVar(&c.expandCustom, "expand-custom", false, "Show the individual permissions of custom access")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.columns != "workspaces" && c.columns != "projects" {
+		c.Ui.Error("Error: -columns must be one of: workspaces, projects")
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" && c.format != "csv" {
+		c.Ui.Error("Error: -output must be one of: table, json, csv")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	teams, err := listAllTeams(ctx, c.teamService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+		return 1
+	}
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+
+	projects, err := listAllProjects(ctx, c.projectService(client), c.organization)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing projects: %s", err))
+		return 1
+	}
+	projectNames := map[string]string{}
+	var selected []*tfe.Project
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+		if c.projectID == "" || p.ID == c.projectID {
+			selected = append(selected, p)
+		}
+	}
+	if c.projectID != "" && len(selected) == 0 {
+		c.Ui.Error(fmt.Sprintf("Error: project %s not found in %s", c.projectID, c.organization))
+		return 1
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+
+	projectIDs := make([]string, 0, len(selected))
+	for _, p := range selected {
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	var workspaces []*tfe.Workspace
+	if c.columns == "workspaces" {
+		workspaces, err = listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+			ProjectID: c.projectID,
+		})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+			return 1
+		}
+		sort.SliceStable(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+		// Project grants only matter through the workspaces' own projects.
+		projectIDs = workspaceProjectIDs(workspaces)
+	}
+
+	inv, err := loadAccessInventory(ctx, c.projectAccessService(client), c.teamAccessService(client), teams, projectIDs, workspaces)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	var columns []accessMatrixColumn
+	grants := map[string][]accessGrant{}
+	if c.columns == "projects" {
+		for _, p := range selected {
+			columns = append(columns, accessMatrixColumn{Type: "project", ID: p.ID, Name: p.Name})
+			grants[p.ID] = inv.projectGrants[p.ID]
+		}
+	} else {
+		for _, ws := range workspaces {
+			col := accessMatrixColumn{Type: "workspace", ID: ws.ID, Name: ws.Name}
+			if ws.Project != nil {
+				col.Project = projectNames[ws.Project.ID]
+			}
+			columns = append(columns, col)
+			grants[ws.ID] = inv.workspaceGrants[ws.ID]
+		}
+	}
+
+	rows := buildAccessMatrix(teams, columns, grants)
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		if columns == nil {
+			columns = []accessMatrixColumn{}
+		}
+		formatter.JSON(map[string]interface{}{
+			"columns": columns,
+			"teams":   rows,
+		})
+		return 0
+	}
+
+	if len(columns) == 0 {
+		if c.format == "table" {
+			c.Ui.Output(fmt.Sprintf("No %s found", c.columns))
+		}
+		return 0
+	}
+
+	headers := []string{"Team"}
+	for _, col := range columns {
+		headers = append(headers, col.Name)
+	}
+	table := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		line := []string{row.Team}
+		for _, col := range columns {
+			cell := "-"
+			if g := row.Cells[col.ID]; g != nil {
+				cell = g.describe(c.expandCustom)
+			}
+			line = append(line, cell)
+		}
+		table = append(table, line)
+	}
+	formatter.Table(headers, table)
+	return 0
+}
+
+// buildAccessMatrix returns each team's strongest grant on every column.
+func buildAccessMatrix(teams []*tfe.Team, columns []accessMatrixColumn, grants map[string][]accessGrant) []*accessMatrixRow {
+	rows := make([]*accessMatrixRow, 0, len(teams))
+	for _, team := range teams {
+		row := &accessMatrixRow{TeamID: team.ID, Team: team.Name, Cells: map[string]*accessGrant{}}
+		for _, col := range columns {
+			var mine []accessGrant
+			for _, g := range grants[col.ID] {
+				if g.TeamID == team.ID {
+					mine = append(mine, g)
+				}
+			}
+			if best, ok := strongestGrant(mine); ok {
+				row.Cells[col.ID] = &best
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (c *AccessMatrixCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *AccessMatrixCommand) projectService(client *client.Client) projectLister {
+	if c.projectSvc != nil {
+		return c.projectSvc
+	}
+	return client.Projects
+}
+
+func (c *AccessMatrixCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *AccessMatrixCommand) projectAccessService(client *client.Client) projectTeamAccessLister {
+	if c.projectAccessSvc != nil {
+		return c.projectAccessSvc
+	}
+	return client.TeamProjectAccess
+}
+
+func (c *AccessMatrixCommand) teamAccessService(client *client.Client) teamAccessLister {
+	if c.teamAccessSvc != nil {
+		return c.teamAccessSvc
+	}
+	return client.TeamAccess
+}
+
+// Help returns help text for the access matrix command
+func (c *AccessMatrixCommand) Help() string {
+	helpText := `
+Usage: hcptf access matrix [options]
+
+  Export every team's access as a grid for access reviews, with teams as
+  rows and workspaces (or projects) as columns.
+
+  Each cell is the team's effective access: the strongest of its
+  organization permissions, its project team access, and (for workspaces)
+  its workspace team access. Cells are read, plan, write, maintain, admin,
+  or custom, and "-" where the team has no access. With -expand-custom,
+  custom cells list the individual permissions, for example
+  custom(runs=plan state-versions=read ...).
+
+  JSON output lists the columns and, for each team, the grant behind each
+  cell including where it comes from and its custom permissions.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -project-id=<id>      Only include this project and its workspaces
+  -columns=<kind>       Matrix columns: workspaces (default) or projects
+  -expand-custom        Show the individual permissions of custom access
+  -output=<format>      Output format: table (default), json, or csv
+
+Example:
+
+  hcptf access matrix -org=my-org
+  hcptf access matrix -org=my-org -columns=projects -output=csv > access.csv
+  hcptf access matrix -org=my-org -project-id=prj-abc123 -expand-custom
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the access matrix command
+func (c *AccessMatrixCommand) Synopsis() string {
+	return "Export a team-by-workspace or team-by-project access matrix"
+}
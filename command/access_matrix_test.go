@@ -0,0 +1,126 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func (f *accessFixture) matrixCommand(ui cli.Ui) *AccessMatrixCommand {
+	return &AccessMatrixCommand{
+		Meta:             newTestMeta(ui),
+		teamSvc:          f.teams,
+		projectSvc:       f.projects,
+		workspaceSvc:     f.workspaces,
+		projectAccessSvc: f.projectAccess,
+		teamAccessSvc:    f.teamAccess,
+	}
+}
+
+func TestAccessMatrixRejectsInvalidColumns(t *testing.T) {
+	ui := cli.NewMockUi()
+	if code := newAccessFixture().matrixCommand(ui).Run([]string{"-org=my-org", "-columns=teams"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-columns must be one of") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestAccessMatrixWorkspaces(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newAccessFixture().matrixCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		Columns []accessMatrixColumn `json:"columns"`
+		Teams   []accessMatrixRow    `json:"teams"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+
+	var names []string
+	for _, col := range result.Columns {
+		names = append(names, col.Name)
+	}
+	if strings.Join(names, ",") != "dev,network,prod" {
+		t.Fatalf("unexpected columns: %v", names)
+	}
+
+	got := map[string]string{}
+	for _, row := range result.Teams {
+		for id, g := range row.Cells {
+			got[row.Team+"/"+id] = g.Access + " " + g.Via
+		}
+	}
+	want := map[string]string{
+		"auditors/ws-dev":  "read organization",
+		"auditors/ws-net":  "read organization",
+		"auditors/ws-prod": "read organization",
+		"owners/ws-dev":    "admin organization",
+		"owners/ws-net":    "admin organization",
+		"owners/ws-prod":   "admin organization",
+		"platform/ws-net":  "admin project",
+		"ci/ws-prod":       "write workspace",
+		"ci/ws-dev":        "custom workspace",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected cells: %v", got)
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s: got %q, want %q", k, got[k], w)
+		}
+	}
+}
+
+func TestAccessMatrixProjectsCSV(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newAccessFixture().matrixCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-columns=projects", "-output=csv"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	want := strings.Join([]string{
+		"Team,apps,infra",
+		"auditors,-,-",
+		"ci,-,-",
+		"owners,admin,admin",
+		"platform,-,admin",
+		"",
+	}, "\n")
+	if output != want {
+		t.Fatalf("unexpected CSV:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+func TestAccessMatrixExpandCustom(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newAccessFixture().matrixCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-project-id=prj-apps", "-expand-custom", "-output=csv"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if strings.Contains(output, "network") {
+		t.Fatalf("expected only apps workspaces, got %q", output)
+	}
+	if !strings.Contains(output, "custom(locking=false run-tasks=false runs=plan") {
+		t.Fatalf("expected expanded custom access, got %q", output)
+	}
+}
@@ -0,0 +1,207 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/config"
+)
+
+// accessTemplatePermissions lists the custom permissions a template team
+// can set, the values each accepts, and the value used when it is left
+// out. The keys match those of projectAccessGrant so template grants can
+// be compared with existing ones.
+var accessTemplatePermissions = []struct {
+	key      string
+	values   []string
+	fallback string
+	value    func(*config.AccessTemplateTeam) string
+}{
+	{"project-settings", []string{"read", "update", "delete"}, "read", func(t *config.AccessTemplateTeam) string { return t.ProjectSettings }},
+	{"project-teams", []string{"none", "read", "manage"}, "none", func(t *config.AccessTemplateTeam) string { return t.ProjectTeams }},
+	{"runs", []string{"read", "plan", "apply"}, "read", func(t *config.AccessTemplateTeam) string { return t.Runs }},
+	{"variables", []string{"none", "read", "write"}, "none", func(t *config.AccessTemplateTeam) string { return t.Variables }},
+	{"state-versions", []string{"none", "read-outputs", "read", "write"}, "none", func(t *config.AccessTemplateTeam) string { return t.StateVersions }},
+	{"sentinel-mocks", []string{"none", "read"}, "none", func(t *config.AccessTemplateTeam) string { return t.SentinelMocks }},
+	{"create", nil, "false", func(t *config.AccessTemplateTeam) string { return boolPermission(t.CreateWorkspace) }},
+	{"delete", nil, "false", func(t *config.AccessTemplateTeam) string { return boolPermission(t.DeleteWorkspace) }},
+	{"move", nil, "false", func(t *config.AccessTemplateTeam) string { return boolPermission(t.MoveWorkspace) }},
+	{"locking", nil, "false", func(t *config.AccessTemplateTeam) string { return boolPermission(t.Locking) }},
+	{"run-tasks", nil, "false", func(t *config.AccessTemplateTeam) string { return boolPermission(t.RunTasks) }},
+}
+
+// boolPermission returns a set boolean permission as "true", and an unset
+// one as empty so it takes the default.
+func boolPermission(set bool) string {
+	if set {
+		return "true"
+	}
+	return ""
+}
+
+// accessTemplate returns the named access template. Templates in file,
+// when one is given, take precedence over those in the CLI configuration.
+func (m *Meta) accessTemplate(file, name string) (*config.AccessTemplate, error) {
+	if file != "" {
+		templates, err := config.LoadAccessTemplates(file)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl, ok := templates[name]; ok {
+			return tmpl, nil
+		}
+	}
+
+	cfg, err := m.Config()
+	if err != nil {
+		return nil, err
+	}
+	if tmpl, ok := cfg.AccessTemplates[name]; ok {
+		return tmpl, nil
+	}
+
+	if file != "" {
+		return nil, fmt.Errorf("access template %q not found in %s or %s", name, file, config.GetConfigPath())
+	}
+	return nil, fmt.Errorf("access template %q not found in %s", name, config.GetConfigPath())
+}
+
+// accessTemplateGrants validates a template and returns the grant it makes
+// to each team, sorted by team name. Every team must exist, so a typo
+// stops the command before anything is changed.
+func accessTemplateGrants(tmpl *config.AccessTemplate, teamIDs map[string]string) ([]accessGrant, error) {
+	grants := make([]accessGrant, 0, len(tmpl.Teams))
+	for _, team := range tmpl.Teams {
+		id, ok := teamIDs[team.Name]
+		if !ok {
+			return nil, fmt.Errorf("access template %q: team %q not found", tmpl.Name, team.Name)
+		}
+		g, err := accessTemplateGrant(team)
+		if err != nil {
+			return nil, fmt.Errorf("access template %q: team %q: %w", tmpl.Name, team.Name, err)
+		}
+		g.TeamID = id
+		grants = append(grants, g)
+	}
+	sort.SliceStable(grants, func(i, j int) bool { return grants[i].Team < grants[j].Team })
+	return grants, nil
+}
+
+// accessTemplateGrant converts one template team to the grant it makes.
+// Custom permissions that are left out take the API's defaults.
+func accessTemplateGrant(team *config.AccessTemplateTeam) (accessGrant, error) {
+	g := accessGrant{Team: team.Name, Via: accessViaProject, Access: team.Access}
+
+	switch team.Access {
+	case "read", "write", "maintain", "admin":
+		for _, p := range accessTemplatePermissions {
+			if p.value(team) != "" {
+				return accessGrant{}, fmt.Errorf("%s only applies to custom access", p.key)
+			}
+		}
+		g.Runs = runsForAccess(g.Access)
+		return g, nil
+	case accessCustom:
+	default:
+		return accessGrant{}, fmt.Errorf("invalid access level %q. Must be one of: read, write, maintain, admin, custom", team.Access)
+	}
+
+	g.Custom = map[string]string{}
+	for _, p := range accessTemplatePermissions {
+		value := p.value(team)
+		if value == "" {
+			value = p.fallback
+		} else if p.values != nil && !validPermission(p.values, value) {
+			return accessGrant{}, fmt.Errorf("invalid %s permission %q. Must be one of: %s", p.key, value, strings.Join(p.values, ", "))
+		}
+		g.Custom[p.key] = value
+	}
+	g.Runs = g.Custom["runs"]
+	return g, nil
+}
+
+// validPermission reports whether value is one of the accepted values.
+func validPermission(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sameProjectAccess reports whether an existing grant gives exactly what a
+// template grant does.
+func sameProjectAccess(current, want accessGrant) bool {
+	if current.Access != want.Access {
+		return false
+	}
+	if want.Access != accessCustom {
+		return true
+	}
+	for key, value := range want.Custom {
+		if current.Custom[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// accessTemplatePermissionOptions returns the custom project and workspace
+// permissions of a grant, or nil for a fixed access level.
+func accessTemplatePermissionOptions(g accessGrant) (*tfe.TeamProjectAccessProjectPermissionsOptions, *tfe.TeamProjectAccessWorkspacePermissionsOptions) {
+	if g.Access != accessCustom {
+		return nil, nil
+	}
+
+	settings := tfe.ProjectSettingsPermissionType(g.Custom["project-settings"])
+	teams := tfe.ProjectTeamsPermissionType(g.Custom["project-teams"])
+	runs := tfe.WorkspaceRunsPermissionType(g.Custom["runs"])
+	variables := tfe.WorkspaceVariablesPermissionType(g.Custom["variables"])
+	stateVersions := tfe.WorkspaceStateVersionsPermissionType(g.Custom["state-versions"])
+	sentinelMocks := tfe.WorkspaceSentinelMocksPermissionType(g.Custom["sentinel-mocks"])
+
+	project := &tfe.TeamProjectAccessProjectPermissionsOptions{
+		Settings: &settings,
+		Teams:    &teams,
+	}
+	workspace := &tfe.TeamProjectAccessWorkspacePermissionsOptions{
+		Runs:          &runs,
+		Variables:     &variables,
+		StateVersions: &stateVersions,
+		SentinelMocks: &sentinelMocks,
+		Create:        tfe.Bool(g.Custom["create"] == "true"),
+		Delete:        tfe.Bool(g.Custom["delete"] == "true"),
+		Move:          tfe.Bool(g.Custom["move"] == "true"),
+		Locking:       tfe.Bool(g.Custom["locking"] == "true"),
+		RunTasks:      tfe.Bool(g.Custom["run-tasks"] == "true"),
+	}
+	return project, workspace
+}
+
+// accessTemplateAddOptions returns the options to grant a template team
+// access to a project.
+func accessTemplateAddOptions(projectID string, g accessGrant) tfe.TeamProjectAccessAddOptions {
+	project, workspace := accessTemplatePermissionOptions(g)
+	return tfe.TeamProjectAccessAddOptions{
+		Access:          tfe.TeamProjectAccessType(g.Access),
+		ProjectAccess:   project,
+		WorkspaceAccess: workspace,
+		Team:            &tfe.Team{ID: g.TeamID},
+		Project:         &tfe.Project{ID: projectID},
+	}
+}
+
+// accessTemplateUpdateOptions returns the options to change an existing
+// project team access to match a template team.
+func accessTemplateUpdateOptions(g accessGrant) tfe.TeamProjectAccessUpdateOptions {
+	access := tfe.TeamProjectAccessType(g.Access)
+	project, workspace := accessTemplatePermissionOptions(g)
+	return tfe.TeamProjectAccessUpdateOptions{
+		Access:          &access,
+		ProjectAccess:   project,
+		WorkspaceAccess: workspace,
+	}
+}
@@ -13,3 +13,11 @@ type agentPoolCreator interface {
 type agentPoolDeleter interface {
 	Delete(ctx context.Context, agentPoolID string) error
 }
+
+type agentPoolLister interface {
+	List(ctx context.Context, organization string, options *tfe.AgentPoolListOptions) (*tfe.AgentPoolList, error)
+}
+
+type agentTokenLister interface {
+	List(ctx context.Context, agentPoolID string) (*tfe.AgentTokenList, error)
+}
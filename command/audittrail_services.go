@@ -0,0 +1,11 @@
+package command
+
+import (
+	"context"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+type auditTrailLister interface {
+	List(ctx context.Context, options *tfe.AuditTrailListOptions) (*tfe.AuditTrailList, error)
+}
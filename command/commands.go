@@ -107,6 +107,50 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"workspace graph": func() (cli.Command, error) {
+			return &WorkspaceGraphCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"workspace cascade": func() (cli.Command, error) {
+			return &WorkspaceCascadeCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"workspace report": func() (cli.Command, error) {
+			return &WorkspaceReportCommand{
+				Meta: *meta,
+			}, nil
+		},
+
+		// Workspace Remote State Consumer commands (explicit state sharing)
+		"workspace remote-state-consumers": func() (cli.Command, error) {
+			return &NamespaceCommand{
+				Meta:     *meta,
+				name:     "workspace remote-state-consumers",
+				synopsis: "Manage workspaces allowed to read a workspace's state",
+			}, nil
+		},
+		"workspace remote-state-consumers list": func() (cli.Command, error) {
+			return &WorkspaceRemoteStateConsumersListCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"workspace remote-state-consumers add": func() (cli.Command, error) {
+			return &WorkspaceRemoteStateConsumersAddCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"workspace remote-state-consumers remove": func() (cli.Command, error) {
+			return &WorkspaceRemoteStateConsumersRemoveCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"workspace remote-state-consumers replace": func() (cli.Command, error) {
+			return &WorkspaceRemoteStateConsumersReplaceCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Run commands
 		"run list": func() (cli.Command, error) {
@@ -271,6 +315,31 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"variable import": func() (cli.Command, error) {
+			return &VariableImportCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"variable export": func() (cli.Command, error) {
+			return &VariableExportCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"variable effective": func() (cli.Command, error) {
+			return &VariableEffectiveCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"variable search": func() (cli.Command, error) {
+			return &VariableSearchCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"variable audit-secrets": func() (cli.Command, error) {
+			return &VariableAuditSecretsCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Variable Set commands
 		"variableset list": func() (cli.Command, error) {
@@ -328,6 +397,11 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"variableset sync": func() (cli.Command, error) {
+			return &VariableSetSyncCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Variable Set Variable commands
 		"variableset variable list": func() (cli.Command, error) {
@@ -382,6 +456,11 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"team sync": func() (cli.Command, error) {
+			return &TeamSyncCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Policy commands
 		"policy list": func() (cli.Command, error) {
@@ -610,6 +689,11 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"project apply-access-template": func() (cli.Command, error) {
+			return &ProjectApplyAccessTemplateCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// State commands
 		"state list": func() (cli.Command, error) {
@@ -632,6 +716,41 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"state diff": func() (cli.Command, error) {
+			return &StateDiffCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state resources": func() (cli.Command, error) {
+			return &StateResourcesCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state show": func() (cli.Command, error) {
+			return &StateShowCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state push": func() (cli.Command, error) {
+			return &StatePushCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state rollback": func() (cli.Command, error) {
+			return &StateRollbackCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state search": func() (cli.Command, error) {
+			return &StateSearchCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"state backup": func() (cli.Command, error) {
+			return &StateBackupCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Notification commands
 		"notification list": func() (cli.Command, error) {
@@ -895,6 +1014,18 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		// Access review commands (effective permissions across teams)
+		"access explain": func() (cli.Command, error) {
+			return &AccessExplainCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"access matrix": func() (cli.Command, error) {
+			return &AccessMatrixCommand{
+				Meta: *meta,
+			}, nil
+		},
+
 		// Audit Trail commands (compliance and security monitoring)
 		"audittrail list": func() (cli.Command, error) {
 			return &AuditTrailListCommand{
@@ -1314,6 +1445,18 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"team token rotate": func() (cli.Command, error) {
+			return &TeamTokenRotateCommand{
+				Meta: *meta,
+			}, nil
+		},
+
+		// Token inventory across users, teams, the organization, and agent pools
+		"token report": func() (cli.Command, error) {
+			return &TokenReportCommand{
+				Meta: *meta,
+			}, nil
+		},
 
 		// Organization Membership commands (manage organization membership)
 		"organization membership": func() (cli.Command, error) {
@@ -1343,6 +1486,21 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 				Meta: *meta,
 			}, nil
 		},
+		"organization membership invite-bulk": func() (cli.Command, error) {
+			return &OrganizationMembershipInviteBulkCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"organization membership stale": func() (cli.Command, error) {
+			return &OrganizationMembershipStaleCommand{
+				Meta: *meta,
+			}, nil
+		},
+		"organization membership remove-bulk": func() (cli.Command, error) {
+			return &OrganizationMembershipRemoveBulkCommand{
+				Meta: *meta,
+			}, nil
+		},
 		// Organization Member commands (detailed member operations)
 		"organization member": func() (cli.Command, error) {
 			return &NamespaceCommand{
@@ -1632,6 +1790,13 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		// Lint commands (governance checks for organization settings)
+		"lint": func() (cli.Command, error) {
+			return &LintCommand{
+				Meta: *meta,
+			}, nil
+		},
+
 		// VCS Event commands (VCS integration debugging and monitoring)
 		"vcsevent list": func() (cli.Command, error) {
 			return &VCSEventListCommand{
@@ -1690,6 +1855,7 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 	}
 
 	namespaceSynopses := map[string]string{
+		"access":           "Review team access",
 		"costestimate":     "Manage cost estimates",
 		"featureset":       "Manage feature sets",
 		"githubapp":        "Manage GitHub app installations",
@@ -1735,6 +1901,7 @@ func Commands(meta *Meta) map[string]cli.CommandFactory {
 		"state":            "Manage Terraform states",
 		"team":             "Manage teams",
 		"team token":       "Manage team tokens",
+		"token":            "Report on API tokens",
 		"user token":       "Manage user tokens",
 		"variable":         "Manage workspace variables",
 		"variableset":      "Manage variable sets",
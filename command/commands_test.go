@@ -579,6 +579,7 @@ func commandNameToFile(name string) string {
 		fileBase string
 	}
 	nsPrefixes := []nsMapping{
+		{"workspace remote-state-consumers", "workspaceremotestateconsumers"},
 		{"registry provider platform", "registryproviderplatform"},
 		{"registry provider version", "registryproviderversion"},
 		{"organization membership", "organizationmembership"},
@@ -631,19 +632,20 @@ func TestCommandNamesMatchFiles(t *testing.T) {
 	// Namespace-only parent commands that use NamespaceCommand and have no
 	// dedicated file (they are generated dynamically in Commands()).
 	namespaceOnly := map[string]bool{
-		"team access":             true,
-		"project teamaccess":      true,
-		"policyset outcome":       true,
-		"policyset parameter":     true,
-		"audittrail token":        true,
-		"user token":              true,
-		"team token":              true,
-		"organization membership": true,
-		"organization member":     true,
-		"organization token":      true,
-		"organization tag":        true,
-		"workspace tag":           true,
-		"workspace resource":      true,
+		"team access":                      true,
+		"project teamaccess":               true,
+		"policyset outcome":                true,
+		"policyset parameter":              true,
+		"audittrail token":                 true,
+		"user token":                       true,
+		"team token":                       true,
+		"organization membership":          true,
+		"organization member":              true,
+		"organization token":               true,
+		"organization tag":                 true,
+		"workspace tag":                    true,
+		"workspace resource":               true,
+		"workspace remote-state-consumers": true,
 	}
 
 	// Auto-generated single-word namespace commands produced by the loop at the
@@ -0,0 +1,29 @@
+package command
+
+import (
+	"sync"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// forEachWorkspace calls fn for every workspace, running at most limit calls
+// at once, and returns when all calls have finished. fn must be safe for
+// concurrent use.
+func forEachWorkspace(workspaces []*tfe.Workspace, limit int, fn func(ws *tfe.Workspace)) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for _, ws := range workspaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ws *tfe.Workspace) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ws)
+		}(ws)
+	}
+	wg.Wait()
+}
@@ -9,5 +9,7 @@ import (
 
 func TestMain(m *testing.M) {
 	_ = os.Setenv(config.DisableEnvFileVariable, "1")
+	// Never prompt for variable values, even when tests run in a terminal.
+	stdinIsTerminal = func() bool { return false }
 	os.Exit(m.Run())
 }
@@ -0,0 +1,506 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	lintRuleNamePattern         = "name_pattern"
+	lintRuleRequiredTag         = "required_tag"
+	lintRuleNoAutoApply         = "no_auto_apply"
+	lintRuleMinTerraformVersion = "min_terraform_version"
+	lintRuleSensitiveVariable   = "sensitive_variable"
+	lintRuleAssessmentsEnabled  = "assessments_enabled"
+
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+	lintSeverityInfo    = "info"
+)
+
+// lintSeverityRank orders severities so -fail-on can compare them.
+var lintSeverityRank = map[string]int{
+	lintSeverityInfo:    1,
+	lintSeverityWarning: 2,
+	lintSeverityError:   3,
+}
+
+// LintCommand is a command to check organization resources against lint rules
+type LintCommand struct {
+	Meta
+	organization string
+	rulesFile    string
+	failOn       string
+	format       string
+	workspaceSvc workspaceLister
+	projectSvc   projectLister
+	variableSvc  variableLister
+	varSetSvc    variableSetLister
+	varSetVarSvc variableSetVariableLister
+}
+
+// lintRuleFile is the decoded form of a lint rules file.
+type lintRuleFile struct {
+	Rules []*lintRule `hcl:"rule,block"`
+}
+
+// lintRule is a single rule from a lint rules file.
+type lintRule struct {
+	Name     string `hcl:"name,label"`
+	Type     string `hcl:"type"`
+	Severity string `hcl:"severity,optional"`
+	Target   string `hcl:"target,optional"`
+	Pattern  string `hcl:"pattern,optional"`
+	Match    string `hcl:"match,optional"`
+	Tag      string `hcl:"tag,optional"`
+	Version  string `hcl:"version,optional"`
+
+	pattern *regexp.Regexp
+	match   *regexp.Regexp
+}
+
+// lintViolation is a single rule failure.
+type lintViolation struct {
+	Severity     string `json:"severity"`
+	Rule         string `json:"rule"`
+	ResourceType string `json:"resource_type"`
+	Resource     string `json:"resource"`
+	Message      string `json:"message"`
+}
+
+// Run executes the lint command
+func (c *LintCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("lint")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.rulesFile, "rules", "lint.hcl", "Path to the lint rules file")
+	flags.StringVar(&c.failOn, "fail-on", lintSeverityError, "Lowest severity that causes a non-zero exit: error, warning, or info")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if _, ok := lintSeverityRank[c.failOn]; !ok {
+		c.Ui.Error("Error: -fail-on must be one of: error, warning, info")
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" && c.format != "csv" {
+		c.Ui.Error("Error: -output must be one of: table, json, csv")
+		return 1
+	}
+
+	rules, err := loadLintRules(c.rulesFile)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading lint rules: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	violations, err := c.lint(client, rules)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error running lint: %s", err))
+		return 1
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		if violations == nil {
+			violations = []lintViolation{}
+		}
+		formatter.JSON(violations)
+	} else if len(violations) == 0 && c.format == "table" {
+		c.Ui.Output(fmt.Sprintf("No lint violations found (%d rules)", len(rules)))
+	} else {
+		// CSV always gets a header so CI can parse a clean result.
+		headers := []string{"Severity", "Rule", "Resource Type", "Resource", "Message"}
+		rows := make([][]string, 0, len(violations))
+		for _, v := range violations {
+			rows = append(rows, []string{v.Severity, v.Rule, v.ResourceType, v.Resource, v.Message})
+		}
+		formatter.Table(headers, rows)
+	}
+
+	threshold := lintSeverityRank[c.failOn]
+	for _, v := range violations {
+		if lintSeverityRank[v.Severity] >= threshold {
+			return 1
+		}
+	}
+	return 0
+}
+
+// loadLintRules reads and validates a lint rules file.
+func loadLintRules(filename string) ([]*lintRule, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualPath := filename
+	switch filepath.Ext(virtualPath) {
+	case ".hcl", ".json":
+	default:
+		virtualPath += ".hcl"
+	}
+
+	var file lintRuleFile
+	if err := hclsimple.Decode(virtualPath, data, nil, &file); err != nil {
+		return nil, err
+	}
+
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("%s defines no rules", filename)
+	}
+
+	seen := map[string]bool{}
+	for _, rule := range file.Rules {
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("rule %q is defined more than once", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return file.Rules, nil
+}
+
+// validate checks a rule's attributes and compiles its patterns.
+func (r *lintRule) validate() error {
+	if r.Severity == "" {
+		r.Severity = lintSeverityError
+	}
+	if _, ok := lintSeverityRank[r.Severity]; !ok {
+		return fmt.Errorf("severity must be one of: error, warning, info")
+	}
+
+	if r.Match != "" {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return fmt.Errorf("invalid match: %w", err)
+		}
+		r.match = re
+	}
+
+	// Only name_pattern rules can check projects; every other rule
+	// checks workspaces.
+	if r.Target != "" && r.Type != lintRuleNamePattern {
+		return fmt.Errorf("target only applies to name_pattern rules")
+	}
+
+	switch r.Type {
+	case lintRuleNamePattern:
+		if r.Target == "" {
+			r.Target = "workspace"
+		}
+		if r.Target != "workspace" && r.Target != "project" {
+			return fmt.Errorf("target must be workspace or project")
+		}
+		if r.Pattern == "" {
+			return fmt.Errorf("pattern is required")
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		r.pattern = re
+	case lintRuleRequiredTag:
+		if r.Tag == "" {
+			return fmt.Errorf("tag is required")
+		}
+	case lintRuleMinTerraformVersion:
+		if _, ok := parseTerraformVersion(r.Version); !ok {
+			return fmt.Errorf("invalid version %q", r.Version)
+		}
+	case lintRuleSensitiveVariable:
+		if r.Pattern == "" {
+			return fmt.Errorf("pattern is required")
+		}
+		if _, err := path.Match(r.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	case lintRuleNoAutoApply, lintRuleAssessmentsEnabled:
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+
+	return nil
+}
+
+// appliesTo reports whether a workspace rule covers the named workspace.
+func (r *lintRule) appliesTo(name string) bool {
+	return r.match == nil || r.match.MatchString(name)
+}
+
+// lint fetches only the resources the rules need and evaluates every rule.
+func (c *LintCommand) lint(client *client.Client, rules []*lintRule) ([]lintViolation, error) {
+	ctx := client.Context()
+
+	needProjects := false
+	needVariables := false
+	for _, rule := range rules {
+		if rule.Type == lintRuleNamePattern && rule.Target == "project" {
+			needProjects = true
+		}
+		if rule.Type == lintRuleSensitiveVariable {
+			needVariables = true
+		}
+	}
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		Include: []tfe.WSIncludeOpt{tfe.WSEffectiveTagBindings},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing workspaces: %w", err)
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+
+	var projects []*tfe.Project
+	if needProjects {
+		projects, err = listAllProjects(ctx, c.projectService(client), c.organization)
+		if err != nil {
+			return nil, fmt.Errorf("listing projects: %w", err)
+		}
+		sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	}
+
+	workspaceVars := map[string][]*tfe.Variable{}
+	var varSets []*tfe.VariableSet
+	varSetVars := map[string][]*tfe.VariableSetVariable{}
+	if needVariables {
+		for _, ws := range workspaces {
+			vars, err := listAllVariables(ctx, c.variableService(client), ws.ID)
+			if err != nil {
+				return nil, fmt.Errorf("listing variables for %s: %w", ws.Name, err)
+			}
+			workspaceVars[ws.ID] = vars
+		}
+
+		varSets, err = listAllVariableSets(ctx, c.variableSetService(client), c.organization, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing variable sets: %w", err)
+		}
+		sort.Slice(varSets, func(i, j int) bool { return varSets[i].Name < varSets[j].Name })
+		for _, set := range varSets {
+			vars, err := listAllVariableSetVariables(ctx, c.variableSetVariableService(client), set.ID)
+			if err != nil {
+				return nil, fmt.Errorf("listing variables for variable set %s: %w", set.Name, err)
+			}
+			varSetVars[set.ID] = vars
+		}
+	}
+
+	var violations []lintViolation
+	for _, rule := range rules {
+		add := func(resourceType, resource, message string) {
+			violations = append(violations, lintViolation{
+				Severity:     rule.Severity,
+				Rule:         rule.Name,
+				ResourceType: resourceType,
+				Resource:     resource,
+				Message:      message,
+			})
+		}
+
+		if rule.Type == lintRuleNamePattern && rule.Target == "project" {
+			for _, project := range projects {
+				if !rule.pattern.MatchString(project.Name) {
+					add("project", project.Name, fmt.Sprintf("name does not match %s", rule.Pattern))
+				}
+			}
+			continue
+		}
+
+		for _, ws := range workspaces {
+			if !rule.appliesTo(ws.Name) {
+				continue
+			}
+
+			switch rule.Type {
+			case lintRuleNamePattern:
+				if !rule.pattern.MatchString(ws.Name) {
+					add("workspace", ws.Name, fmt.Sprintf("name does not match %s", rule.Pattern))
+				}
+			case lintRuleRequiredTag:
+				if !hasOwnerTag(ws, rule.Tag) {
+					add("workspace", ws.Name, fmt.Sprintf("missing %q tag", rule.Tag))
+				}
+			case lintRuleNoAutoApply:
+				if ws.AutoApply {
+					add("workspace", ws.Name, "auto-apply is enabled")
+				}
+			case lintRuleMinTerraformVersion:
+				if cmp, ok := compareTerraformVersions(ws.TerraformVersion, rule.Version); ok && cmp < 0 {
+					add("workspace", ws.Name, fmt.Sprintf("Terraform %s is older than %s", ws.TerraformVersion, rule.Version))
+				}
+			case lintRuleAssessmentsEnabled:
+				if !ws.AssessmentsEnabled {
+					add("workspace", ws.Name, "health assessments are disabled")
+				}
+			case lintRuleSensitiveVariable:
+				for _, v := range workspaceVars[ws.ID] {
+					if matched, _ := path.Match(rule.Pattern, v.Key); matched && !v.Sensitive {
+						add("variable", ws.Name+"/"+v.Key, fmt.Sprintf("keys matching %s must be sensitive", rule.Pattern))
+					}
+				}
+			}
+		}
+
+		if rule.Type == lintRuleSensitiveVariable {
+			for _, set := range varSets {
+				for _, v := range varSetVars[set.ID] {
+					if matched, _ := path.Match(rule.Pattern, v.Key); matched && !v.Sensitive {
+						add("variable", "varset:"+set.Name+"/"+v.Key, fmt.Sprintf("keys matching %s must be sensitive", rule.Pattern))
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return lintSeverityRank[violations[i].Severity] > lintSeverityRank[violations[j].Severity]
+	})
+
+	return violations, nil
+}
+
+func (c *LintCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *LintCommand) projectService(client *client.Client) projectLister {
+	if c.projectSvc != nil {
+		return c.projectSvc
+	}
+	return client.Projects
+}
+
+func (c *LintCommand) variableService(client *client.Client) variableLister {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+func (c *LintCommand) variableSetService(client *client.Client) variableSetLister {
+	if c.varSetSvc != nil {
+		return c.varSetSvc
+	}
+	return client.VariableSets
+}
+
+func (c *LintCommand) variableSetVariableService(client *client.Client) variableSetVariableLister {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+// Help returns help text for the lint command
+func (c *LintCommand) Help() string {
+	helpText := `
+Usage: hcptf lint [options]
+
+  Check workspaces, projects, and variables against a rules file.
+
+  This is a governance check for organization settings. It complements
+  Sentinel and OPA policies, which check plans. Violations are reported
+  with the rule's severity. The command exits non-zero when any violation
+  is at or above -fail-on, so it can gate CI pipelines.
+
+  Each rule is a "rule" block with a type, an optional severity (error,
+  warning, or info; default error), and an optional "match" regex that
+  limits workspace rules to matching workspace names. Only name_pattern
+  rules accept a "target"; every other rule checks workspaces.
+
+  Rule types:
+
+    name_pattern           Names must match "pattern" (target = workspace or project)
+    required_tag           Workspaces must have the "tag" tag (as key, key:value, or
+                           a key/value tag on the workspace or its project)
+    no_auto_apply          Workspaces must not auto-apply
+    min_terraform_version  Workspaces must use at least "version"
+    sensitive_variable     Variables whose key matches the "pattern" glob must be sensitive
+    assessments_enabled    Workspaces must have health assessments enabled
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -rules=<path>        Path to the rules file, HCL or JSON (default: lint.hcl)
+  -fail-on=<severity>  Lowest severity that fails the check (default: error)
+  -output=<format>     Output format: table (default), json, or csv
+
+Example rules file:
+
+  rule "workspace-names" {
+    type    = "name_pattern"
+    pattern = "^[a-z0-9-]+$"
+  }
+
+  rule "team-tag" {
+    type     = "required_tag"
+    tag      = "team"
+    severity = "warning"
+  }
+
+  rule "no-prod-auto-apply" {
+    type  = "no_auto_apply"
+    match = "-prod$"
+  }
+
+  rule "terraform-version" {
+    type    = "min_terraform_version"
+    version = "1.5.0"
+  }
+
+  rule "secrets-sensitive" {
+    type    = "sensitive_variable"
+    pattern = "*_SECRET"
+  }
+
+  rule "assessments" {
+    type = "assessments_enabled"
+  }
+
+Example:
+
+  hcptf lint -org=my-org -rules=lint.hcl
+  hcptf lint -org=my-org -rules=lint.hcl -fail-on=warning -output=json
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the lint command
+func (c *LintCommand) Synopsis() string {
+	return "Check organization resources against lint rules"
+}
@@ -0,0 +1,256 @@
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+const lintTestRules = `
+rule "workspace-names" {
+  type    = "name_pattern"
+  pattern = "^[a-z0-9-]+$"
+}
+
+rule "project-names" {
+  type     = "name_pattern"
+  target   = "project"
+  pattern  = "^team-"
+  severity = "warning"
+}
+
+rule "team-tag" {
+  type     = "required_tag"
+  tag      = "team"
+  severity = "warning"
+}
+
+rule "no-prod-auto-apply" {
+  type  = "no_auto_apply"
+  match = "-prod$"
+}
+
+rule "terraform-version" {
+  type    = "min_terraform_version"
+  version = "1.5.0"
+}
+
+rule "secrets-sensitive" {
+  type    = "sensitive_variable"
+  pattern = "*_SECRET"
+}
+
+rule "assessments" {
+  type     = "assessments_enabled"
+  severity = "info"
+}
+`
+
+func writeLintRules(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lint.hcl")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+	return path
+}
+
+func newLintCommand(ui cli.Ui) *LintCommand {
+	return &LintCommand{
+		Meta: newTestMeta(ui),
+		workspaceSvc: &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{
+			{ID: "ws-1", Name: "app-prod", AutoApply: true, TerraformVersion: "1.4.0", TagNames: []string{"team:web"}, AssessmentsEnabled: true},
+			{ID: "ws-2", Name: "App_Staging", TerraformVersion: "1.6.0"},
+		}}},
+		projectSvc: &mockProjectListService{response: &tfe.ProjectList{Items: []*tfe.Project{
+			{ID: "prj-1", Name: "team-web"},
+			{ID: "prj-2", Name: "Default Project"},
+		}}},
+		variableSvc: &mockVariableListByWorkspaceService{variables: map[string][]*tfe.Variable{
+			"ws-1": {{Key: "DB_SECRET", Sensitive: true}, {Key: "API_SECRET"}},
+		}},
+		varSetSvc: &mockVariableSetListService{response: &tfe.VariableSetList{Items: []*tfe.VariableSet{
+			{ID: "varset-1", Name: "shared"},
+		}}},
+		varSetVarSvc: &mockVariableSetVariableListService{variables: map[string][]*tfe.VariableSetVariable{
+			"varset-1": {{Key: "CLIENT_SECRET"}},
+		}},
+	}
+}
+
+func TestLintRequiresOrganization(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newLintCommand(ui)
+
+	if code := cmd.Run(nil); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-organization") {
+		t.Fatalf("expected organization error, got %q", out)
+	}
+}
+
+func TestLintReportsViolations(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newLintCommand(ui)
+	rules := writeLintRules(t, lintTestRules)
+
+	code := cmd.Run([]string{"-org=my-org", "-rules=" + rules, "-output=json"})
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var violations []lintViolation
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &violations); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, ui.OutputWriter.String())
+	}
+
+	got := map[string]string{}
+	for _, v := range violations {
+		got[v.Rule+" "+v.Resource] = v.Severity
+	}
+	want := map[string]string{
+		"workspace-names App_Staging":                   "error",
+		"project-names Default Project":                 "warning",
+		"team-tag App_Staging":                          "warning",
+		"no-prod-auto-apply app-prod":                   "error",
+		"terraform-version app-prod":                    "error",
+		"secrets-sensitive app-prod/API_SECRET":         "error",
+		"secrets-sensitive varset:shared/CLIENT_SECRET": "error",
+		"assessments App_Staging":                       "info",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d violations, got %#v", len(want), got)
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Errorf("expected %q with severity %s, got %q", key, severity, got[key])
+		}
+	}
+	if violations[0].Severity != "error" || violations[len(violations)-1].Severity != "info" {
+		t.Fatalf("expected violations sorted by severity, got %#v", violations)
+	}
+}
+
+func TestLintFailOnThreshold(t *testing.T) {
+	rules := writeLintRules(t, `
+rule "team-tag" {
+  type     = "required_tag"
+  tag      = "team"
+  severity = "warning"
+}
+`)
+
+	ui := cli.NewMockUi()
+	if code := newLintCommand(ui).Run([]string{"-org=my-org", "-rules=" + rules}); code != 0 {
+		t.Fatalf("expected warnings to pass by default, got %d", code)
+	}
+
+	ui = cli.NewMockUi()
+	if code := newLintCommand(ui).Run([]string{"-org=my-org", "-rules=" + rules, "-fail-on=warning"}); code != 1 {
+		t.Fatalf("expected -fail-on=warning to fail, got %d", code)
+	}
+}
+
+func TestLintSkipsUnneededLookups(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newLintCommand(ui)
+	variables := cmd.variableSvc.(*mockVariableListByWorkspaceService)
+	rules := writeLintRules(t, `
+rule "assessments" {
+  type  = "assessments_enabled"
+  match = "-prod$"
+}
+`)
+
+	if code := cmd.Run([]string{"-org=my-org", "-rules=" + rules}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.OutputWriter.String())
+	}
+	if len(variables.calls) != 0 {
+		t.Fatalf("expected no variable lookups, got %v", variables.calls)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "No lint violations found") {
+		t.Fatalf("expected clean result, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestLintRequiredTagAcceptsTagBindings(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newLintCommand(ui)
+	workspaces := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{
+		{ID: "ws-1", Name: "app-prod", EffectiveTagBindings: []*tfe.EffectiveTagBinding{{Key: "team", Value: "platform"}}},
+	}}}
+	cmd.workspaceSvc = workspaces
+	rules := writeLintRules(t, `
+rule "team-tag" {
+  type = "required_tag"
+  tag  = "team"
+}
+`)
+
+	if code := cmd.Run([]string{"-org=my-org", "-rules=" + rules}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.OutputWriter.String())
+	}
+	if workspaces.lastOptions == nil || len(workspaces.lastOptions.Include) != 1 || workspaces.lastOptions.Include[0] != tfe.WSEffectiveTagBindings {
+		t.Fatalf("expected effective tag bindings to be included, got %+v", workspaces.lastOptions)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "No lint violations found") {
+		t.Fatalf("expected clean result, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestLintEmptyCSVHasHeader(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newLintCommand(ui)
+	rules := writeLintRules(t, `
+rule "assessments" {
+  type  = "assessments_enabled"
+  match = "-prod$"
+}
+`)
+
+	if code := cmd.Run([]string{"-org=my-org", "-rules=" + rules, "-output=csv"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); out != "Severity,Rule,Resource Type,Resource,Message\n" {
+		t.Fatalf("expected only the CSV header, got %q", out)
+	}
+}
+
+func TestLoadLintRulesValidation(t *testing.T) {
+	cases := map[string]string{
+		"unknown type":   "rule \"x\" {\n  type = \"bogus\"\n}\n",
+		"bad severity":   "rule \"x\" {\n  type     = \"no_auto_apply\"\n  severity = \"fatal\"\n}\n",
+		"bad regex":      "rule \"x\" {\n  type    = \"name_pattern\"\n  pattern = \"(\"\n}\n",
+		"missing tag":    "rule \"x\" {\n  type = \"required_tag\"\n}\n",
+		"bad version":    "rule \"x\" {\n  type    = \"min_terraform_version\"\n  version = \"latest\"\n}\n",
+		"bad target":     "rule \"x\" {\n  type    = \"name_pattern\"\n  target  = \"team\"\n  pattern = \"a\"\n}\n",
+		"target on tag":  "rule \"x\" {\n  type   = \"required_tag\"\n  target = \"project\"\n  tag    = \"owner\"\n}\n",
+		"duplicate name": "rule \"x\" {\n  type = \"no_auto_apply\"\n}\n\nrule \"x\" {\n  type = \"no_auto_apply\"\n}\n",
+		"no rules":       "",
+	}
+
+	for name, content := range cases {
+		if _, err := loadLintRules(writeLintRules(t, content)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLintHelp(t *testing.T) {
+	cmd := &LintCommand{}
+	help := cmd.Help()
+	for _, want := range []string{"hcptf lint", "-rules", "-fail-on", "sensitive_variable"} {
+		if !strings.Contains(help, want) {
+			t.Fatalf("expected help to contain %q", want)
+		}
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
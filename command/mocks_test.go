@@ -2,6 +2,8 @@ package command
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"io"
 
 	tfe "github.com/hashicorp/go-tfe"
@@ -224,6 +226,39 @@ func (m *mockVariableUpdateService) Update(_ context.Context, workspaceID, varia
 	return m.response, m.err
 }
 
+type mockVariableReadService struct {
+	response *tfe.Variable
+	err      error
+	lastID   string
+}
+
+func (m *mockVariableReadService) Read(_ context.Context, _ string, variableID string) (*tfe.Variable, error) {
+	m.lastID = variableID
+	return m.response, m.err
+}
+
+type mockVariableSetVariableReadService struct {
+	response *tfe.VariableSetVariable
+	err      error
+	lastID   string
+}
+
+func (m *mockVariableSetVariableReadService) Read(_ context.Context, _ string, variableID string) (*tfe.VariableSetVariable, error) {
+	m.lastID = variableID
+	return m.response, m.err
+}
+
+type mockPolicySetParameterReadService struct {
+	response *tfe.PolicySetParameter
+	err      error
+	lastID   string
+}
+
+func (m *mockPolicySetParameterReadService) Read(_ context.Context, _ string, parameterID string) (*tfe.PolicySetParameter, error) {
+	m.lastID = parameterID
+	return m.response, m.err
+}
+
 type mockVariableDeleteService struct {
 	err           error
 	lastWorkspace string
@@ -819,3 +854,430 @@ func (m *mockReservedTagKeyCreateService) Create(_ context.Context, organization
 	m.lastOptions = options
 	return m.response, m.err
 }
+
+type mockWorkspacePagedListService struct {
+	pages       [][]*tfe.Workspace
+	err         error
+	lastOrg     string
+	lastOptions *tfe.WorkspaceListOptions
+	callCount   int
+}
+
+func (m *mockWorkspacePagedListService) List(_ context.Context, organization string, options *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	m.lastOrg = organization
+	m.lastOptions = options
+	m.callCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	page := 1
+	if options != nil && options.PageNumber > 0 {
+		page = options.PageNumber
+	}
+	list := &tfe.WorkspaceList{Pagination: &tfe.Pagination{CurrentPage: page, TotalPages: len(m.pages)}}
+	if page <= len(m.pages) {
+		list.Items = m.pages[page-1]
+	}
+	if page < len(m.pages) {
+		list.Pagination.NextPage = page + 1
+	}
+	return list, nil
+}
+
+// mockRunTriggerByWorkspaceService returns run triggers keyed by workspace
+// ID, split into pages of pageSize when it is set.
+type mockRunTriggerByWorkspaceService struct {
+	inbound  map[string][]*tfe.RunTrigger
+	outbound map[string][]*tfe.RunTrigger
+	pageSize int
+	err      error
+}
+
+func (m *mockRunTriggerByWorkspaceService) List(_ context.Context, workspaceID string, options *tfe.RunTriggerListOptions) (*tfe.RunTriggerList, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	items := m.inbound[workspaceID]
+	if options != nil && options.RunTriggerType == tfe.RunTriggerFilterOp("outbound") {
+		items = m.outbound[workspaceID]
+	}
+	if m.pageSize == 0 || options == nil {
+		return &tfe.RunTriggerList{Items: items}, nil
+	}
+
+	page := options.PageNumber
+	if page == 0 {
+		page = 1
+	}
+	start, end := (page-1)*m.pageSize, page*m.pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	pagination := &tfe.Pagination{CurrentPage: page}
+	if end < len(items) {
+		pagination.NextPage = page + 1
+	}
+	return &tfe.RunTriggerList{Items: items[start:end], Pagination: pagination}, nil
+}
+
+type mockRemoteStateConsumerService struct {
+	consumers map[string][]*tfe.Workspace
+	err       error
+	calls     []string
+}
+
+func (m *mockRemoteStateConsumerService) RemoteStateConsumers(_ context.Context, workspaceID string, _ *tfe.RemoteStateConsumersListOptions) (*tfe.WorkspaceList, error) {
+	m.calls = append(m.calls, workspaceID)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &tfe.WorkspaceList{Items: m.consumers[workspaceID]}, nil
+}
+
+type mockVariableListByWorkspaceService struct {
+	variables map[string][]*tfe.Variable
+	err       error
+	calls     []string
+}
+
+func (m *mockVariableListByWorkspaceService) List(_ context.Context, workspaceID string, _ *tfe.VariableListOptions) (*tfe.VariableList, error) {
+	m.calls = append(m.calls, workspaceID)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &tfe.VariableList{Items: m.variables[workspaceID]}, nil
+}
+
+// mockVariableSyncService keeps workspace variables in memory and records
+// every change made to them.
+type mockVariableSyncService struct {
+	variables map[string][]*tfe.Variable
+	created   []tfe.VariableCreateOptions
+	updated   map[string]tfe.VariableUpdateOptions
+	deleted   []string
+}
+
+func (m *mockVariableSyncService) List(_ context.Context, workspaceID string, _ *tfe.VariableListOptions) (*tfe.VariableList, error) {
+	return &tfe.VariableList{Items: m.variables[workspaceID]}, nil
+}
+
+func (m *mockVariableSyncService) Create(_ context.Context, workspaceID string, options tfe.VariableCreateOptions) (*tfe.Variable, error) {
+	m.created = append(m.created, options)
+	return &tfe.Variable{ID: fmt.Sprintf("var-new-%d", len(m.created)), Key: *options.Key}, nil
+}
+
+func (m *mockVariableSyncService) Update(_ context.Context, workspaceID, variableID string, options tfe.VariableUpdateOptions) (*tfe.Variable, error) {
+	if m.updated == nil {
+		m.updated = map[string]tfe.VariableUpdateOptions{}
+	}
+	m.updated[variableID] = options
+	return &tfe.Variable{ID: variableID}, nil
+}
+
+func (m *mockVariableSyncService) Delete(_ context.Context, workspaceID, variableID string) error {
+	m.deleted = append(m.deleted, variableID)
+	return nil
+}
+
+type mockVariableSetVariableListService struct {
+	variables map[string][]*tfe.VariableSetVariable
+	err       error
+}
+
+func (m *mockVariableSetVariableListService) List(_ context.Context, variableSetID string, _ *tfe.VariableSetVariableListOptions) (*tfe.VariableSetVariableList, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &tfe.VariableSetVariableList{Items: m.variables[variableSetID]}, nil
+}
+
+// mockRemoteStateConsumerManager keeps workspaces by name and ID and applies
+// consumer changes in memory.
+type mockRemoteStateConsumerManager struct {
+	workspaces  []*tfe.Workspace
+	consumers   map[string][]*tfe.Workspace
+	err         error
+	addCalls    [][]*tfe.Workspace
+	removeCalls [][]*tfe.Workspace
+	updateCalls [][]*tfe.Workspace
+}
+
+func (m *mockRemoteStateConsumerManager) Read(_ context.Context, _ string, workspace string) (*tfe.Workspace, error) {
+	for _, ws := range m.workspaces {
+		if ws.Name == workspace {
+			return ws, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockRemoteStateConsumerManager) ReadByID(_ context.Context, workspaceID string) (*tfe.Workspace, error) {
+	for _, ws := range m.workspaces {
+		if ws.ID == workspaceID {
+			return ws, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockRemoteStateConsumerManager) RemoteStateConsumers(_ context.Context, workspaceID string, _ *tfe.RemoteStateConsumersListOptions) (*tfe.WorkspaceList, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &tfe.WorkspaceList{Items: m.consumers[workspaceID]}, nil
+}
+
+func (m *mockRemoteStateConsumerManager) AddRemoteStateConsumers(_ context.Context, workspaceID string, options tfe.WorkspaceAddRemoteStateConsumersOptions) error {
+	m.addCalls = append(m.addCalls, options.Workspaces)
+	m.consumers[workspaceID] = append(m.consumers[workspaceID], options.Workspaces...)
+	return nil
+}
+
+func (m *mockRemoteStateConsumerManager) RemoveRemoteStateConsumers(_ context.Context, workspaceID string, options tfe.WorkspaceRemoveRemoteStateConsumersOptions) error {
+	m.removeCalls = append(m.removeCalls, options.Workspaces)
+	var kept []*tfe.Workspace
+	for _, ws := range m.consumers[workspaceID] {
+		removed := false
+		for _, r := range options.Workspaces {
+			if r.ID == ws.ID {
+				removed = true
+			}
+		}
+		if !removed {
+			kept = append(kept, ws)
+		}
+	}
+	m.consumers[workspaceID] = kept
+	return nil
+}
+
+func (m *mockRemoteStateConsumerManager) UpdateRemoteStateConsumers(_ context.Context, workspaceID string, options tfe.WorkspaceUpdateRemoteStateConsumersOptions) error {
+	m.updateCalls = append(m.updateCalls, options.Workspaces)
+	m.consumers[workspaceID] = options.Workspaces
+	return nil
+}
+
+func newMockRemoteStateConsumerManager() *mockRemoteStateConsumerManager {
+	network := &tfe.Workspace{ID: "ws-network", Name: "network"}
+	app := &tfe.Workspace{ID: "ws-app", Name: "app"}
+	api := &tfe.Workspace{ID: "ws-api", Name: "api"}
+	batch := &tfe.Workspace{ID: "ws-batch", Name: "batch"}
+	return &mockRemoteStateConsumerManager{
+		workspaces: []*tfe.Workspace{network, app, api, batch},
+		consumers: map[string][]*tfe.Workspace{
+			"ws-network": {app},
+		},
+	}
+}
+
+// mockStateVersionHistoryService serves a workspace's state history, newest
+// first, and the contents behind each download URL.
+type mockStateVersionHistoryService struct {
+	versions  []*tfe.StateVersion
+	contents  map[string][]byte
+	pageSize  int
+	err       error
+	downloads []string
+	listCalls int
+}
+
+func (m *mockStateVersionHistoryService) ReadCurrent(_ context.Context, _ string) (*tfe.StateVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.versions) == 0 {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return m.versions[0], nil
+}
+
+func (m *mockStateVersionHistoryService) Read(_ context.Context, svID string) (*tfe.StateVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, sv := range m.versions {
+		if sv.ID == svID {
+			return sv, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockStateVersionHistoryService) List(_ context.Context, options *tfe.StateVersionListOptions) (*tfe.StateVersionList, error) {
+	m.listCalls++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	size := m.pageSize
+	if size == 0 {
+		size = len(m.versions) + 1
+	}
+	page := 1
+	if options != nil && options.PageNumber > 0 {
+		page = options.PageNumber
+	}
+
+	start := (page - 1) * size
+	end := start + size
+	if start > len(m.versions) {
+		start = len(m.versions)
+	}
+	if end > len(m.versions) {
+		end = len(m.versions)
+	}
+
+	list := &tfe.StateVersionList{
+		Items:      m.versions[start:end],
+		Pagination: &tfe.Pagination{CurrentPage: page},
+	}
+	if end < len(m.versions) {
+		list.Pagination.NextPage = page + 1
+	}
+	return list, nil
+}
+
+func (m *mockStateVersionHistoryService) Download(_ context.Context, url string) ([]byte, error) {
+	m.downloads = append(m.downloads, url)
+	data, ok := m.contents[url]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return data, nil
+}
+
+type mockRunDetailService struct {
+	runs  map[string]*tfe.Run
+	calls int
+}
+
+func (m *mockRunDetailService) ReadWithOptions(_ context.Context, runID string, _ *tfe.RunReadOptions) (*tfe.Run, error) {
+	m.calls++
+	run, ok := m.runs[runID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return run, nil
+}
+
+// mockStateVersionWriteService records created state versions and adds them
+// to the front of the history so they can be read and downloaded back.
+type mockStateVersionWriteService struct {
+	*mockStateVersionHistoryService
+	created   []tfe.StateVersionCreateOptions
+	createErr error
+	// stored, when set, replaces the contents served for new versions.
+	stored []byte
+}
+
+func (m *mockStateVersionWriteService) Create(_ context.Context, _ string, options tfe.StateVersionCreateOptions) (*tfe.StateVersion, error) {
+	m.created = append(m.created, options)
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*options.State)
+	if err != nil {
+		return nil, err
+	}
+	if m.stored != nil {
+		data = m.stored
+	}
+
+	id := fmt.Sprintf("sv-new-%d", len(m.created))
+	sv := &tfe.StateVersion{ID: id, Serial: *options.Serial, DownloadURL: "https://archivist.example/" + id}
+	m.contents[sv.DownloadURL] = data
+	m.versions = append([]*tfe.StateVersion{sv}, m.versions...)
+	return sv, nil
+}
+
+// mockWorkspaceStateLocker tracks lock and unlock calls around state writes.
+type mockWorkspaceStateLocker struct {
+	workspace   *tfe.Workspace
+	locked      bool
+	lockCalls   int
+	unlockCalls int
+	unlockErr   error
+	onLock      func()
+}
+
+func (m *mockWorkspaceStateLocker) Read(_ context.Context, _, _ string) (*tfe.Workspace, error) {
+	return m.workspace, nil
+}
+
+func (m *mockWorkspaceStateLocker) Lock(_ context.Context, _ string, _ tfe.WorkspaceLockOptions) (*tfe.Workspace, error) {
+	m.lockCalls++
+	m.locked = true
+	if m.onLock != nil {
+		m.onLock()
+	}
+	return m.workspace, nil
+}
+
+func (m *mockWorkspaceStateLocker) Unlock(_ context.Context, _ string) (*tfe.Workspace, error) {
+	m.unlockCalls++
+	if m.unlockErr != nil {
+		return nil, m.unlockErr
+	}
+	m.locked = false
+	return m.workspace, nil
+}
+
+// mockWorkspaceAccessService lists, and reads by name or ID, a fixed set of
+// workspaces.
+type mockWorkspaceAccessService struct {
+	workspaces []*tfe.Workspace
+}
+
+func (m *mockWorkspaceAccessService) List(_ context.Context, _ string, options *tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	list := &tfe.WorkspaceList{}
+	for _, ws := range m.workspaces {
+		if options != nil && options.ProjectID != "" && (ws.Project == nil || ws.Project.ID != options.ProjectID) {
+			continue
+		}
+		list.Items = append(list.Items, ws)
+	}
+	return list, nil
+}
+
+func (m *mockWorkspaceAccessService) Read(_ context.Context, _ string, workspace string) (*tfe.Workspace, error) {
+	for _, ws := range m.workspaces {
+		if ws.Name == workspace {
+			return ws, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockWorkspaceAccessService) ReadByID(_ context.Context, workspaceID string) (*tfe.Workspace, error) {
+	for _, ws := range m.workspaces {
+		if ws.ID == workspaceID {
+			return ws, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+// mockTeamAccessByWorkspace returns team access grants keyed by workspace
+// ID.
+type mockTeamAccessByWorkspace struct {
+	access map[string][]*tfe.TeamAccess
+}
+
+func (m *mockTeamAccessByWorkspace) List(_ context.Context, options *tfe.TeamAccessListOptions) (*tfe.TeamAccessList, error) {
+	return &tfe.TeamAccessList{Items: m.access[options.WorkspaceID]}, nil
+}
+
+// mockProjectTeamAccessByProject returns project team access grants keyed
+// by project ID.
+type mockProjectTeamAccessByProject struct {
+	access map[string][]*tfe.TeamProjectAccess
+}
+
+func (m *mockProjectTeamAccessByProject) List(_ context.Context, options tfe.TeamProjectAccessListOptions) (*tfe.TeamProjectAccessList, error) {
+	return &tfe.TeamProjectAccessList{Items: m.access[options.ProjectID]}, nil
+}
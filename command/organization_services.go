@@ -21,3 +21,8 @@ type organizationDeleter interface {
 type organizationUpdater interface {
 	Update(ctx context.Context, organization string, options tfe.OrganizationUpdateOptions) (*tfe.Organization, error)
 }
+
+type organizationTokenReader interface {
+	Read(ctx context.Context, organization string) (*tfe.OrganizationToken, error)
+	ReadWithOptions(ctx context.Context, organization string, options tfe.OrganizationTokenReadOptions) (*tfe.OrganizationToken, error)
+}
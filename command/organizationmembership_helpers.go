@@ -0,0 +1,182 @@
+package command
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// membershipCSVRow is one user in a bulk membership file.
+type membershipCSVRow struct {
+	Line     int
+	Email    string
+	Username string
+	Teams    []string
+}
+
+// ref returns the email of the row, or its username if it has no email.
+func (r *membershipCSVRow) ref() string {
+	if r.Email != "" {
+		return r.Email
+	}
+	return r.Username
+}
+
+// loadMembershipCSV reads a bulk membership file. The header row names the
+// columns: email, username, and teams, in any order and case. Teams are
+// separated by semicolons or spaces. Other columns are ignored, so the CSV
+// written by "organization membership stale" can be read back, and "-"
+// counts as empty for the same reason.
+func loadMembershipCSV(filename string) ([]*membershipCSVRow, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	_, hasEmail := columns["email"]
+	_, hasUsername := columns["username"]
+	if !hasEmail && !hasUsername {
+		return nil, fmt.Errorf("header must include an email or username column")
+	}
+
+	value := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		v := strings.TrimSpace(record[i])
+		if v == "-" {
+			return ""
+		}
+		return v
+	}
+
+	var rows []*membershipCSVRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := &membershipCSVRow{
+			Line:     line,
+			Email:    value(record, "email"),
+			Username: value(record, "username"),
+			Teams: strings.FieldsFunc(value(record, "teams"), func(r rune) bool {
+				return r == ';' || r == ' '
+			}),
+		}
+		if row.Email == "" && row.Username == "" {
+			return nil, fmt.Errorf("line %d: email or username is required", line)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// membershipTeamNames returns the sorted names of the teams a membership
+// belongs to.
+func membershipTeamNames(m *tfe.OrganizationMembership) []string {
+	names := make([]string, 0, len(m.Teams))
+	for _, team := range m.Teams {
+		name := team.Name
+		if name == "" {
+			name = team.ID
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectRunActivity records, by user ID, the time of each user's most
+// recent run in the organization since the given time. Runs are listed
+// newest first, so paging stops at the first run older than that.
+func collectRunActivity(ctx context.Context, svc runOrgLister, organization string, since time.Time, activity map[string]time.Time) error {
+	opts := &tfe.RunListForOrganizationOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		Include: []tfe.RunIncludeOpt{tfe.RunCreatedBy},
+	}
+
+	for {
+		list, err := svc.ListForOrganization(ctx, organization, opts)
+		if err != nil {
+			return err
+		}
+		for _, run := range list.Items {
+			if run.CreatedAt.Before(since) {
+				return nil
+			}
+			if run.CreatedBy != nil && run.CreatedAt.After(activity[run.CreatedBy.ID]) {
+				activity[run.CreatedBy.ID] = run.CreatedAt
+			}
+		}
+
+		if list.PaginationNextPrev == nil || list.NextPage == 0 || list.NextPage <= opts.PageNumber {
+			return nil
+		}
+		opts.PageNumber = list.NextPage
+	}
+}
+
+// collectAuditTrailActivity records, by user ID, the time of each user's
+// most recent audit trail event since the given time.
+func collectAuditTrailActivity(ctx context.Context, svc auditTrailLister, since time.Time, activity map[string]time.Time) error {
+	opts := &tfe.AuditTrailListOptions{
+		Since: since,
+		ListOptions: &tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, event := range list.Items {
+			id := event.Auth.AccessorID
+			if id != "" && event.Timestamp.After(activity[id]) {
+				activity[id] = event.Timestamp
+			}
+		}
+
+		if list.AuditTrailPagination == nil || list.NextPage == 0 || list.NextPage <= opts.ListOptions.PageNumber {
+			return nil
+		}
+		opts.ListOptions.PageNumber = list.NextPage
+	}
+}
@@ -0,0 +1,293 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	membershipBulkInvite     = "invite"
+	membershipBulkAddToTeams = "add-to-teams"
+	membershipBulkUnchanged  = "unchanged"
+)
+
+// OrganizationMembershipInviteBulkCommand is a command to invite many users
+// and place them on teams
+type OrganizationMembershipInviteBulkCommand struct {
+	Meta
+	organization  string
+	file          string
+	format        string
+	teamSvc       teamLister
+	membershipSvc organizationMembershipInviter
+	teamMemberSvc teamMemberManager
+}
+
+// membershipInvite is the planned change, and its result, for one user in
+// a bulk invitation.
+type membershipInvite struct {
+	Email  string   `json:"email"`
+	Action string   `json:"action"`
+	Teams  []string `json:"teams"`
+	Result string   `json:"result,omitempty"`
+
+	membershipID string
+	teamIDs      []string
+}
+
+// Run executes the organization membership invite-bulk command
+func (c *OrganizationMembershipInviteBulkCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("organization membership invite-bulk")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.file, "file", "", "CSV file with email and teams columns (required)")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Error("Error: -file flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	rows, err := loadMembershipCSV(c.file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading %s: %s", c.file, err))
+		return 1
+	}
+	for _, row := range rows {
+		if row.Email == "" {
+			c.Ui.Error(fmt.Sprintf("Error loading %s: line %d: email is required to invite a user", c.file, row.Line))
+			return 1
+		}
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	teams, err := listAllTeams(ctx, c.teamService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+		return 1
+	}
+	teamIDs := map[string]string{}
+	for _, team := range teams {
+		teamIDs[team.Name] = team.ID
+	}
+
+	memberships, err := listAllOrganizationMemberships(ctx, c.membershipService(client), c.organization, &tfe.OrganizationMembershipListOptions{
+		Include: []tfe.OrgMembershipIncludeOpt{tfe.OrgMembershipUser, tfe.OrgMembershipTeam},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing organization memberships: %s", err))
+		return 1
+	}
+
+	invites, err := planMembershipInvites(rows, teamIDs, memberships)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":       "invite-bulk",
+			"resource":     "organization-membership",
+			"organization": c.organization,
+			"users":        invites,
+		})
+		return 0
+	}
+
+	failed := 0
+	for _, invite := range invites {
+		if err := c.apply(client, invite); err != nil {
+			invite.Result = fmt.Sprintf("error: %s", err)
+			failed++
+			continue
+		}
+		invite.Result = "ok"
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(invites)
+	} else {
+		rows := make([][]string, 0, len(invites))
+		counts := map[string]int{}
+		for _, invite := range invites {
+			if !strings.HasPrefix(invite.Result, "error") {
+				counts[invite.Action]++
+			}
+			teams := strings.Join(invite.Teams, ", ")
+			if teams == "" {
+				teams = "-"
+			}
+			rows = append(rows, []string{invite.Email, invite.Action, teams, invite.Result})
+		}
+		c.Meta.NewFormatter("table").Table([]string{"Email", "Action", "Teams", "Result"}, rows)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("%d invited, %d added to teams, %d unchanged, %d failed",
+			counts[membershipBulkInvite], counts[membershipBulkAddToTeams], counts[membershipBulkUnchanged], failed))
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// planMembershipInvites decides, for each row, whether to invite the user
+// or add an existing member to the teams they are missing from. Every team
+// must exist, so a typo stops the run before anyone is invited.
+func planMembershipInvites(rows []*membershipCSVRow, teamIDs map[string]string, memberships []*tfe.OrganizationMembership) ([]*membershipInvite, error) {
+	seen := map[string]bool{}
+	invites := make([]*membershipInvite, 0, len(rows))
+	for _, row := range rows {
+		email := strings.ToLower(row.Email)
+		if seen[email] {
+			return nil, fmt.Errorf("line %d: %s is listed more than once", row.Line, row.Email)
+		}
+		seen[email] = true
+
+		var ids []string
+		for _, name := range row.Teams {
+			id, ok := teamIDs[name]
+			if !ok {
+				return nil, fmt.Errorf("line %d: team %q not found", row.Line, name)
+			}
+			ids = append(ids, id)
+		}
+
+		m := findMembership(memberships, row.Email)
+		if m == nil {
+			invites = append(invites, &membershipInvite{Email: row.Email, Action: membershipBulkInvite, Teams: append([]string{}, row.Teams...), teamIDs: ids})
+			continue
+		}
+
+		onTeam := map[string]bool{}
+		for _, team := range m.Teams {
+			onTeam[team.ID] = true
+		}
+		invite := &membershipInvite{Email: row.Email, Action: membershipBulkUnchanged, Teams: []string{}, membershipID: m.ID}
+		for i, id := range ids {
+			if !onTeam[id] {
+				invite.Teams = append(invite.Teams, row.Teams[i])
+				invite.teamIDs = append(invite.teamIDs, id)
+			}
+		}
+		if len(invite.teamIDs) > 0 {
+			invite.Action = membershipBulkAddToTeams
+		}
+		invites = append(invites, invite)
+	}
+	return invites, nil
+}
+
+// apply invites the user, or adds the existing member to each missing team
+// through the team members API.
+func (c *OrganizationMembershipInviteBulkCommand) apply(client *client.Client, invite *membershipInvite) error {
+	ctx := client.Context()
+	switch invite.Action {
+	case membershipBulkInvite:
+		teams := make([]*tfe.Team, 0, len(invite.teamIDs))
+		for _, id := range invite.teamIDs {
+			teams = append(teams, &tfe.Team{ID: id})
+		}
+		_, err := c.membershipService(client).Create(ctx, c.organization, tfe.OrganizationMembershipCreateOptions{
+			Email: tfe.String(invite.Email),
+			Teams: teams,
+		})
+		return err
+	case membershipBulkAddToTeams:
+		for i, id := range invite.teamIDs {
+			err := c.teamMemberService(client).Add(ctx, id, tfe.TeamMemberAddOptions{
+				OrganizationMembershipIDs: []string{invite.membershipID},
+			})
+			if err != nil {
+				return fmt.Errorf("team %s: %w", invite.Teams[i], err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *OrganizationMembershipInviteBulkCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *OrganizationMembershipInviteBulkCommand) membershipService(client *client.Client) organizationMembershipInviter {
+	if c.membershipSvc != nil {
+		return c.membershipSvc
+	}
+	return client.OrganizationMemberships
+}
+
+func (c *OrganizationMembershipInviteBulkCommand) teamMemberService(client *client.Client) teamMemberManager {
+	if c.teamMemberSvc != nil {
+		return c.teamMemberSvc
+	}
+	return client.TeamMembers
+}
+
+// Help returns help text for the organization membership invite-bulk command
+func (c *OrganizationMembershipInviteBulkCommand) Help() string {
+	helpText := `
+Usage: hcptf organization membership invite-bulk [options]
+
+  Invite many users to an organization and place them on teams.
+
+  The CSV file has a header row with an email column and an optional teams
+  column listing team names separated by semicolons or spaces:
+
+    email,teams
+    alice@example.com,contractors;developers
+    bob@example.com,contractors
+
+  Users who are not in the organization are invited onto their teams.
+  Users who already are (including pending invitations) are added to any
+  listed team they are not on yet. Every team must exist; nothing is
+  changed if one does not. Use -dry-run to print the plan as JSON.
+
+  The same file can be passed to "organization membership remove-bulk" to
+  offboard the users again.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -file=<path>          CSV file with email and teams columns (required)
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf organization membership invite-bulk -org=my-org -file=users.csv -dry-run
+  hcptf organization membership invite-bulk -org=my-org -file=users.csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the organization membership invite-bulk command
+func (c *OrganizationMembershipInviteBulkCommand) Synopsis() string {
+	return "Invite users from a CSV file and place them on teams"
+}
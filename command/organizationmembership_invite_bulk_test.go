@@ -0,0 +1,92 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newInviteBulkCommand(ui cli.Ui) (*OrganizationMembershipInviteBulkCommand, *mockOrganizationMembershipInviter, *mockTeamMemberManager) {
+	memberships := &mockOrganizationMembershipInviter{memberships: []*tfe.OrganizationMembership{
+		{ID: "ou-alice", Status: tfe.OrganizationMembershipActive, Email: "alice@example.com",
+			User: &tfe.User{Username: "alice"}, Teams: []*tfe.Team{{ID: "team-dev", Name: "developers"}}},
+	}}
+	members := &mockTeamMemberManager{}
+	cmd := &OrganizationMembershipInviteBulkCommand{
+		Meta: newTestMeta(ui),
+		teamSvc: &mockTeamListService{response: &tfe.TeamList{Items: []*tfe.Team{
+			{ID: "team-dev", Name: "developers"},
+			{ID: "team-con", Name: "contractors"},
+		}}},
+		membershipSvc: memberships,
+		teamMemberSvc: members,
+	}
+	return cmd, memberships, members
+}
+
+func TestLoadMembershipCSV(t *testing.T) {
+	path := writeTeamSyncFile(t, "users.csv", "Email,Teams\n# contractors\nbob@example.com,contractors;developers\ncarol@example.com,\n")
+
+	rows, err := loadMembershipCSV(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Email != "bob@example.com" || strings.Join(rows[0].Teams, ",") != "contractors,developers" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	if rows[1].Line != 4 || len(rows[1].Teams) != 0 {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+
+	if _, err := loadMembershipCSV(writeTeamSyncFile(t, "bad.csv", "name,teams\nbob,ci\n")); err == nil || !strings.Contains(err.Error(), "email or username column") {
+		t.Fatalf("expected header error, got %v", err)
+	}
+}
+
+func TestOrganizationMembershipInviteBulk(t *testing.T) {
+	path := writeTeamSyncFile(t, "users.csv", "email,teams\nbob@example.com,contractors developers\nALICE@example.com,contractors;developers\n")
+
+	ui := cli.NewMockUi()
+	cmd, memberships, members := newInviteBulkCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-file=" + path, "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if got := strings.Join(memberships.invited, ";"); got != "bob@example.com->team-con,team-dev" {
+		t.Fatalf("unexpected invitations: %s", got)
+	}
+	if got := strings.Join(members.added, ","); got != "team-con/ou-alice" {
+		t.Fatalf("unexpected members added: %s", got)
+	}
+
+	var invites []membershipInvite
+	if err := json.Unmarshal([]byte(output), &invites); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if len(invites) != 2 || invites[0].Action != membershipBulkInvite || invites[1].Action != membershipBulkAddToTeams || invites[1].Result != "ok" {
+		t.Fatalf("unexpected results: %+v", invites)
+	}
+}
+
+func TestOrganizationMembershipInviteBulkUnknownTeam(t *testing.T) {
+	path := writeTeamSyncFile(t, "users.csv", "email,teams\nbob@example.com,contractors\ncarol@example.com,qa\n")
+
+	ui := cli.NewMockUi()
+	cmd, memberships, _ := newInviteBulkCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-file=" + path}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if len(memberships.invited) != 0 {
+		t.Fatalf("expected no invitations, got %v", memberships.invited)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `line 3: team "qa" not found`) {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
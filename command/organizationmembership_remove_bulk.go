@@ -0,0 +1,250 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// OrganizationMembershipRemoveBulkCommand is a command to remove many users
+// from an organization
+type OrganizationMembershipRemoveBulkCommand struct {
+	Meta
+	organization  string
+	file          string
+	users         string
+	force         bool
+	format        string
+	membershipSvc organizationMembershipRemover
+}
+
+// membershipRemoval is one member to remove and the result.
+type membershipRemoval struct {
+	MembershipID string   `json:"membership_id"`
+	Username     string   `json:"username,omitempty"`
+	Email        string   `json:"email"`
+	Status       string   `json:"status"`
+	Teams        []string `json:"teams"`
+	Result       string   `json:"result,omitempty"`
+}
+
+// Run executes the organization membership remove-bulk command
+func (c *OrganizationMembershipRemoveBulkCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("organization membership remove-bulk")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.file, "file", "", "CSV file with an email or username column")
+	flags.StringVar(&c.users, "users", "", "Comma-separated usernames or emails")
+	flags.BoolVar(&c.force, "force", false, "Remove without confirmation")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" && c.users == "" {
+		c.Ui.Error("Error: -file or -users is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	var refs []string
+	if c.file != "" {
+		rows, err := loadMembershipCSV(c.file)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error loading %s: %s", c.file, err))
+			return 1
+		}
+		for _, row := range rows {
+			refs = append(refs, row.ref())
+		}
+	}
+	refs = append(refs, splitCommaList(c.users)...)
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	memberships, err := listAllOrganizationMemberships(ctx, c.membershipService(client), c.organization, &tfe.OrganizationMembershipListOptions{
+		Include: []tfe.OrgMembershipIncludeOpt{tfe.OrgMembershipUser, tfe.OrgMembershipTeam},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing organization memberships: %s", err))
+		return 1
+	}
+
+	removals := []*membershipRemoval{}
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		m := findMembership(memberships, ref)
+		if m == nil {
+			c.Ui.Warn(fmt.Sprintf("Warning: %s is not a member of %s; skipping", ref, c.organization))
+			continue
+		}
+		if seen[m.ID] {
+			continue
+		}
+		seen[m.ID] = true
+
+		removal := &membershipRemoval{
+			MembershipID: m.ID,
+			Email:        m.Email,
+			Status:       string(m.Status),
+			Teams:        membershipTeamNames(m),
+		}
+		if m.User != nil {
+			removal.Username = m.User.Username
+		}
+		removals = append(removals, removal)
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":       "remove-bulk",
+			"resource":     "organization-membership",
+			"organization": c.organization,
+			"members":      removals,
+		})
+		return 0
+	}
+
+	if len(removals) == 0 {
+		c.Ui.Output("No members to remove")
+		return 0
+	}
+
+	if !c.force {
+		c.renderRemovals(removals, false)
+		c.Ui.Output("")
+		for _, r := range removals {
+			for _, team := range r.Teams {
+				if team == "owners" {
+					c.Ui.Warn(fmt.Sprintf("Warning: %s is on the owners team", r.Email))
+				}
+			}
+		}
+		c.Ui.Output(fmt.Sprintf("Do you want to remove these %d members from '%s'? They lose access to every workspace in the organization.", len(removals), c.organization))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Removal cancelled.")
+			return 0
+		}
+	}
+
+	failed := 0
+	for _, r := range removals {
+		if err := c.membershipService(client).Delete(ctx, r.MembershipID); err != nil {
+			r.Result = fmt.Sprintf("error: %s", err)
+			failed++
+			continue
+		}
+		r.Result = "removed"
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(removals)
+	} else {
+		c.renderRemovals(removals, true)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("%d members removed, %d failed", len(removals)-failed, failed))
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// renderRemovals prints the members to remove, with the result of each
+// removal once they have been made.
+func (c *OrganizationMembershipRemoveBulkCommand) renderRemovals(removals []*membershipRemoval, withResult bool) {
+	headers := []string{"Username", "Email", "Status", "Teams"}
+	if withResult {
+		headers = append(headers, "Result")
+	}
+	rows := make([][]string, 0, len(removals))
+	for _, r := range removals {
+		username, teams := r.Username, strings.Join(r.Teams, ", ")
+		if username == "" {
+			username = "-"
+		}
+		if teams == "" {
+			teams = "-"
+		}
+		row := []string{username, r.Email, r.Status, teams}
+		if withResult {
+			row = append(row, r.Result)
+		}
+		rows = append(rows, row)
+	}
+	c.Meta.NewFormatter("table").Table(headers, rows)
+}
+
+func (c *OrganizationMembershipRemoveBulkCommand) membershipService(client *client.Client) organizationMembershipRemover {
+	if c.membershipSvc != nil {
+		return c.membershipSvc
+	}
+	return client.OrganizationMemberships
+}
+
+// Help returns help text for the organization membership remove-bulk command
+func (c *OrganizationMembershipRemoveBulkCommand) Help() string {
+	helpText := `
+Usage: hcptf organization membership remove-bulk [options]
+
+  Remove many users from an organization. Pending invitations are
+  revoked. Removed users lose access to every workspace in the
+  organization.
+
+  Users come from -file, a CSV file with a header row and an email or
+  username column (the file given to invite-bulk, or the CSV written by
+  "organization membership stale"), and from -users. Users who are not
+  members are skipped with a warning.
+
+  The members to remove are shown and must be confirmed unless -force is
+  set. Use -dry-run to print them as JSON without removing anyone.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -file=<path>          CSV file with an email or username column
+  -users=<list>         Comma-separated usernames or emails
+                        (one of -file or -users is required)
+  -force                Remove without confirmation
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf organization membership remove-bulk -org=my-org -file=users.csv
+  hcptf organization membership remove-bulk -org=my-org -users=alice,bob@example.com -dry-run
+  hcptf organization membership stale -org=my-org -output=csv > stale.csv
+  hcptf organization membership remove-bulk -org=my-org -file=stale.csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the organization membership remove-bulk command
+func (c *OrganizationMembershipRemoveBulkCommand) Synopsis() string {
+	return "Remove many users from an organization"
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockOrganizationMembershipRemover struct {
+	memberships []*tfe.OrganizationMembership
+	deleted     []string
+}
+
+func (m *mockOrganizationMembershipRemover) List(_ context.Context, _ string, _ *tfe.OrganizationMembershipListOptions) (*tfe.OrganizationMembershipList, error) {
+	return &tfe.OrganizationMembershipList{Items: m.memberships}, nil
+}
+
+func (m *mockOrganizationMembershipRemover) Delete(_ context.Context, organizationMembershipID string) error {
+	m.deleted = append(m.deleted, organizationMembershipID)
+	return nil
+}
+
+func newRemoveBulkCommand(ui cli.Ui) (*OrganizationMembershipRemoveBulkCommand, *mockOrganizationMembershipRemover) {
+	svc := &mockOrganizationMembershipRemover{memberships: []*tfe.OrganizationMembership{
+		{ID: "ou-alice", Status: tfe.OrganizationMembershipActive, Email: "alice@example.com", User: &tfe.User{Username: "alice"}},
+		{ID: "ou-bob", Status: tfe.OrganizationMembershipActive, Email: "bob@example.com", User: &tfe.User{Username: "bob"}},
+		{ID: "ou-dan", Status: tfe.OrganizationMembershipInvited, Email: "dan@example.com"},
+	}}
+	return &OrganizationMembershipRemoveBulkCommand{Meta: newTestMeta(ui), membershipSvc: svc}, svc
+}
+
+func TestOrganizationMembershipRemoveBulkRequiresUsers(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newRemoveBulkCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-file or -users is required") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestOrganizationMembershipRemoveBulkConfirm(t *testing.T) {
+	path := writeTeamSyncFile(t, "users.csv", "email,teams\nbob@example.com,contractors\nDAN@example.com,contractors\nzed@example.com,\n")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "no\n", want: ""},
+		{input: "yes\n", want: "ou-bob,ou-dan,ou-alice"},
+	}
+	for _, tt := range tests {
+		ui := cli.NewMockUi()
+		ui.InputReader = strings.NewReader(tt.input)
+		cmd, svc := newRemoveBulkCommand(ui)
+
+		_, code := captureStdout(t, func() int {
+			return cmd.Run([]string{"-org=my-org", "-file=" + path, "-users=alice,bob"})
+		})
+		if code != 0 {
+			t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+		}
+		if got := strings.Join(svc.deleted, ","); got != tt.want {
+			t.Errorf("input %q: deleted %q, want %q", tt.input, got, tt.want)
+		}
+		if !strings.Contains(ui.ErrorWriter.String(), "zed@example.com is not a member") {
+			t.Errorf("expected warning for unknown user, got %q", ui.ErrorWriter.String())
+		}
+	}
+}
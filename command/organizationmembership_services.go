@@ -0,0 +1,29 @@
+package command
+
+import (
+	"context"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+type organizationMembershipLister interface {
+	List(ctx context.Context, organization string, options *tfe.OrganizationMembershipListOptions) (*tfe.OrganizationMembershipList, error)
+}
+
+type organizationMembershipCreator interface {
+	Create(ctx context.Context, organization string, options tfe.OrganizationMembershipCreateOptions) (*tfe.OrganizationMembership, error)
+}
+
+type organizationMembershipInviter interface {
+	organizationMembershipLister
+	organizationMembershipCreator
+}
+
+type organizationMembershipDeleter interface {
+	Delete(ctx context.Context, organizationMembershipID string) error
+}
+
+type organizationMembershipRemover interface {
+	organizationMembershipLister
+	organizationMembershipDeleter
+}
@@ -0,0 +1,218 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// OrganizationMembershipStaleCommand is a command to flag organization
+// members with pending invitations or no recent activity
+type OrganizationMembershipStaleCommand struct {
+	Meta
+	organization  string
+	days          int
+	format        string
+	now           func() time.Time
+	membershipSvc organizationMembershipLister
+	runSvc        runOrgLister
+	auditTrailSvc auditTrailLister
+}
+
+// staleMember is one organization member flagged as stale.
+type staleMember struct {
+	MembershipID string   `json:"membership_id"`
+	Username     string   `json:"username,omitempty"`
+	Email        string   `json:"email"`
+	Status       string   `json:"status"`
+	Teams        []string `json:"teams"`
+	Reason       string   `json:"reason"`
+}
+
+// Run executes the organization membership stale command
+func (c *OrganizationMembershipStaleCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("organization membership stale")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.IntVar(&c.days, "days", 90, "Flag members with no runs or audit trail events in this many days")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.days <= 0 {
+		c.Ui.Error("Error: -days must be greater than zero")
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" && c.format != "csv" {
+		c.Ui.Error("Error: -output must be one of: table, json, csv")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	since := now.AddDate(0, 0, -c.days)
+
+	memberships, err := listAllOrganizationMemberships(ctx, c.membershipService(client), c.organization, &tfe.OrganizationMembershipListOptions{
+		Include: []tfe.OrgMembershipIncludeOpt{tfe.OrgMembershipUser, tfe.OrgMembershipTeam},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing organization memberships: %s", err))
+		return 1
+	}
+
+	activity := map[string]time.Time{}
+	if err := collectRunActivity(ctx, c.runService(client), c.organization, since, activity); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing organization runs: %s", err))
+		return 1
+	}
+
+	sources := "runs or audit trail events"
+	err = collectAuditTrailActivity(ctx, c.auditTrailService(client), since, activity)
+	if errors.Is(err, tfe.ErrUnauthorized) || errors.Is(err, tfe.ErrResourceNotFound) {
+		c.Ui.Warn("Warning: audit trail events are not available with this token; using runs only")
+		sources = "runs"
+	} else if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing audit trail events: %s", err))
+		return 1
+	}
+
+	stale := findStaleMembers(memberships, activity, fmt.Sprintf("no %s in %d days", sources, c.days))
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		formatter.JSON(stale)
+		return 0
+	}
+
+	if len(stale) == 0 {
+		if c.format == "table" {
+			c.Ui.Output(fmt.Sprintf("No stale members among %d organization members", len(memberships)))
+		}
+		return 0
+	}
+
+	rows := make([][]string, 0, len(stale))
+	for _, m := range stale {
+		username, teams := m.Username, strings.Join(m.Teams, ";")
+		if username == "" {
+			username = "-"
+		}
+		if teams == "" {
+			teams = "-"
+		}
+		rows = append(rows, []string{username, m.Email, m.Status, teams, m.Reason})
+	}
+	formatter.Table([]string{"Username", "Email", "Status", "Teams", "Reason"}, rows)
+	return 0
+}
+
+// findStaleMembers returns the members whose invitation is still pending
+// or who have no recorded activity, sorted by email.
+func findStaleMembers(memberships []*tfe.OrganizationMembership, activity map[string]time.Time, inactive string) []*staleMember {
+	stale := []*staleMember{}
+	for _, m := range memberships {
+		entry := &staleMember{
+			MembershipID: m.ID,
+			Email:        m.Email,
+			Status:       string(m.Status),
+			Teams:        membershipTeamNames(m),
+		}
+		if m.User != nil {
+			entry.Username = m.User.Username
+		}
+
+		switch {
+		case m.Status == tfe.OrganizationMembershipInvited:
+			entry.Reason = "invitation pending"
+		case m.User == nil || activity[m.User.ID].IsZero():
+			entry.Reason = inactive
+		default:
+			continue
+		}
+		stale = append(stale, entry)
+	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].Email < stale[j].Email })
+	return stale
+}
+
+func (c *OrganizationMembershipStaleCommand) membershipService(client *client.Client) organizationMembershipLister {
+	if c.membershipSvc != nil {
+		return c.membershipSvc
+	}
+	return client.OrganizationMemberships
+}
+
+func (c *OrganizationMembershipStaleCommand) runService(client *client.Client) runOrgLister {
+	if c.runSvc != nil {
+		return c.runSvc
+	}
+	return client.Runs
+}
+
+func (c *OrganizationMembershipStaleCommand) auditTrailService(client *client.Client) auditTrailLister {
+	if c.auditTrailSvc != nil {
+		return c.auditTrailSvc
+	}
+	return client.AuditTrails
+}
+
+// Help returns help text for the organization membership stale command
+func (c *OrganizationMembershipStaleCommand) Help() string {
+	helpText := `
+Usage: hcptf organization membership stale [options]
+
+  Flag organization members whose invitation is still pending, or who have
+  started no runs and caused no audit trail events in the last -days days.
+
+  Audit trail events need an organization token and a plan that includes
+  audit trails. When they are not available a warning is printed and only
+  runs are considered.
+
+  CSV output can be reviewed and passed to "organization membership
+  remove-bulk -file=..." to remove the flagged members.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -days=<n>             Flag members with no activity in this many days
+                        (default: 90)
+  -output=<format>      Output format: table (default), json, or csv
+
+Example:
+
+  hcptf organization membership stale -org=my-org
+  hcptf organization membership stale -org=my-org -days=30 -output=csv > stale.csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the organization membership stale command
+func (c *OrganizationMembershipStaleCommand) Synopsis() string {
+	return "Flag members with pending invitations or no recent activity"
+}
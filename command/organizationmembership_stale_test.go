@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockAuditTrailLister struct {
+	response *tfe.AuditTrailList
+	err      error
+}
+
+func (m *mockAuditTrailLister) List(_ context.Context, _ *tfe.AuditTrailListOptions) (*tfe.AuditTrailList, error) {
+	return m.response, m.err
+}
+
+func newStaleCommand(ui cli.Ui, auditTrails *mockAuditTrailLister) *OrganizationMembershipStaleCommand {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	return &OrganizationMembershipStaleCommand{
+		Meta: newTestMeta(ui),
+		now:  func() time.Time { return now },
+		membershipSvc: &mockOrganizationMembershipInviter{memberships: []*tfe.OrganizationMembership{
+			{ID: "ou-alice", Status: tfe.OrganizationMembershipActive, Email: "alice@example.com", User: &tfe.User{ID: "user-alice", Username: "alice"}},
+			{ID: "ou-bob", Status: tfe.OrganizationMembershipActive, Email: "bob@example.com", User: &tfe.User{ID: "user-bob", Username: "bob"},
+				Teams: []*tfe.Team{{ID: "team-con", Name: "contractors"}}},
+			{ID: "ou-carol", Status: tfe.OrganizationMembershipActive, Email: "carol@example.com", User: &tfe.User{ID: "user-carol", Username: "carol"}},
+			{ID: "ou-dan", Status: tfe.OrganizationMembershipInvited, Email: "dan@example.com"},
+		}},
+		runSvc: &mockRunOrgListService{response: &tfe.OrganizationRunList{Items: []*tfe.Run{
+			{ID: "run-1", CreatedAt: now.AddDate(0, 0, -5), CreatedBy: &tfe.User{ID: "user-alice"}},
+			{ID: "run-2", CreatedAt: now.AddDate(0, 0, -200), CreatedBy: &tfe.User{ID: "user-bob"}},
+		}}},
+		auditTrailSvc: auditTrails,
+	}
+}
+
+func TestOrganizationMembershipStale(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStaleCommand(ui, &mockAuditTrailLister{response: &tfe.AuditTrailList{Items: []*tfe.AuditTrail{
+		{ID: "ae-1", Timestamp: time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC), Auth: tfe.AuditTrailAuth{AccessorID: "user-carol"}},
+	}}})
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var stale []staleMember
+	if err := json.Unmarshal([]byte(output), &stale); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	var got []string
+	for _, m := range stale {
+		got = append(got, m.Email+": "+m.Reason)
+	}
+	want := "bob@example.com: no runs or audit trail events in 90 days,dan@example.com: invitation pending"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("got %v, want %s", got, want)
+	}
+}
+
+func TestOrganizationMembershipStaleWithoutAuditTrail(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStaleCommand(ui, &mockAuditTrailLister{err: tfe.ErrUnauthorized})
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-output=csv"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "using runs only") {
+		t.Fatalf("expected audit trail warning, got %q", ui.ErrorWriter.String())
+	}
+	if !strings.Contains(output, "bob,bob@example.com,active,contractors,no runs in 90 days") ||
+		!strings.Contains(output, "carol@example.com") {
+		t.Fatalf("unexpected CSV: %q", output)
+	}
+}
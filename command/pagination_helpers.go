@@ -0,0 +1,382 @@
+package command
+
+import (
+	"context"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+const defaultListPageSize = 100
+
+// listAllWorkspaces pages through every workspace in an organization that
+// matches the given list options.
+func listAllWorkspaces(ctx context.Context, svc workspaceLister, organization string, options *tfe.WorkspaceListOptions) ([]*tfe.Workspace, error) {
+	opts := tfe.WorkspaceListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultListPageSize
+	}
+	if opts.PageNumber == 0 {
+		opts.PageNumber = 1
+	}
+
+	var workspaces []*tfe.Workspace
+	for {
+		list, err := svc.List(ctx, organization, &opts)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return workspaces, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllRemoteStateConsumers pages through every workspace that is allowed
+// to read the state of the given workspace.
+func listAllRemoteStateConsumers(ctx context.Context, svc workspaceRemoteStateConsumerLister, workspaceID string) ([]*tfe.Workspace, error) {
+	opts := &tfe.RemoteStateConsumersListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var consumers []*tfe.Workspace
+	for {
+		list, err := svc.RemoteStateConsumers(ctx, workspaceID, opts)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return consumers, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllProjects pages through every project in an organization.
+func listAllProjects(ctx context.Context, svc projectLister, organization string) ([]*tfe.Project, error) {
+	opts := &tfe.ProjectListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var projects []*tfe.Project
+	for {
+		list, err := svc.List(ctx, organization, opts)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return projects, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllTeams pages through every team in an organization that matches the
+// given list options.
+func listAllTeams(ctx context.Context, svc teamLister, organization string, options *tfe.TeamListOptions) ([]*tfe.Team, error) {
+	opts := tfe.TeamListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultListPageSize
+	}
+	if opts.PageNumber == 0 {
+		opts.PageNumber = 1
+	}
+
+	var teams []*tfe.Team
+	for {
+		list, err := svc.List(ctx, organization, &opts)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return teams, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllTeamTokens pages through every team token in an organization.
+func listAllTeamTokens(ctx context.Context, svc teamTokenLister, organization string) ([]*tfe.TeamToken, error) {
+	opts := &tfe.TeamTokenListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var tokens []*tfe.TeamToken
+	for {
+		list, err := svc.List(ctx, organization, opts)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return tokens, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllOrganizationMemberships pages through every organization
+// membership that matches the given list options.
+func listAllOrganizationMemberships(ctx context.Context, svc organizationMembershipLister, organization string, options *tfe.OrganizationMembershipListOptions) ([]*tfe.OrganizationMembership, error) {
+	opts := tfe.OrganizationMembershipListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultListPageSize
+	}
+	if opts.PageNumber == 0 {
+		opts.PageNumber = 1
+	}
+
+	var memberships []*tfe.OrganizationMembership
+	for {
+		list, err := svc.List(ctx, organization, &opts)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return memberships, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllAgentPools pages through every agent pool in an organization.
+func listAllAgentPools(ctx context.Context, svc agentPoolLister, organization string) ([]*tfe.AgentPool, error) {
+	opts := &tfe.AgentPoolListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var pools []*tfe.AgentPool
+	for {
+		list, err := svc.List(ctx, organization, opts)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return pools, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllRunTriggers pages through every inbound or outbound run trigger of
+// a workspace.
+func listAllRunTriggers(ctx context.Context, svc runTriggerLister, workspaceID string, triggerType tfe.RunTriggerFilterOp) ([]*tfe.RunTrigger, error) {
+	opts := &tfe.RunTriggerListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		RunTriggerType: triggerType,
+	}
+
+	var triggers []*tfe.RunTrigger
+	for {
+		list, err := svc.List(ctx, workspaceID, opts)
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return triggers, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllTeamAccess pages through every team access grant on a workspace.
+func listAllTeamAccess(ctx context.Context, svc teamAccessLister, workspaceID string) ([]*tfe.TeamAccess, error) {
+	opts := &tfe.TeamAccessListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		WorkspaceID: workspaceID,
+	}
+
+	var access []*tfe.TeamAccess
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		access = append(access, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return access, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllProjectTeamAccess pages through every team access grant on a
+// project.
+func listAllProjectTeamAccess(ctx context.Context, svc projectTeamAccessLister, projectID string) ([]*tfe.TeamProjectAccess, error) {
+	opts := tfe.TeamProjectAccessListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		ProjectID: projectID,
+	}
+
+	var access []*tfe.TeamProjectAccess
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		access = append(access, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return access, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllVariables pages through every variable in a workspace.
+func listAllVariables(ctx context.Context, svc variableLister, workspaceID string) ([]*tfe.Variable, error) {
+	opts := &tfe.VariableListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var variables []*tfe.Variable
+	for {
+		list, err := svc.List(ctx, workspaceID, opts)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return variables, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllVariableSets pages through every variable set in an organization.
+func listAllVariableSets(ctx context.Context, svc variableSetLister, organization string, options *tfe.VariableSetListOptions) ([]*tfe.VariableSet, error) {
+	opts := tfe.VariableSetListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = defaultListPageSize
+	}
+	if opts.PageNumber == 0 {
+		opts.PageNumber = 1
+	}
+
+	var sets []*tfe.VariableSet
+	for {
+		list, err := svc.List(ctx, organization, &opts)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return sets, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllVariableSetVariables pages through every variable in a variable set.
+func listAllVariableSetVariables(ctx context.Context, svc variableSetVariableLister, variableSetID string) ([]*tfe.VariableSetVariable, error) {
+	opts := &tfe.VariableSetVariableListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+	}
+
+	var variables []*tfe.VariableSetVariable
+	for {
+		list, err := svc.List(ctx, variableSetID, opts)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, list.Items...)
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return variables, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// listAllStateVersions pages through a workspace's state versions, newest
+// first. A positive limit stops after that many versions.
+func listAllStateVersions(ctx context.Context, svc stateVersionLister, organization, workspace string, limit int) ([]*tfe.StateVersion, error) {
+	opts := &tfe.StateVersionListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		Organization: organization,
+		Workspace:    workspace,
+	}
+
+	var versions []*tfe.StateVersion
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, list.Items...)
+
+		if limit > 0 && len(versions) >= limit {
+			return versions[:limit], nil
+		}
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			return versions, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// hasNextPage reports whether the pagination block points at a page after
+// the current one.
+func hasNextPage(pagination *tfe.Pagination, current int) bool {
+	return pagination != nil && pagination.NextPage != 0 && pagination.NextPage > current
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+func TestListAllWorkspacesFollowsPagination(t *testing.T) {
+	svc := &mockWorkspacePagedListService{
+		pages: [][]*tfe.Workspace{
+			{{ID: "ws-1", Name: "one"}, {ID: "ws-2", Name: "two"}},
+			{{ID: "ws-3", Name: "three"}},
+		},
+	}
+
+	workspaces, err := listAllWorkspaces(context.Background(), svc, "my-org", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(workspaces) != 3 {
+		t.Fatalf("expected 3 workspaces, got %d", len(workspaces))
+	}
+	if svc.callCount != 2 {
+		t.Fatalf("expected 2 list calls, got %d", svc.callCount)
+	}
+	if svc.lastOrg != "my-org" {
+		t.Fatalf("expected organization my-org, got %s", svc.lastOrg)
+	}
+}
+
+func TestListAllWorkspacesReturnsError(t *testing.T) {
+	svc := &mockWorkspacePagedListService{err: errors.New("boom")}
+
+	if _, err := listAllWorkspaces(context.Background(), svc, "my-org", nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListAllRemoteStateConsumers(t *testing.T) {
+	svc := &mockRemoteStateConsumerService{
+		consumers: map[string][]*tfe.Workspace{
+			"ws-1": {{ID: "ws-2", Name: "app"}},
+		},
+	}
+
+	consumers, err := listAllRemoteStateConsumers(context.Background(), svc, "ws-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(consumers) != 1 || consumers[0].Name != "app" {
+		t.Fatalf("unexpected consumers: %#v", consumers)
+	}
+}
+
+func TestHasNextPage(t *testing.T) {
+	if hasNextPage(nil, 1) {
+		t.Fatal("expected no next page without pagination")
+	}
+	if hasNextPage(&tfe.Pagination{NextPage: 0}, 1) {
+		t.Fatal("expected no next page when next page is zero")
+	}
+	if !hasNextPage(&tfe.Pagination{NextPage: 2}, 1) {
+		t.Fatal("expected next page")
+	}
+}
@@ -18,6 +18,10 @@ type policySetReaderWithOptions interface {
 	ReadWithOptions(ctx context.Context, policySetID string, options *tfe.PolicySetReadOptions) (*tfe.PolicySet, error)
 }
 
+type policySetParameterReader interface {
+	Read(ctx context.Context, policySetID string, parameterID string) (*tfe.PolicySetParameter, error)
+}
+
 type policySetWorkspaceAdder interface {
 	AddWorkspaces(ctx context.Context, policySetID string, options tfe.PolicySetAddWorkspacesOptions) error
 }
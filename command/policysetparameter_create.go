@@ -12,7 +12,7 @@ type PolicySetParameterCreateCommand struct {
 	Meta
 	policySetID string
 	key         string
-	value       string
+	value       variableValueFlags
 	sensitive   bool
 	format      string
 }
@@ -22,7 +22,7 @@ func (c *PolicySetParameterCreateCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet("policysetparameter create")
 	flags.StringVar(&c.policySetID, "policy-set-id", "", "Policy Set ID (required)")
 	flags.StringVar(&c.key, "key", "", "Parameter key (required)")
-	flags.StringVar(&c.value, "value", "", "Parameter value (required)")
+	c.value.register(flags, "Parameter value (not allowed with -sensitive)")
 	flags.BoolVar(&c.sensitive, "sensitive", false, "Mark parameter as sensitive")
 	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
 
@@ -43,15 +43,24 @@ func (c *PolicySetParameterCreateCommand) Run(args []string) int {
 		return 1
 	}
 
-	if c.Meta.JSONInput == "" && c.value == "" {
-		c.Ui.Error("Error: -value flag is required")
+	if !c.Meta.ValidateID(c.policySetID, "-policy-set-id") {
 		c.Ui.Error(c.Help())
 		return 1
 	}
 
-	if !c.Meta.ValidateID(c.policySetID, "-policy-set-id") {
-		c.Ui.Error(c.Help())
-		return 1
+	var value string
+	if c.Meta.JSONInput == "" {
+		resolved, ok, err := c.value.resolve(c.Ui, c.key, c.sensitive, true)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if !ok {
+			c.Ui.Error("Error: a value is required; use -value, -value-file, -value-stdin, or -value-env")
+			c.Ui.Error(c.Help())
+			return 1
+		}
+		value = resolved
 	}
 
 	// Get API client
@@ -72,7 +81,7 @@ func (c *PolicySetParameterCreateCommand) Run(args []string) int {
 	} else {
 		options = tfe.PolicySetParameterCreateOptions{
 			Key:       tfe.String(c.key),
-			Value:     tfe.String(c.value),
+			Value:     tfe.String(value),
 			Category:  &category,
 			Sensitive: tfe.Bool(c.sensitive),
 		}
@@ -94,8 +103,13 @@ func (c *PolicySetParameterCreateCommand) Run(args []string) int {
 		c.Ui.Error(c.Help())
 		return 1
 	}
+	if err := checkInlineJSONSecret(&c.Meta, options.Value, options.Sensitive); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
 
 	if c.Meta.DryRun {
+		options.Value = redactVariableValue(options.Value)
 		formatter := c.Meta.NewFormatter("json")
 		formatter.JSON(map[string]interface{}{
 			"action":        "create",
@@ -118,15 +132,15 @@ func (c *PolicySetParameterCreateCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("Policy set parameter '%s' created successfully", parameter.Key))
 
 	// Show parameter details
-	value := parameter.Value
+	displayValue := parameter.Value
 	if parameter.Sensitive {
-		value = "(sensitive)"
+		displayValue = "(sensitive)"
 	}
 
 	data := map[string]interface{}{
 		"ID":        parameter.ID,
 		"Key":       parameter.Key,
-		"Value":     value,
+		"Value":     displayValue,
 		"Category":  string(parameter.Category),
 		"Sensitive": parameter.Sensitive,
 	}
@@ -143,18 +157,27 @@ Usage: hcptf policysetparameter create [options]
   Create a parameter for a policy set. Parameters are key/value pairs that
   Sentinel uses during policy checks. Use the -sensitive flag for secret values.
 
+  The value can be given with exactly one of -value, -value-file,
+  -value-stdin, or -value-env. If none is given and stdin is a terminal,
+  the value is prompted for with input hidden. Sensitive values cannot be
+  passed with -value, so they never appear in shell history or process
+  listings. -dry-run output shows the value as (redacted).
+
 Options:
 
   -policy-set-id=<id>  Policy Set ID (required)
   -key=<name>          Parameter key (required)
-  -value=<value>       Parameter value (required)
+  -value=<value>       Parameter value (not allowed with -sensitive)
+  -value-file=<path>   Read the value from a file
+  -value-stdin         Read the value from stdin
+  -value-env=<name>    Read the value from an environment variable
   -sensitive           Mark parameter as sensitive (write-once, not visible thereafter)
   -output=<format>     Output format: table (default) or json
 
 Example:
 
   hcptf policysetparameter create -policy-set-id=polset-abc123 -key=max_cost -value=1000
-  hcptf policysetparameter create -policy-set-id=polset-abc123 -key=api_key -value=secret -sensitive
+  hcptf policysetparameter create -policy-set-id=polset-abc123 -key=api_key -sensitive -value-env=API_KEY
 `
 	return strings.TrimSpace(helpText)
 }
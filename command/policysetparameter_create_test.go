@@ -170,7 +170,7 @@ func TestPolicySetParameterCreateFlagParsing(t *testing.T) {
 			flags := cmd.Meta.FlagSet("policysetparameter create")
 			flags.StringVar(&cmd.policySetID, "policy-set-id", "", "Policy Set ID (required)")
 			flags.StringVar(&cmd.key, "key", "", "Parameter key (required)")
-			flags.StringVar(&cmd.value, "value", "", "Parameter value (required)")
+			cmd.value.register(flags, "Parameter value (required)")
 			flags.BoolVar(&cmd.sensitive, "sensitive", false, "Mark parameter as sensitive")
 			flags.StringVar(&cmd.format, "output", "table", "Output format: table or json")
 
@@ -189,8 +189,8 @@ func TestPolicySetParameterCreateFlagParsing(t *testing.T) {
 			}
 
 			// Verify the value was set correctly
-			if cmd.value != tt.expectedValue {
-				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value)
+			if cmd.value.value != tt.expectedValue {
+				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value.value)
 			}
 
 			// Verify the sensitive flag was set correctly
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
 )
 
 // PolicySetParameterUpdateCommand is a command to update a policy set parameter
@@ -14,9 +15,10 @@ type PolicySetParameterUpdateCommand struct {
 	policySetID string
 	parameterID string
 	key         string
-	value       string
+	value       variableValueFlags
 	sensitive   *bool
 	format      string
+	readSvc     policySetParameterReader
 }
 
 // Run executes the policy set parameter update command
@@ -25,7 +27,7 @@ func (c *PolicySetParameterUpdateCommand) Run(args []string) int {
 	flags.StringVar(&c.policySetID, "policy-set-id", "", "Policy Set ID (required)")
 	flags.StringVar(&c.parameterID, "id", "", "Parameter ID (required)")
 	flags.StringVar(&c.key, "key", "", "Parameter key")
-	flags.StringVar(&c.value, "value", "", "Parameter value")
+	c.value.register(flags, "Parameter value (not allowed for sensitive parameters)")
 	sensitiveFlag := flags.Bool("sensitive", false, "Mark parameter as sensitive")
 	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
 
@@ -63,7 +65,7 @@ func (c *PolicySetParameterUpdateCommand) Run(args []string) int {
 	}
 
 	// At least one field must be provided for update
-	if c.Meta.JSONInput == "" && c.key == "" && c.value == "" && c.sensitive == nil {
+	if c.Meta.JSONInput == "" && c.key == "" && !c.value.isSet() && c.sensitive == nil {
 		c.Ui.Error("Error: At least one of -key, -value, or -sensitive must be provided")
 		c.Ui.Error(c.Help())
 		return 1
@@ -87,8 +89,29 @@ func (c *PolicySetParameterUpdateCommand) Run(args []string) int {
 		if c.key != "" {
 			options.Key = tfe.String(c.key)
 		}
-		if c.value != "" {
-			options.Value = tfe.String(c.value)
+		// -value is refused for sensitive parameters, including ones that
+		// are already sensitive, so check the current parameter first.
+		sensitive := c.sensitive != nil && *c.sensitive
+		if c.value.value != "" && !sensitive {
+			current, err := c.parameterReadService(client).Read(client.Context(), c.policySetID, c.parameterID)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading policy set parameter: %s", err))
+				return 1
+			}
+			sensitive = current.Sensitive
+		}
+
+		prompt := c.key
+		if prompt == "" {
+			prompt = c.parameterID
+		}
+		value, ok, err := c.value.resolve(c.Ui, prompt, sensitive, false)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if ok {
+			options.Value = tfe.String(value)
 		}
 		if c.sensitive != nil {
 			options.Sensitive = tfe.Bool(*c.sensitive)
@@ -103,8 +126,13 @@ func (c *PolicySetParameterUpdateCommand) Run(args []string) int {
 		c.Ui.Error(c.Help())
 		return 1
 	}
+	if err := checkInlineJSONSecret(&c.Meta, options.Value, options.Sensitive); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
 
 	if c.Meta.DryRun {
+		options.Value = redactVariableValue(options.Value)
 		formatter := c.Meta.NewFormatter("json")
 		formatter.JSON(map[string]interface{}{
 			"action":        "update",
@@ -145,6 +173,13 @@ func (c *PolicySetParameterUpdateCommand) Run(args []string) int {
 	return 0
 }
 
+func (c *PolicySetParameterUpdateCommand) parameterReadService(client *client.Client) policySetParameterReader {
+	if c.readSvc != nil {
+		return c.readSvc
+	}
+	return client.PolicySetParameters
+}
+
 // Help returns help text for the policy set parameter update command
 func (c *PolicySetParameterUpdateCommand) Help() string {
 	helpText := `
@@ -153,12 +188,21 @@ Usage: hcptf policysetparameter update [options]
   Update a policy set parameter. You can update the key, value, or
   sensitive flag. At least one field must be provided.
 
+  A new value can be given with exactly one of -value, -value-file,
+  -value-stdin, or -value-env. -value is refused when the parameter is or
+  is being made sensitive, so secrets never appear in shell history or
+  process listings. -value-stdin prompts with input hidden when stdin is a
+  terminal. -dry-run output shows the value as (redacted).
+
 Options:
 
   -policy-set-id=<id>  Policy Set ID (required)
   -id=<parameter-id>   Parameter ID (required)
   -key=<name>          New parameter key
-  -value=<value>       New parameter value
+  -value=<value>       New parameter value (not allowed for sensitive parameters)
+  -value-file=<path>   Read the new value from a file
+  -value-stdin         Read the new value from stdin
+  -value-env=<name>    Read the new value from an environment variable
   -sensitive           Mark parameter as sensitive
   -output=<format>     Output format: table (default) or json
 
@@ -166,6 +210,7 @@ Example:
 
   hcptf policysetparameter update -policy-set-id=polset-abc123 -id=var-xyz789 -value=2000
   hcptf policysetparameter update -policy-set-id=polset-abc123 -id=var-xyz789 -key=new_key -value=new_value
+  hcptf policysetparameter update -policy-set-id=polset-abc123 -id=var-xyz789 -value-stdin
 `
 	return strings.TrimSpace(helpText)
 }
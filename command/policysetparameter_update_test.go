@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/mitchellh/cli"
 )
 
@@ -189,7 +190,7 @@ func TestPolicySetParameterUpdateFlagParsing(t *testing.T) {
 			flags.StringVar(&cmd.policySetID, "policy-set-id", "", "Policy Set ID (required)")
 			flags.StringVar(&cmd.parameterID, "id", "", "Parameter ID (required)")
 			flags.StringVar(&cmd.key, "key", "", "Parameter key")
-			flags.StringVar(&cmd.value, "value", "", "Parameter value")
+			cmd.value.register(flags, "Parameter value")
 			sensitiveFlag := flags.Bool("sensitive", false, "Mark parameter as sensitive")
 			flags.StringVar(&cmd.format, "output", "table", "Output format: table or json")
 
@@ -213,8 +214,8 @@ func TestPolicySetParameterUpdateFlagParsing(t *testing.T) {
 			}
 
 			// Verify the value was set correctly
-			if cmd.value != tt.expectedValue {
-				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value)
+			if cmd.value.value != tt.expectedValue {
+				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value.value)
 			}
 
 			// Verify the format was set correctly
@@ -230,3 +231,22 @@ func TestPolicySetParameterUpdateFlagParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicySetParameterUpdateRejectsValueFlagForSensitiveParameter(t *testing.T) {
+	ui := cli.NewMockUi()
+	read := &mockPolicySetParameterReadService{response: &tfe.PolicySetParameter{ID: "var-1", Key: "token", Sensitive: true}}
+	cmd := &PolicySetParameterUpdateCommand{
+		Meta:    newTestMeta(ui),
+		readSvc: read,
+	}
+
+	if code := cmd.Run([]string{"-policy-set-id=polset-1", "-id=var-1", "-value=hunter2"}); code != 1 {
+		t.Fatalf("expected exit 1")
+	}
+	if read.lastID != "var-1" {
+		t.Fatalf("expected current parameter to be read")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cannot be passed with -value") {
+		t.Fatalf("expected -value error, got %q", ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,419 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	accessTemplateCreate    = "create"
+	accessTemplateUpdate    = "update"
+	accessTemplateRemove    = "remove"
+	accessTemplateUnchanged = "unchanged"
+)
+
+// ProjectApplyAccessTemplateCommand is a command to make existing projects'
+// team access match an access template
+type ProjectApplyAccessTemplateCommand struct {
+	Meta
+	organization         string
+	projectID            string
+	all                  bool
+	accessTemplate       string
+	accessTemplateFile   string
+	prune                bool
+	autoApprove          bool
+	format               string
+	projectSvc           projectLister
+	projectReadSvc       projectReader
+	teamSvc              teamLister
+	projectTeamAccessSvc projectTeamAccessManager
+}
+
+// accessTemplateChange is one difference between a project's team access
+// and an access template, and the result of resolving it.
+type accessTemplateChange struct {
+	ProjectID string `json:"project_id"`
+	Project   string `json:"project"`
+	Team      string `json:"team"`
+	Action    string `json:"action"`
+	Current   string `json:"current,omitempty"`
+	Template  string `json:"template,omitempty"`
+	Result    string `json:"result,omitempty"`
+
+	accessID string
+	grant    accessGrant
+}
+
+// Run executes the project apply-access-template command
+func (c *ProjectApplyAccessTemplateCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("project apply-access-template")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "id", "", "Project ID")
+	flags.BoolVar(&c.all, "all", false, "Apply the template to every project in the organization")
+	flags.StringVar(&c.accessTemplate, "access-template", "", "Access template to apply (required)")
+	flags.StringVar(&c.accessTemplateFile, "access-template-file", "", "File with access templates, checked before the CLI config file")
+	flags.BoolVar(&c.prune, "prune", false, "Remove access for teams that are not in the template")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.accessTemplate == "" {
+		c.Ui.Error("Error: -access-template flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if (c.projectID == "") == !c.all {
+		c.Ui.Error("Error: exactly one of -id or -all is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	tmpl, err := c.Meta.accessTemplate(c.accessTemplateFile, c.accessTemplate)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading access template: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	teams, err := listAllTeams(ctx, c.teamService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+		return 1
+	}
+	teamIDs := map[string]string{}
+	teamNames := map[string]string{}
+	for _, team := range teams {
+		teamIDs[team.Name] = team.ID
+		teamNames[team.ID] = team.Name
+	}
+
+	grants, err := accessTemplateGrants(tmpl, teamIDs)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	var projects []*tfe.Project
+	if c.all {
+		projects, err = listAllProjects(ctx, c.projectService(client), c.organization)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing projects: %s", err))
+			return 1
+		}
+	} else {
+		project, err := c.projectReadService(client).Read(ctx, c.projectID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading project: %s", err))
+			return 1
+		}
+		projects = []*tfe.Project{project}
+	}
+
+	changes := []*accessTemplateChange{}
+	for _, project := range projects {
+		existing, err := listAllProjectTeamAccess(ctx, c.projectTeamAccessService(client), project.ID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing team access for project '%s': %s", project.Name, err))
+			return 1
+		}
+		for _, change := range planAccessTemplate(project, grants, existing, teamNames, c.prune) {
+			if change.Action != accessTemplateUnchanged {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":          "apply-access-template",
+			"resource":        "project",
+			"organization":    c.organization,
+			"access_template": c.accessTemplate,
+			"changes":         changes,
+		})
+		return 0
+	}
+
+	if len(changes) == 0 {
+		if c.format == "json" {
+			c.Meta.NewFormatter("json").JSON(changes)
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("%d projects already match access template '%s'", len(projects), c.accessTemplate))
+		return 0
+	}
+
+	if !c.autoApprove {
+		c.renderChanges(changes, false)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Do you want to apply access template '%s' to these projects?", c.accessTemplate))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Apply cancelled.")
+			return 0
+		}
+	}
+
+	failed := 0
+	for _, change := range changes {
+		if err := c.apply(ctx, client, change); err != nil {
+			change.Result = fmt.Sprintf("error: %s", err)
+			failed++
+			continue
+		}
+		change.Result = "ok"
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(changes)
+	} else {
+		c.renderChanges(changes, true)
+		counts := map[string]int{}
+		for _, change := range changes {
+			if change.Result == "ok" {
+				counts[change.Action]++
+			}
+		}
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("%d created, %d updated, %d removed, %d failed",
+			counts[accessTemplateCreate], counts[accessTemplateUpdate], counts[accessTemplateRemove], failed))
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// planAccessTemplate compares a project's team access with the template's
+// grants. Teams missing from the project are granted access and teams with
+// different access are updated. Teams that are not in the template are
+// removed only with prune.
+func planAccessTemplate(project *tfe.Project, grants []accessGrant, existing []*tfe.TeamProjectAccess, teamNames map[string]string, prune bool) []*accessTemplateChange {
+	current := map[string]*tfe.TeamProjectAccess{}
+	for _, pta := range existing {
+		if pta.Team != nil {
+			current[pta.Team.ID] = pta
+		}
+	}
+
+	changes := []*accessTemplateChange{}
+	inTemplate := map[string]bool{}
+	for _, g := range grants {
+		inTemplate[g.TeamID] = true
+		change := &accessTemplateChange{
+			ProjectID: project.ID,
+			Project:   project.Name,
+			Team:      g.Team,
+			Action:    accessTemplateCreate,
+			Template:  g.describe(true),
+			grant:     g,
+		}
+		if pta, ok := current[g.TeamID]; ok {
+			have := projectAccessGrant(pta, g.Team)
+			change.accessID = pta.ID
+			change.Current = have.describe(true)
+			change.Action = accessTemplateUpdate
+			if sameProjectAccess(have, g) {
+				change.Action = accessTemplateUnchanged
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	if !prune {
+		return changes
+	}
+
+	var removals []*accessTemplateChange
+	for teamID, pta := range current {
+		if inTemplate[teamID] {
+			continue
+		}
+		name := teamNames[teamID]
+		if name == "" {
+			name = teamID
+		}
+		removals = append(removals, &accessTemplateChange{
+			ProjectID: project.ID,
+			Project:   project.Name,
+			Team:      name,
+			Action:    accessTemplateRemove,
+			Current:   projectAccessGrant(pta, name).describe(true),
+			accessID:  pta.ID,
+		})
+	}
+	sort.Slice(removals, func(i, j int) bool { return removals[i].Team < removals[j].Team })
+	return append(changes, removals...)
+}
+
+// apply resolves one difference through the project team access API.
+func (c *ProjectApplyAccessTemplateCommand) apply(ctx context.Context, client *client.Client, change *accessTemplateChange) error {
+	svc := c.projectTeamAccessService(client)
+	switch change.Action {
+	case accessTemplateCreate:
+		_, err := svc.Add(ctx, accessTemplateAddOptions(change.ProjectID, change.grant))
+		return err
+	case accessTemplateUpdate:
+		_, err := svc.Update(ctx, change.accessID, accessTemplateUpdateOptions(change.grant))
+		return err
+	case accessTemplateRemove:
+		return svc.Remove(ctx, change.accessID)
+	}
+	return fmt.Errorf("unknown action %q", change.Action)
+}
+
+// renderChanges prints the differences to resolve, with the result of
+// each once they have been applied.
+func (c *ProjectApplyAccessTemplateCommand) renderChanges(changes []*accessTemplateChange, withResult bool) {
+	headers := []string{"Project", "Team", "Action", "Current", "Template"}
+	if withResult {
+		headers = append(headers, "Result")
+	}
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		current, template := change.Current, change.Template
+		if current == "" {
+			current = "-"
+		}
+		if template == "" {
+			template = "-"
+		}
+		row := []string{change.Project, change.Team, change.Action, current, template}
+		if withResult {
+			row = append(row, change.Result)
+		}
+		rows = append(rows, row)
+	}
+	c.Meta.NewFormatter("table").Table(headers, rows)
+}
+
+func (c *ProjectApplyAccessTemplateCommand) projectService(client *client.Client) projectLister {
+	if c.projectSvc != nil {
+		return c.projectSvc
+	}
+	return client.Projects
+}
+
+func (c *ProjectApplyAccessTemplateCommand) projectReadService(client *client.Client) projectReader {
+	if c.projectReadSvc != nil {
+		return c.projectReadSvc
+	}
+	return client.Projects
+}
+
+func (c *ProjectApplyAccessTemplateCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *ProjectApplyAccessTemplateCommand) projectTeamAccessService(client *client.Client) projectTeamAccessManager {
+	if c.projectTeamAccessSvc != nil {
+		return c.projectTeamAccessSvc
+	}
+	return client.TeamProjectAccess
+}
+
+// Help returns help text for the project apply-access-template command
+func (c *ProjectApplyAccessTemplateCommand) Help() string {
+	helpText := `
+Usage: hcptf project apply-access-template [options]
+
+  Make the team access of existing projects match an access template, and
+  report where they differ.
+
+  Templates are access_template blocks in the CLI config file
+  (~/.hcptfrc), or in a file given with -access-template-file, which is
+  checked first. Each team block grants one team access to the project:
+
+    access_template "standard" {
+      team "platform" {
+        access = "admin"
+      }
+      team "developers" {
+        access           = "custom"
+        project_settings = "read"    # read (default), update, delete
+        project_teams    = "none"    # none (default), read, manage
+        runs             = "apply"   # read (default), plan, apply
+        variables        = "write"   # none (default), read, write
+        state_versions   = "read"    # none (default), read-outputs, read, write
+        sentinel_mocks   = "none"    # none (default), read
+        create_workspace = true      # create_workspace, delete_workspace,
+        locking          = true      # move_workspace, locking, and run_tasks
+      }                              # default to false
+    }
+
+  Access is read, write, maintain, admin, or custom; the permissions only
+  apply to custom access. Every team must exist.
+
+  Teams without access are granted it, and teams whose access differs are
+  updated. Teams that are not in the template keep their access unless
+  -prune is set. The differences are shown and must be confirmed unless
+  -auto-approve is set. Use -dry-run to print them as JSON without
+  changing anything.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -id=<project-id>      Project ID
+  -all                  Apply the template to every project in the
+                        organization (one of -id or -all is required)
+  -access-template=<name>
+                        Access template to apply (required)
+  -access-template-file=<path>
+                        File with access templates, checked before the
+                        CLI config file
+  -prune                Remove access for teams that are not in the
+                        template
+  -auto-approve         Skip confirmation
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf project apply-access-template -org=my-org -id=prj-123abc -access-template=standard -dry-run
+  hcptf project apply-access-template -org=my-org -all -access-template=standard
+  hcptf project apply-access-template -org=my-org -all -access-template=standard \
+    -access-template-file=access-templates.hcl -prune -auto-approve
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the project apply-access-template command
+func (c *ProjectApplyAccessTemplateCommand) Synopsis() string {
+	return "Apply an access template to existing projects"
+}
@@ -0,0 +1,219 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/config"
+	"github.com/mitchellh/cli"
+)
+
+type mockProjectTeamAccessManager struct {
+	access  map[string][]*tfe.TeamProjectAccess
+	added   []tfe.TeamProjectAccessAddOptions
+	updated map[string]tfe.TeamProjectAccessUpdateOptions
+	removed []string
+	addErr  error
+}
+
+func (m *mockProjectTeamAccessManager) List(_ context.Context, options tfe.TeamProjectAccessListOptions) (*tfe.TeamProjectAccessList, error) {
+	return &tfe.TeamProjectAccessList{Items: m.access[options.ProjectID]}, nil
+}
+
+func (m *mockProjectTeamAccessManager) Add(_ context.Context, options tfe.TeamProjectAccessAddOptions) (*tfe.TeamProjectAccess, error) {
+	if m.addErr != nil {
+		return nil, m.addErr
+	}
+	m.added = append(m.added, options)
+	return &tfe.TeamProjectAccess{ID: "tprj-new", Access: options.Access, Team: options.Team, Project: options.Project}, nil
+}
+
+func (m *mockProjectTeamAccessManager) Update(_ context.Context, teamProjectAccessID string, options tfe.TeamProjectAccessUpdateOptions) (*tfe.TeamProjectAccess, error) {
+	if m.updated == nil {
+		m.updated = map[string]tfe.TeamProjectAccessUpdateOptions{}
+	}
+	m.updated[teamProjectAccessID] = options
+	return &tfe.TeamProjectAccess{ID: teamProjectAccessID, Access: *options.Access}, nil
+}
+
+func (m *mockProjectTeamAccessManager) Remove(_ context.Context, teamProjectAccessID string) error {
+	m.removed = append(m.removed, teamProjectAccessID)
+	return nil
+}
+
+// testAccessTemplates is a CLI configuration with one access template:
+// platform gets admin and developers get custom access.
+func testAccessTemplates() *config.Config {
+	return &config.Config{AccessTemplates: map[string]*config.AccessTemplate{
+		"standard": {Name: "standard", Teams: []*config.AccessTemplateTeam{
+			{Name: "platform", Access: "admin"},
+			{Name: "developers", Access: "custom", Runs: "apply", Variables: "write", CreateWorkspace: true},
+		}},
+	}}
+}
+
+func testAccessTemplateTeams() *mockTeamListService {
+	return &mockTeamListService{response: &tfe.TeamList{Items: []*tfe.Team{
+		{ID: "team-platform", Name: "platform"},
+		{ID: "team-dev", Name: "developers"},
+		{ID: "team-contractors", Name: "contractors"},
+	}}}
+}
+
+func newApplyAccessTemplateCommand(ui cli.Ui) (*ProjectApplyAccessTemplateCommand, *mockProjectTeamAccessManager) {
+	access := &mockProjectTeamAccessManager{access: map[string][]*tfe.TeamProjectAccess{
+		"prj-alpha": {
+			{ID: "tprj-a1", Access: tfe.TeamProjectAccessAdmin, Team: &tfe.Team{ID: "team-platform"}},
+			{ID: "tprj-a2", Access: tfe.TeamProjectAccessWrite, Team: &tfe.Team{ID: "team-dev"}},
+			{ID: "tprj-a3", Access: tfe.TeamProjectAccessRead, Team: &tfe.Team{ID: "team-contractors"}},
+		},
+	}}
+
+	meta := newTestMeta(ui)
+	meta.config = testAccessTemplates()
+	return &ProjectApplyAccessTemplateCommand{
+		Meta: meta,
+		projectSvc: &mockProjectListService{response: &tfe.ProjectList{Items: []*tfe.Project{
+			{ID: "prj-alpha", Name: "alpha"},
+			{ID: "prj-beta", Name: "beta"},
+		}}},
+		projectReadSvc:       &mockProjectReadService{response: &tfe.Project{ID: "prj-alpha", Name: "alpha"}},
+		teamSvc:              testAccessTemplateTeams(),
+		projectTeamAccessSvc: access,
+	}, access
+}
+
+func TestAccessTemplateGrant(t *testing.T) {
+	g, err := accessTemplateGrant(&config.AccessTemplateTeam{Name: "developers", Access: "custom", Runs: "plan", Locking: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "custom(create=false delete=false locking=true move=false project-settings=read project-teams=none run-tasks=false runs=plan sentinel-mocks=none state-versions=none variables=none)"
+	if got := g.describe(true); got != want {
+		t.Fatalf("describe = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		team *config.AccessTemplateTeam
+		want string
+	}{
+		{&config.AccessTemplateTeam{Access: "owner"}, `invalid access level "owner"`},
+		{&config.AccessTemplateTeam{Access: "custom", Variables: "admin"}, `invalid variables permission "admin"`},
+		{&config.AccessTemplateTeam{Access: "write", Runs: "apply"}, "runs only applies to custom access"},
+	}
+	for _, tt := range tests {
+		_, err := accessTemplateGrant(tt.team)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("access %q: expected error containing %q, got %v", tt.team.Access, tt.want, err)
+		}
+	}
+}
+
+func TestProjectApplyAccessTemplateRequiresProject(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newApplyAccessTemplateCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org", "-access-template=standard"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "exactly one of -id or -all") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestProjectApplyAccessTemplateUnknownTeam(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, access := newApplyAccessTemplateCommand(ui)
+	cmd.Meta.config.AccessTemplates["standard"].Teams[0].Name = "platfrom"
+
+	if code := cmd.Run([]string{"-org=my-org", "-all", "-access-template=standard", "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `team "platfrom" not found`) {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+	if len(access.added) != 0 {
+		t.Fatalf("expected no changes, got %d grants", len(access.added))
+	}
+}
+
+func TestProjectApplyAccessTemplateDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, access := newApplyAccessTemplateCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-all", "-access-template=standard", "-prune", "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(access.added) != 0 || len(access.updated) != 0 || len(access.removed) != 0 {
+		t.Fatalf("expected no API changes during dry-run")
+	}
+
+	var result struct {
+		Changes []accessTemplateChange `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	var got []string
+	for _, change := range result.Changes {
+		got = append(got, change.Project+":"+change.Team+":"+change.Action)
+	}
+	want := "alpha:developers:update,alpha:contractors:remove,beta:developers:create,beta:platform:create"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("changes = %v, want %s", got, want)
+	}
+}
+
+func TestProjectApplyAccessTemplateApply(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, access := newApplyAccessTemplateCommand(ui)
+
+	_, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-id=prj-alpha", "-access-template=standard", "-auto-approve"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	update, ok := access.updated["tprj-a2"]
+	if !ok || *update.Access != tfe.TeamProjectAccessCustom {
+		t.Fatalf("expected developers to be updated to custom access, got %v", access.updated)
+	}
+	if update.WorkspaceAccess == nil || *update.WorkspaceAccess.Runs != "apply" || !*update.WorkspaceAccess.Create {
+		t.Fatalf("unexpected workspace permissions: %+v", update.WorkspaceAccess)
+	}
+	if len(access.added) != 0 || len(access.removed) != 0 {
+		t.Fatalf("expected only an update without -prune, got %d added, %d removed", len(access.added), len(access.removed))
+	}
+}
+
+func TestProjectApplyAccessTemplateFileOverridesConfig(t *testing.T) {
+	path := writeTeamSyncFile(t, "access-templates.hcl", `
+access_template "standard" {
+  team "contractors" {
+    access = "read"
+  }
+}
+`)
+
+	ui := cli.NewMockUi()
+	cmd, access := newApplyAccessTemplateCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-id=prj-alpha", "-access-template=standard", "-access-template-file=" + path, "-auto-approve"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(access.added) != 0 || len(access.updated) != 0 {
+		t.Fatalf("expected no changes, got %d added, %d updated", len(access.added), len(access.updated))
+	}
+	if !strings.Contains(output, "1 projects already match access template 'standard'") {
+		t.Fatalf("unexpected output: %q", output)
+	}
+}
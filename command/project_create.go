@@ -5,15 +5,21 @@ import (
 	"strings"
 
 	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
 )
 
 // ProjectCreateCommand is a command to create a project
 type ProjectCreateCommand struct {
 	Meta
-	organization string
-	name         string
-	description  string
-	format       string
+	organization         string
+	name                 string
+	description          string
+	accessTemplate       string
+	accessTemplateFile   string
+	format               string
+	projectSvc           projectCreator
+	teamSvc              teamLister
+	projectTeamAccessSvc projectTeamAccessCreator
 }
 
 // Run executes the project create command
@@ -23,6 +29,8 @@ func (c *ProjectCreateCommand) Run(args []string) int {
 	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
 	flags.StringVar(&c.name, "name", "", "Project name (required)")
 	flags.StringVar(&c.description, "description", "", "Project description")
+	flags.StringVar(&c.accessTemplate, "access-template", "", "Access template to grant teams access with")
+	flags.StringVar(&c.accessTemplateFile, "access-template-file", "", "File with access templates, checked before the CLI config file")
 	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
 
 	if err := flags.Parse(args); err != nil {
@@ -82,27 +90,74 @@ func (c *ProjectCreateCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.accessTemplateFile != "" && c.accessTemplate == "" {
+		c.Ui.Error("Error: -access-template-file requires -access-template")
+		return 1
+	}
+
+	// Resolve the access template before creating anything, so a missing
+	// template or team does not leave a project without its access
+	var grants []accessGrant
+	if c.accessTemplate != "" {
+		tmpl, err := c.Meta.accessTemplate(c.accessTemplateFile, c.accessTemplate)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error loading access template: %s", err))
+			return 1
+		}
+
+		teams, err := listAllTeams(client.Context(), c.teamService(client), c.organization, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+			return 1
+		}
+		teamIDs := map[string]string{}
+		for _, team := range teams {
+			teamIDs[team.Name] = team.ID
+		}
+
+		grants, err = accessTemplateGrants(tmpl, teamIDs)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+	}
+
 	if c.Meta.DryRun {
-		formatter := c.Meta.NewFormatter("json")
-		formatter.JSON(map[string]interface{}{
+		plan := map[string]interface{}{
 			"action":       "create",
 			"resource":     "project",
 			"organization": c.organization,
 			"options":      options,
-		})
+		}
+		if c.accessTemplate != "" {
+			plan["access_template"] = c.accessTemplate
+			plan["team_access"] = grants
+		}
+		formatter := c.Meta.NewFormatter("json")
+		formatter.JSON(plan)
 		return 0
 	}
 
-	project, err := client.Projects.Create(client.Context(), c.organization, options)
+	project, err := c.projectService(client).Create(client.Context(), c.organization, options)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error creating project: %s", err))
 		return 1
 	}
 
+	for _, g := range grants {
+		if _, err := c.projectTeamAccessService(client).Add(client.Context(), accessTemplateAddOptions(project.ID, g)); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error granting team %s access to project '%s': %s", g.Team, project.Name, err))
+			c.Ui.Error(fmt.Sprintf("Run \"%s\" to finish applying the template.", c.applyAccessTemplateCommand(project.ID)))
+			return 1
+		}
+	}
+
 	// Format output
 	formatter := c.Meta.NewFormatter(c.format)
 
-	c.Ui.Output(fmt.Sprintf("Project '%s' created successfully", project.Name))
+	if c.format != "json" {
+		c.Ui.Output(fmt.Sprintf("Project '%s' created successfully", project.Name))
+	}
 
 	// Show project details
 	data := map[string]interface{}{
@@ -111,10 +166,58 @@ func (c *ProjectCreateCommand) Run(args []string) int {
 		"Description": project.Description,
 	}
 
+	if len(grants) > 0 && c.format != "table" {
+		access := make(map[string]string, len(grants))
+		for _, g := range grants {
+			access[g.Team] = g.describe(true)
+		}
+		data["TeamAccess"] = access
+	}
+
 	formatter.KeyValue(data)
+
+	if len(grants) == 0 || c.format != "table" {
+		return 0
+	}
+
+	c.Ui.Output("")
+	for _, g := range grants {
+		c.Ui.Output(fmt.Sprintf("Granted team %s %s access", g.Team, g.describe(true)))
+	}
 	return 0
 }
 
+// applyAccessTemplateCommand returns the command that finishes applying
+// the access template to the project if a grant fails.
+func (c *ProjectCreateCommand) applyAccessTemplateCommand(projectID string) string {
+	command := fmt.Sprintf("hcptf project apply-access-template -id=%s -access-template=%s", projectID, c.accessTemplate)
+	if c.accessTemplateFile != "" {
+		command += " -access-template-file=" + c.accessTemplateFile
+	}
+	return command
+}
+
+func (c *ProjectCreateCommand) projectService(client *client.Client) projectCreator {
+	if c.projectSvc != nil {
+		return c.projectSvc
+	}
+	return client.Projects
+}
+
+func (c *ProjectCreateCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *ProjectCreateCommand) projectTeamAccessService(client *client.Client) projectTeamAccessCreator {
+	if c.projectTeamAccessSvc != nil {
+		return c.projectTeamAccessSvc
+	}
+	return client.TeamProjectAccess
+}
+
 // Help returns help text for the project create command
 func (c *ProjectCreateCommand) Help() string {
 	helpText := `
@@ -122,18 +225,45 @@ Usage: hcptf project create [options]
 
   Create a new project.
 
+  With -access-template, teams are granted access to the new project as
+  the named template describes. Templates are access_template blocks in
+  the CLI config file (~/.hcptfrc), or in a file shared through a
+  repository and given with -access-template-file:
+
+    access_template "standard" {
+      team "platform" {
+        access = "admin"
+      }
+      team "developers" {
+        access           = "custom"
+        runs             = "apply"
+        variables        = "write"
+        create_workspace = true
+      }
+    }
+
+  See "hcptf project apply-access-template -help" for every permission.
+  Use the same template with that command to bring existing projects in
+  line.
+
 Options:
 
   -organization=<name>  Organization name (required)
   -org=<name>          Alias for -organization
   -name=<name>         Project name (required)
   -description=<text>  Project description
+  -access-template=<name>
+                       Access template to grant teams access with
+  -access-template-file=<path>
+                       File with access templates, checked before the
+                       CLI config file
   -output=<format>     Output format: table (default) or json
 
 Example:
 
   hcptf project create -org=my-org -name=infrastructure
   hcptf project create -org=my-org -name=platform -description="Platform services"
+  hcptf project create -org=my-org -name=payments -access-template=standard
 `
 	return strings.TrimSpace(helpText)
 }
@@ -1,12 +1,25 @@
 package command
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/mitchellh/cli"
 )
 
+type mockProjectCreator struct {
+	created []tfe.ProjectCreateOptions
+}
+
+func (m *mockProjectCreator) Create(_ context.Context, _ string, options tfe.ProjectCreateOptions) (*tfe.Project, error) {
+	m.created = append(m.created, options)
+	return &tfe.Project{ID: "prj-new", Name: options.Name}, nil
+}
+
 func TestProjectCreateRequiresOrganization(t *testing.T) {
 	ui := cli.NewMockUi()
 	cmd := &ProjectCreateCommand{
@@ -170,3 +183,144 @@ func TestProjectCreateFlagParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectCreateWithAccessTemplate(t *testing.T) {
+	ui := cli.NewMockUi()
+	projects := &mockProjectCreator{}
+	access := &mockProjectTeamAccessManager{}
+	meta := newTestMeta(ui)
+	meta.config = testAccessTemplates()
+	cmd := &ProjectCreateCommand{
+		Meta:                 meta,
+		projectSvc:           projects,
+		teamSvc:              testAccessTemplateTeams(),
+		projectTeamAccessSvc: access,
+	}
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-name=payments", "-access-template=standard"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(projects.created) != 1 {
+		t.Fatalf("expected one project to be created, got %d", len(projects.created))
+	}
+
+	var got []string
+	for _, options := range access.added {
+		got = append(got, options.Project.ID+":"+options.Team.ID+":"+string(options.Access))
+	}
+	if want := "prj-new:team-dev:custom,prj-new:team-platform:admin"; strings.Join(got, ",") != want {
+		t.Fatalf("grants = %v, want %s", got, want)
+	}
+	if !strings.Contains(output, "Granted team platform admin access") {
+		t.Fatalf("unexpected output: %q", output)
+	}
+}
+
+func TestProjectCreateWithAccessTemplateJSON(t *testing.T) {
+	ui := cli.NewMockUi()
+	meta := newTestMeta(ui)
+	meta.config = testAccessTemplates()
+	cmd := &ProjectCreateCommand{
+		Meta:                 meta,
+		projectSvc:           &mockProjectCreator{},
+		teamSvc:              testAccessTemplateTeams(),
+		projectTeamAccessSvc: &mockProjectTeamAccessManager{},
+	}
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-name=payments", "-access-template=standard", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		ID         string
+		TeamAccess map[string]string
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if result.ID != "prj-new" || result.TeamAccess["platform"] != "admin" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestProjectCreateAccessTemplateFailureHint(t *testing.T) {
+	path := writeTeamSyncFile(t, "access-templates.hcl", `
+access_template "shared" {
+  team "platform" {
+    access = "admin"
+  }
+}
+`)
+
+	ui := cli.NewMockUi()
+	cmd := &ProjectCreateCommand{
+		Meta:                 newTestMeta(ui),
+		projectSvc:           &mockProjectCreator{},
+		teamSvc:              testAccessTemplateTeams(),
+		projectTeamAccessSvc: &mockProjectTeamAccessManager{addErr: errors.New("forbidden")},
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-name=payments", "-access-template=shared", "-access-template-file=" + path}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	want := "hcptf project apply-access-template -id=prj-new -access-template=shared -access-template-file=" + path
+	if !strings.Contains(ui.ErrorWriter.String(), want) {
+		t.Fatalf("expected hint %q, got %q", want, ui.ErrorWriter.String())
+	}
+}
+
+func TestProjectCreateAccessTemplateDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	projects := &mockProjectCreator{}
+	meta := newTestMeta(ui)
+	meta.config = testAccessTemplates()
+	cmd := &ProjectCreateCommand{
+		Meta:       meta,
+		projectSvc: projects,
+		teamSvc:    testAccessTemplateTeams(),
+	}
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-name=payments", "-access-template=standard", "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(projects.created) != 0 {
+		t.Fatal("expected no project to be created during dry-run")
+	}
+
+	var result struct {
+		TeamAccess []accessGrant `json:"team_access"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if len(result.TeamAccess) != 2 || result.TeamAccess[0].Team != "developers" || result.TeamAccess[0].Custom["variables"] != "write" {
+		t.Fatalf("unexpected team access: %+v", result.TeamAccess)
+	}
+}
+
+func TestProjectCreateUnknownAccessTemplate(t *testing.T) {
+	ui := cli.NewMockUi()
+	projects := &mockProjectCreator{}
+	meta := newTestMeta(ui)
+	meta.config = testAccessTemplates()
+	cmd := &ProjectCreateCommand{Meta: meta, projectSvc: projects}
+
+	if code := cmd.Run([]string{"-org=my-org", "-name=payments", "-access-template=missing"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `access template "missing" not found`) {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+	if len(projects.created) != 0 {
+		t.Fatal("expected no project to be created")
+	}
+}
@@ -13,3 +13,7 @@ type projectLister interface {
 type projectReader interface {
 	Read(ctx context.Context, projectID string) (*tfe.Project, error)
 }
+
+type projectCreator interface {
+	Create(ctx context.Context, organization string, options tfe.ProjectCreateOptions) (*tfe.Project, error)
+}
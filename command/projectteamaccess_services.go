@@ -25,3 +25,10 @@ type projectTeamAccessUpdater interface {
 type projectTeamAccessDeleter interface {
 	Remove(ctx context.Context, teamProjectAccessID string) error
 }
+
+type projectTeamAccessManager interface {
+	projectTeamAccessLister
+	projectTeamAccessCreator
+	projectTeamAccessUpdater
+	projectTeamAccessDeleter
+}
@@ -38,3 +38,14 @@ type runForceExecutor interface {
 type runOrgLister interface {
 	ListForOrganization(ctx context.Context, organization string, options *tfe.RunListForOrganizationOptions) (*tfe.OrganizationRunList, error)
 }
+
+type runOrchestrator interface {
+	runCreator
+	runReader
+	runLister
+	runApplier
+}
+
+type runDetailReader interface {
+	ReadWithOptions(ctx context.Context, runID string, options *tfe.RunReadOptions) (*tfe.Run, error)
+}
@@ -0,0 +1,469 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const stateBackupManifestFile = "manifest.json"
+
+const (
+	stateBackupDownloaded = "downloaded"
+	stateBackupUnchanged  = "unchanged"
+	stateBackupFailed     = "failed"
+)
+
+// stateBackupManifest records what a backup directory holds. It is written
+// last, so a manifest always describes files that exist.
+type stateBackupManifest struct {
+	Organization string              `json:"organization"`
+	ProjectID    string              `json:"project_id,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Workspaces   []*stateBackupEntry `json:"workspaces"`
+}
+
+// stateBackupEntry is the backed up current state of one workspace. Path is
+// relative to the backup directory and Checksum is the SHA-256 of the file.
+type stateBackupEntry struct {
+	Workspace      string `json:"workspace"`
+	WorkspaceID    string `json:"workspace_id"`
+	StateVersionID string `json:"state_version_id"`
+	Serial         int64  `json:"serial"`
+	Lineage        string `json:"lineage"`
+	Checksum       string `json:"checksum"`
+	Path           string `json:"path"`
+}
+
+// stateBackupResult is the outcome for one workspace in this run.
+type stateBackupResult struct {
+	entry  *stateBackupEntry
+	ws     *tfe.Workspace
+	status string
+	err    error
+}
+
+// StateBackupCommand is a command to back up the current state of every workspace
+type StateBackupCommand struct {
+	Meta
+	organization string
+	projectID    string
+	dir          string
+	incremental  bool
+	tarball      bool
+	concurrency  int
+	format       string
+	now          func() time.Time
+	workspaceSvc workspaceLister
+	stateSvc     currentStateDownloader
+}
+
+// Run executes the state backup command
+func (c *StateBackupCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state backup")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "project-id", "", "Only back up workspaces in this project")
+	flags.StringVar(&c.dir, "dir", "", "Backup directory (required)")
+	flags.BoolVar(&c.incremental, "incremental", false, "Skip workspaces whose state is unchanged since the last backup")
+	flags.BoolVar(&c.tarball, "tar", false, "Also write the backup directory as a single <dir>.tar.gz archive")
+	flags.IntVar(&c.concurrency, "concurrency", defaultStateSearchConcurrency, "Number of workspaces to download at once")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.dir == "" {
+		c.Ui.Error("Error: -dir flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.concurrency < 1 {
+		c.Ui.Error("Error: -concurrency must be at least 1")
+		return 1
+	}
+
+	var previous map[string]*stateBackupEntry
+	if c.incremental {
+		manifest, err := readStateBackupManifest(c.dir)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading previous manifest: %s", err))
+			return 1
+		}
+		if manifest != nil {
+			if manifest.Organization != c.organization {
+				c.Ui.Error(fmt.Sprintf("Error: %s holds a backup of organization %q, not %q", c.dir, manifest.Organization, c.organization))
+				return 1
+			}
+			previous = make(map[string]*stateBackupEntry, len(manifest.Workspaces))
+			for _, entry := range manifest.Workspaces {
+				previous[entry.WorkspaceID] = entry
+			}
+		}
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating directory: %s", err))
+		return 1
+	}
+
+	results := c.backup(ctx, c.stateService(client), workspaces, previous)
+
+	manifest := &stateBackupManifest{
+		Organization: c.organization,
+		ProjectID:    c.projectID,
+		CreatedAt:    c.timeNow().UTC(),
+		Workspaces:   []*stateBackupEntry{},
+	}
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			c.Ui.Error(fmt.Sprintf("Error backing up workspace %s: %s", r.ws.Name, r.err))
+			// Keep the last good backup of the workspace in the manifest
+			// so a transient failure does not orphan its state file.
+			if entry, ok := previous[r.ws.ID]; ok && c.verify(entry) {
+				manifest.Workspaces = append(manifest.Workspaces, entry)
+			}
+			continue
+		}
+		manifest.Workspaces = append(manifest.Workspaces, r.entry)
+	}
+
+	if err := writeStateBackupManifest(c.dir, manifest); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing manifest: %s", err))
+		return 1
+	}
+
+	archive := ""
+	if c.tarball {
+		dir, err := filepath.Abs(c.dir)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing archive: %s", err))
+			return 1
+		}
+		archive = dir + ".tar.gz"
+		if err := writeStateBackupArchive(dir, archive); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing archive: %s", err))
+			return 1
+		}
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"directory": c.dir,
+			"archive":   archive,
+			"failed":    failed,
+			"manifest":  manifest,
+		})
+	} else {
+		headers := []string{"Workspace", "State Version", "Serial", "Status"}
+		var rows [][]string
+		downloaded, unchanged := 0, 0
+		for _, r := range results {
+			switch r.status {
+			case stateBackupDownloaded:
+				downloaded++
+			case stateBackupUnchanged:
+				unchanged++
+			}
+			if r.entry == nil {
+				rows = append(rows, []string{r.ws.Name, "", "", r.status})
+				continue
+			}
+			rows = append(rows, []string{r.entry.Workspace, r.entry.StateVersionID, fmt.Sprintf("%d", r.entry.Serial), r.status})
+		}
+		if len(rows) > 0 {
+			c.Meta.NewFormatter(c.format).Table(headers, rows)
+			c.Ui.Output("")
+		}
+		c.Ui.Output(fmt.Sprintf("Backed up %d workspaces to %s (%d downloaded, %d unchanged, %d failed)",
+			len(manifest.Workspaces), c.dir, downloaded, unchanged, failed))
+		if archive != "" {
+			c.Ui.Output(fmt.Sprintf("Archive written to %s", archive))
+		}
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// backup downloads the current state of each workspace, at most
+// c.concurrency at a time. Workspaces without state are left out; with a
+// previous manifest, workspaces whose current state version and backed up
+// file are unchanged are carried forward without downloading.
+func (c *StateBackupCommand) backup(ctx context.Context, svc currentStateDownloader, workspaces []*tfe.Workspace, previous map[string]*stateBackupEntry) []*stateBackupResult {
+	var (
+		mu      sync.Mutex
+		results []*stateBackupResult
+	)
+
+	var withState []*tfe.Workspace
+	for _, ws := range workspaces {
+		if ws.CurrentStateVersion != nil {
+			withState = append(withState, ws)
+		}
+	}
+
+	forEachWorkspace(withState, c.concurrency, func(ws *tfe.Workspace) {
+		result := &stateBackupResult{ws: ws}
+		if entry, ok := previous[ws.ID]; ok && entry.StateVersionID == ws.CurrentStateVersion.ID && c.verify(entry) {
+			entry.Workspace = ws.Name
+			result.entry = entry
+			result.status = stateBackupUnchanged
+		} else {
+			result.entry, result.err = c.download(ctx, svc, ws)
+			result.status = stateBackupDownloaded
+			if result.err != nil {
+				result.status = stateBackupFailed
+			}
+		}
+		if result.entry == nil && result.err == nil {
+			return
+		}
+
+		mu.Lock()
+		results = append(results, result)
+		mu.Unlock()
+	})
+
+	sort.Slice(results, func(i, j int) bool { return results[i].ws.Name < results[j].ws.Name })
+	return results
+}
+
+// download writes the workspace's current state to
+// <dir>/<workspace>/<serial>-<state version ID>.json. It returns nil if the
+// workspace has no current state.
+func (c *StateBackupCommand) download(ctx context.Context, svc currentStateDownloader, ws *tfe.Workspace) (*stateBackupEntry, error) {
+	sv, err := svc.ReadCurrent(ctx, ws.ID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading current state version: %w", err)
+	}
+
+	data, state, err := downloadStateFile(ctx, svc, sv)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := filepath.Join(filepath.Base(ws.Name), fmt.Sprintf("%d-%s.json", sv.Serial, sv.ID))
+	path := filepath.Join(c.dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+
+	return &stateBackupEntry{
+		Workspace:      ws.Name,
+		WorkspaceID:    ws.ID,
+		StateVersionID: sv.ID,
+		Serial:         state.Serial,
+		Lineage:        state.Lineage,
+		Checksum:       stateBackupChecksum(data),
+		Path:           filepath.ToSlash(rel),
+	}, nil
+}
+
+// verify reports whether a previously backed up file is still intact.
+func (c *StateBackupCommand) verify(entry *stateBackupEntry) bool {
+	data, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(entry.Path)))
+	if err != nil {
+		return false
+	}
+	return stateBackupChecksum(data) == entry.Checksum
+}
+
+func (c *StateBackupCommand) timeNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func stateBackupChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// readStateBackupManifest returns the manifest in dir, or nil if there is
+// none yet.
+func readStateBackupManifest(dir string) (*stateBackupManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateBackupManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest stateBackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func writeStateBackupManifest(dir string, manifest *stateBackupManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, stateBackupManifestFile), append(data, '\n'), 0600)
+}
+
+// writeStateBackupArchive writes the regular files under dir to a gzipped
+// tar archive, with paths rooted at the directory's base name.
+func writeStateBackupArchive(dir, archive string) error {
+	f, err := os.OpenFile(archive, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	root := filepath.Base(filepath.Clean(dir))
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(root, rel))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (c *StateBackupCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *StateBackupCommand) stateService(client *client.Client) currentStateDownloader {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+// Help returns help text for the state backup command
+func (c *StateBackupCommand) Help() string {
+	helpText := `
+Usage: hcptf state backup [options]
+
+  Download the current state of every workspace in an organization or
+  project into a local directory, for disaster recovery and compliance.
+
+  Each workspace's state is written to <dir>/<workspace>/<serial>-<id>.json
+  and dir/manifest.json records, for every workspace, the workspace ID,
+  state version ID, serial, lineage, SHA-256 checksum, and file path. Files
+  are readable only by the current user, since state may hold secrets.
+
+  With -incremental, the previous manifest is compared against each
+  workspace's current state version, and workspaces whose state version is
+  unchanged and whose backed up file still matches its checksum are not
+  downloaded again. Earlier state files are kept, and a workspace that
+  fails to back up keeps its previous manifest entry.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -dir=<path>          Backup directory (required)
+  -project-id=<id>     Only back up workspaces in this project
+  -incremental         Skip workspaces whose state is unchanged since the
+                       last backup in -dir
+  -tar                 Also write the directory as a single <dir>.tar.gz
+  -concurrency=<n>     Number of workspaces to download at once (default: 8)
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf state backup -org=my-org -dir=./state-backup
+  hcptf state backup -org=my-org -dir=./state-backup -incremental -tar
+  hcptf state backup -org=my-org -project-id=prj-abc123 -dir=./network-backup
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state backup command
+func (c *StateBackupCommand) Synopsis() string {
+	return "Back up the current state of every workspace"
+}
@@ -0,0 +1,238 @@
+package command
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newStateBackupCommand(ui cli.Ui, workspaces workspaceLister, states currentStateDownloader) *StateBackupCommand {
+	return &StateBackupCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: workspaces,
+		stateSvc:     states,
+		now:          func() time.Time { return time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestStateBackupWritesStateAndManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backup")
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(2)
+
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	manifest, err := readStateBackupManifest(dir)
+	if err != nil || manifest == nil {
+		t.Fatalf("expected manifest, got %v", err)
+	}
+	if manifest.Organization != "my-org" || len(manifest.Workspaces) != 2 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+
+	entry := manifest.Workspaces[0]
+	if entry.Workspace != "app-01" || entry.WorkspaceID != "ws-1" || entry.StateVersionID != "sv-1" ||
+		entry.Serial != 1 || entry.Lineage != "l" || entry.Path != "app-01/0-sv-1.json" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app-01", "0-sv-1.json"))
+	if err != nil {
+		t.Fatalf("expected state file: %v", err)
+	}
+	if stateBackupChecksum(data) != entry.Checksum {
+		t.Fatalf("checksum does not match file")
+	}
+	info, err := os.Stat(filepath.Join(dir, "app-01", "0-sv-1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "2 downloaded, 0 unchanged, 0 failed") {
+		t.Fatalf("unexpected summary %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStateBackupIncrementalSkipsUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	workspaces, states := newStateSearchFixture(3)
+
+	ui := cli.NewMockUi()
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	// app-02 gets a new state version and app-03's backup is corrupted.
+	workspaces.pages[0][1].CurrentStateVersion = &tfe.StateVersion{ID: "sv-2b"}
+	states.current["ws-2"] = &tfe.StateVersion{ID: "sv-2b", Serial: 2, DownloadURL: "https://archivist.example/sv-2b"}
+	states.contents["https://archivist.example/sv-2b"] = []byte(`{"version":4,"serial":2,"lineage":"l","resources":[]}`)
+	if err := os.WriteFile(filepath.Join(dir, "app-03", "0-sv-3.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	states.reads, states.downloads = 0, 0
+	ui = cli.NewMockUi()
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir, "-incremental"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if states.reads != 2 || states.downloads != 2 {
+		t.Fatalf("expected only app-02 and app-03 to be downloaded, got %d reads and %d downloads", states.reads, states.downloads)
+	}
+
+	manifest, err := readStateBackupManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Workspaces) != 3 || manifest.Workspaces[1].StateVersionID != "sv-2b" || manifest.Workspaces[1].Serial != 2 {
+		t.Fatalf("unexpected manifest %+v", manifest.Workspaces)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app-02", "0-sv-2.json")); err != nil {
+		t.Fatalf("expected earlier state file to be kept: %v", err)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "2 downloaded, 1 unchanged") {
+		t.Fatalf("unexpected summary %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStateBackupTar(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backup")
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(2)
+
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir + "/", "-tar"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	f, err := os.Open(dir + ".tar.gz")
+	if err != nil {
+		t.Fatalf("expected archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	want := []string{"backup/app-01/0-sv-1.json", "backup/app-02/0-sv-2.json", "backup/manifest.json"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected archive contents %v", names)
+	}
+}
+
+func TestStateBackupTarCurrentDirectory(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "backup")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(1)
+
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=.", "-tar"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if _, err := os.Stat(filepath.Join(parent, "backup.tar.gz")); err != nil {
+		t.Fatalf("expected archive named after the directory: %v", err)
+	}
+}
+
+func TestStateBackupReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(2)
+	delete(states.contents, "https://archivist.example/sv-2")
+
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir, "-output=json"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Error backing up workspace app-02") {
+		t.Fatalf("expected failure, got %q", ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		Failed   int                 `json:"failed"`
+		Manifest stateBackupManifest `json:"manifest"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if result.Failed != 1 || len(result.Manifest.Workspaces) != 1 || result.Manifest.Workspaces[0].Workspace != "app-01" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestStateBackupIncrementalKeepsFailedWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	workspaces, states := newStateSearchFixture(2)
+
+	ui := cli.NewMockUi()
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	// app-02 gets a new state version that cannot be downloaded.
+	workspaces.pages[0][1].CurrentStateVersion = &tfe.StateVersion{ID: "sv-2b"}
+	states.current["ws-2"] = &tfe.StateVersion{ID: "sv-2b", Serial: 2, DownloadURL: "https://archivist.example/sv-2b"}
+
+	ui = cli.NewMockUi()
+	if code := newStateBackupCommand(ui, workspaces, states).Run([]string{"-org=my-org", "-dir=" + dir, "-incremental"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+
+	manifest, err := readStateBackupManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Workspaces) != 2 || manifest.Workspaces[1].Workspace != "app-02" || manifest.Workspaces[1].StateVersionID != "sv-2" {
+		t.Fatalf("expected app-02's previous entry to be kept, got %+v", manifest.Workspaces)
+	}
+}
+
+func TestStateBackupIncrementalRejectsOtherOrganization(t *testing.T) {
+	dir := t.TempDir()
+	if err := writeStateBackupManifest(dir, &stateBackupManifest{Organization: "other-org"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	if code := newStateBackupCommand(ui, nil, nil).Run([]string{"-org=my-org", "-dir=" + dir, "-incremental"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "other-org") {
+		t.Fatalf("unexpected error %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestStateBackupRequiresDir(t *testing.T) {
+	ui := cli.NewMockUi()
+	if code := newStateBackupCommand(ui, nil, nil).Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-dir") {
+		t.Fatalf("expected -dir error, got %q", ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,511 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	stateChangeAdded   = "added"
+	stateChangeRemoved = "removed"
+	stateChangeMoved   = "moved"
+	stateChangeChanged = "changed"
+
+	stateSensitiveValue = "(sensitive)"
+)
+
+// stateDiff is the difference between two state files.
+type stateDiff struct {
+	From      stateDiffSide          `json:"from"`
+	To        stateDiffSide          `json:"to"`
+	Resources []*stateResourceChange `json:"resources"`
+	Outputs   []*stateOutputChange   `json:"outputs"`
+}
+
+type stateDiffSide struct {
+	ID      string `json:"id"`
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// stateResourceChange describes one resource instance that was added,
+// removed, moved to a new address, or changed in place.
+type stateResourceChange struct {
+	Address         string                  `json:"address"`
+	Action          string                  `json:"action"`
+	PreviousAddress string                  `json:"previous_address,omitempty"`
+	Attributes      []*stateAttributeChange `json:"attributes,omitempty"`
+}
+
+// stateAttributeChange is a changed leaf attribute. Before and After hold the
+// JSON-rendered values and are empty when the attribute is absent on that
+// side.
+type stateAttributeChange struct {
+	Path      string `json:"path"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+type stateOutputChange struct {
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+func (d *stateDiff) empty() bool {
+	return len(d.Resources) == 0 && len(d.Outputs) == 0
+}
+
+// diffStates compares two parsed state files. An instance that disappears
+// from one address and appears at another with the same type and id is
+// reported as moved rather than as a removal and an addition.
+func diffStates(from, to *terraformState) *stateDiff {
+	diff := &stateDiff{
+		Resources: []*stateResourceChange{},
+		Outputs:   []*stateOutputChange{},
+	}
+
+	before := from.instances()
+	after := to.instances()
+
+	var removed, added []*stateInstanceEntry
+	for addr, entry := range before {
+		if _, ok := after[addr]; !ok {
+			removed = append(removed, entry)
+		}
+	}
+	for addr, entry := range after {
+		if _, ok := before[addr]; !ok {
+			added = append(added, entry)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Address < removed[j].Address })
+	sort.Slice(added, func(i, j int) bool { return added[i].Address < added[j].Address })
+
+	moved := map[string]bool{}
+	for _, old := range removed {
+		id := stateInstanceID(old)
+		if id == "" {
+			continue
+		}
+		for _, candidate := range added {
+			if moved[candidate.Address] || candidate.Resource.Type != old.Resource.Type || stateInstanceID(candidate) != id {
+				continue
+			}
+			moved[candidate.Address] = true
+			moved[old.Address] = true
+			diff.Resources = append(diff.Resources, &stateResourceChange{
+				Address:         candidate.Address,
+				Action:          stateChangeMoved,
+				PreviousAddress: old.Address,
+				Attributes:      diffStateInstances(old.Instance, candidate.Instance),
+			})
+			break
+		}
+	}
+
+	for _, entry := range added {
+		if !moved[entry.Address] {
+			diff.Resources = append(diff.Resources, &stateResourceChange{Address: entry.Address, Action: stateChangeAdded})
+		}
+	}
+	for _, entry := range removed {
+		if !moved[entry.Address] {
+			diff.Resources = append(diff.Resources, &stateResourceChange{Address: entry.Address, Action: stateChangeRemoved})
+		}
+	}
+	for addr, entry := range after {
+		old, ok := before[addr]
+		if !ok {
+			continue
+		}
+		if changes := diffStateInstances(old.Instance, entry.Instance); len(changes) > 0 {
+			diff.Resources = append(diff.Resources, &stateResourceChange{
+				Address:    addr,
+				Action:     stateChangeChanged,
+				Attributes: changes,
+			})
+		}
+	}
+	sort.SliceStable(diff.Resources, func(i, j int) bool {
+		return diff.Resources[i].Address < diff.Resources[j].Address
+	})
+
+	diff.Outputs = diffStateOutputs(from.Outputs, to.Outputs)
+	return diff
+}
+
+// stateInstanceID returns the instance's "id" attribute, if any.
+func stateInstanceID(entry *stateInstanceEntry) string {
+	if entry.Instance.Attributes == nil {
+		return ""
+	}
+	id, _ := entry.Instance.Attributes["id"].(string)
+	return id
+}
+
+// diffStateInstances returns the leaf attributes that differ between two
+// instances, masking any path either side marks as sensitive.
+func diffStateInstances(before, after *stateInstance) []*stateAttributeChange {
+	oldValues := map[string]interface{}{}
+	newValues := map[string]interface{}{}
+	flattenStateValue("", map[string]interface{}(before.Attributes), oldValues)
+	flattenStateValue("", map[string]interface{}(after.Attributes), newValues)
+	sensitive := append(before.sensitivePaths(), after.sensitivePaths()...)
+
+	paths := sortedStateKeys(oldValues)
+	for _, path := range sortedStateKeys(newValues) {
+		if _, ok := oldValues[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []*stateAttributeChange
+	for _, path := range paths {
+		oldValue, hadOld := oldValues[path]
+		newValue, hasNew := newValues[path]
+
+		oldText, newText := "", ""
+		if hadOld {
+			oldText = renderStateValue(oldValue)
+		}
+		if hasNew {
+			newText = renderStateValue(newValue)
+		}
+		if hadOld == hasNew && oldText == newText {
+			continue
+		}
+
+		change := &stateAttributeChange{Path: path, Before: oldText, After: newText}
+		if isSensitivePath(path, sensitive) {
+			change.Sensitive = true
+			if hadOld {
+				change.Before = stateSensitiveValue
+			}
+			if hasNew {
+				change.After = stateSensitiveValue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// diffStateOutputs compares root module outputs. Values of outputs marked
+// sensitive on either side are never shown.
+func diffStateOutputs(before, after map[string]*stateOutput) []*stateOutputChange {
+	names := map[string]bool{}
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	changes := []*stateOutputChange{}
+	for _, name := range sorted {
+		oldOutput, hadOld := before[name]
+		newOutput, hasNew := after[name]
+
+		change := &stateOutputChange{Name: name}
+		sensitive := (hadOld && oldOutput.Sensitive) || (hasNew && newOutput.Sensitive)
+		if hadOld {
+			change.Before = renderStateValue(oldOutput.Value)
+		}
+		if hasNew {
+			change.After = renderStateValue(newOutput.Value)
+		}
+
+		switch {
+		case !hadOld:
+			change.Action = stateChangeAdded
+		case !hasNew:
+			change.Action = stateChangeRemoved
+		case change.Before != change.After || oldOutput.Sensitive != newOutput.Sensitive:
+			change.Action = stateChangeChanged
+		default:
+			continue
+		}
+
+		if sensitive {
+			change.Sensitive = true
+			if hadOld {
+				change.Before = stateSensitiveValue
+			}
+			if hasNew {
+				change.After = stateSensitiveValue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// StateDiffCommand is a command to compare two state versions
+type StateDiffCommand struct {
+	Meta
+	organization string
+	workspace    string
+	from         string
+	to           string
+	format       string
+	stateSvc     stateVersionHistoryService
+	workspaceSvc workspaceReader
+}
+
+// Run executes the state diff command
+func (c *StateDiffCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state diff")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name")
+	flags.StringVar(&c.from, "from", "", "Older state version ID (default: version before -to)")
+	flags.StringVar(&c.to, "to", "", "Newer state version ID (default: current)")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or markdown")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Both versions given by ID need no workspace context
+	if (c.from == "" || c.to == "") && (c.organization == "" || c.workspace == "") {
+		c.Ui.Error("Error: must provide both -from and -to, or -org and -workspace")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	switch c.format {
+	case "table", "json", "markdown":
+	default:
+		c.Ui.Error("Error: -output must be one of: table, json, markdown")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+	svc := c.stateService(client)
+
+	var ws *tfe.Workspace
+	if c.to == "" {
+		ws, err = c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+			return 1
+		}
+	}
+
+	toVersion, err := resolveStateVersion(ctx, svc, ws, c.organization, stateVersionSelector{ID: c.to})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state version: %s", err))
+		return 1
+	}
+
+	var fromVersion *tfe.StateVersion
+	if c.from != "" {
+		fromVersion, err = svc.Read(ctx, c.from)
+	} else {
+		fromVersion, err = previousStateVersion(ctx, svc, c.organization, c.workspace, toVersion.ID)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state version: %s", err))
+		return 1
+	}
+
+	_, fromState, err := downloadStateFile(ctx, svc, fromVersion)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error downloading state file: %s", err))
+		return 1
+	}
+	_, toState, err := downloadStateFile(ctx, svc, toVersion)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error downloading state file: %s", err))
+		return 1
+	}
+
+	if fromState.Lineage != toState.Lineage && c.format != "json" {
+		c.Ui.Warn(fmt.Sprintf("Warning: state versions have different lineages (%s, %s)", fromState.Lineage, toState.Lineage))
+	}
+
+	diff := diffStates(fromState, toState)
+	diff.From = stateDiffSide{ID: fromVersion.ID, Serial: fromState.Serial, Lineage: fromState.Lineage}
+	diff.To = stateDiffSide{ID: toVersion.ID, Serial: toState.Serial, Lineage: toState.Lineage}
+
+	switch c.format {
+	case "json":
+		c.Meta.NewFormatter("json").JSON(diff)
+	case "markdown":
+		c.Ui.Output(renderStateDiffMarkdown(diff))
+	default:
+		renderStateDiffTable(&c.Meta, diff)
+	}
+	return 0
+}
+
+// renderStateDiffTable writes the resource, attribute, and output changes as
+// tables.
+func renderStateDiffTable(m *Meta, diff *stateDiff) {
+	m.Ui.Output(fmt.Sprintf("Comparing %s (serial %d) to %s (serial %d)", diff.From.ID, diff.From.Serial, diff.To.ID, diff.To.Serial))
+	if diff.empty() {
+		m.Ui.Output("No differences found")
+		return
+	}
+
+	formatter := m.NewFormatter("table")
+	if len(diff.Resources) > 0 {
+		m.Ui.Output("\nResources:")
+		var rows [][]string
+		for _, change := range diff.Resources {
+			rows = append(rows, []string{change.Action, change.Address, stateResourceChangeDetail(change)})
+		}
+		formatter.Table([]string{"Action", "Address", "Details"}, rows)
+
+		var attrRows [][]string
+		for _, change := range diff.Resources {
+			for _, attr := range change.Attributes {
+				attrRows = append(attrRows, []string{change.Address, attr.Path, stateDiffCell(attr.Before), stateDiffCell(attr.After)})
+			}
+		}
+		if len(attrRows) > 0 {
+			m.Ui.Output("\nAttribute changes:")
+			formatter.Table([]string{"Address", "Attribute", "Before", "After"}, attrRows)
+		}
+	}
+
+	if len(diff.Outputs) > 0 {
+		m.Ui.Output("\nOutputs:")
+		var rows [][]string
+		for _, change := range diff.Outputs {
+			rows = append(rows, []string{change.Name, change.Action, stateDiffCell(change.Before), stateDiffCell(change.After)})
+		}
+		formatter.Table([]string{"Output", "Action", "Before", "After"}, rows)
+	}
+}
+
+func renderStateDiffMarkdown(diff *stateDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## State diff: `%s` (serial %d) → `%s` (serial %d)\n", diff.From.ID, diff.From.Serial, diff.To.ID, diff.To.Serial)
+	if diff.empty() {
+		b.WriteString("\nNo differences found.\n")
+		return b.String()
+	}
+
+	if len(diff.Resources) > 0 {
+		b.WriteString("\n### Resources\n\n| Action | Address | Details |\n| --- | --- | --- |\n")
+		for _, change := range diff.Resources {
+			fmt.Fprintf(&b, "| %s | `%s` | %s |\n", change.Action, change.Address, markdownCell(stateResourceChangeDetail(change)))
+		}
+
+		var attrs strings.Builder
+		for _, change := range diff.Resources {
+			for _, attr := range change.Attributes {
+				fmt.Fprintf(&attrs, "| `%s` | `%s` | %s | %s |\n", change.Address, attr.Path,
+					markdownCell(stateDiffCell(attr.Before)), markdownCell(stateDiffCell(attr.After)))
+			}
+		}
+		if attrs.Len() > 0 {
+			b.WriteString("\n### Attribute changes\n\n| Address | Attribute | Before | After |\n| --- | --- | --- | --- |\n")
+			b.WriteString(attrs.String())
+		}
+	}
+
+	if len(diff.Outputs) > 0 {
+		b.WriteString("\n### Outputs\n\n| Output | Action | Before | After |\n| --- | --- | --- | --- |\n")
+		for _, change := range diff.Outputs {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", change.Name, change.Action,
+				markdownCell(stateDiffCell(change.Before)), markdownCell(stateDiffCell(change.After)))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func stateResourceChangeDetail(change *stateResourceChange) string {
+	switch {
+	case change.Action == stateChangeMoved && len(change.Attributes) > 0:
+		return fmt.Sprintf("from %s, %d attribute(s) changed", change.PreviousAddress, len(change.Attributes))
+	case change.Action == stateChangeMoved:
+		return "from " + change.PreviousAddress
+	case len(change.Attributes) > 0:
+		return fmt.Sprintf("%d attribute(s) changed", len(change.Attributes))
+	}
+	return ""
+}
+
+// stateDiffCell shows an absent value as "-".
+func stateDiffCell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func (c *StateDiffCommand) stateService(client *client.Client) stateVersionHistoryService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateDiffCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the state diff command
+func (c *StateDiffCommand) Help() string {
+	helpText := `
+Usage: hcptf state diff [options]
+
+  Compare two state versions of a workspace. Reports resource instances that
+  were added, removed, or moved to a new address, the attributes that changed
+  on instances present in both, and changes to output values. Attributes and
+  outputs marked sensitive in either state are masked.
+
+  By default the current state version is compared with the one before it.
+
+Options:
+
+  -organization=<name>  Organization name
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required unless -from and -to are set)
+  -from=<id>           Older state version ID (default: the version before -to)
+  -to=<id>             Newer state version ID (default: current)
+  -output=<format>     Output format: table (default), json, or markdown
+
+Example:
+
+  hcptf state diff -org=my-org -workspace=prod
+  hcptf state diff -org=my-org -workspace=prod -from=sv-ABC123
+  hcptf state diff -from=sv-ABC123 -to=sv-DEF456 -output=markdown
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state diff command
+func (c *StateDiffCommand) Synopsis() string {
+	return "Compare resources and outputs between two state versions"
+}
@@ -0,0 +1,292 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+const stateDiffBefore = `{
+  "version": 4,
+  "serial": 1,
+  "lineage": "lineage-a",
+  "outputs": {
+    "vpc_id": {"value": "vpc-1", "type": "string"},
+    "db_password": {"value": "hunter2", "type": "string", "sensitive": true},
+    "legacy": {"value": "x", "type": "string"}
+  },
+  "resources": [
+    {
+      "mode": "managed", "type": "aws_vpc", "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 1, "attributes": {"id": "vpc-1", "cidr_block": "10.0.0.0/16", "tags": {"Name": "main"}}}]
+    },
+    {
+      "mode": "managed", "type": "aws_db_instance", "name": "db",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "db-1", "password": "hunter2", "instance_class": "db.t3.micro"},
+        "sensitive_attributes": [[{"type": "get_attr", "value": "password"}]]}]
+    },
+    {
+      "mode": "managed", "type": "aws_subnet", "name": "old",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"index_key": 0, "schema_version": 1, "attributes": {"id": "subnet-1"}}]
+    },
+    {
+      "mode": "data", "type": "aws_ami", "name": "ubuntu",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "ami-1"}}]
+    }
+  ]
+}`
+
+const stateDiffAfter = `{
+  "version": 4,
+  "serial": 2,
+  "lineage": "lineage-a",
+  "outputs": {
+    "vpc_id": {"value": "vpc-1", "type": "string"},
+    "db_password": {"value": "correct-horse", "type": "string", "sensitive": true},
+    "subnet_ids": {"value": ["subnet-1"], "type": ["list", "string"]}
+  },
+  "resources": [
+    {
+      "mode": "managed", "type": "aws_vpc", "name": "main",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 1, "attributes": {"id": "vpc-1", "cidr_block": "10.1.0.0/16", "tags": {"Name": "main", "env": "prod"}}}]
+    },
+    {
+      "mode": "managed", "type": "aws_db_instance", "name": "db",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "db-1", "password": "correct-horse", "instance_class": "db.t3.micro"},
+        "sensitive_attributes": [[{"type": "get_attr", "value": "password"}]]}]
+    },
+    {
+      "module": "module.network", "mode": "managed", "type": "aws_subnet", "name": "this",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"index_key": "a", "schema_version": 1, "attributes": {"id": "subnet-1"}}]
+    },
+    {
+      "mode": "managed", "type": "aws_s3_bucket", "name": "logs",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "logs"}}]
+    }
+  ]
+}`
+
+func parseTestState(t *testing.T, data string) *terraformState {
+	t.Helper()
+	state, err := parseStateFile([]byte(data))
+	if err != nil {
+		t.Fatalf("parsing state: %v", err)
+	}
+	return state
+}
+
+func findResourceChange(diff *stateDiff, address string) *stateResourceChange {
+	for _, change := range diff.Resources {
+		if change.Address == address {
+			return change
+		}
+	}
+	return nil
+}
+
+func TestDiffStatesResources(t *testing.T) {
+	diff := diffStates(parseTestState(t, stateDiffBefore), parseTestState(t, stateDiffAfter))
+
+	if len(diff.Resources) != 5 {
+		t.Fatalf("expected 5 resource changes, got %d: %+v", len(diff.Resources), diff.Resources)
+	}
+
+	if c := findResourceChange(diff, "aws_s3_bucket.logs"); c == nil || c.Action != stateChangeAdded {
+		t.Fatalf("expected aws_s3_bucket.logs added, got %+v", c)
+	}
+	if c := findResourceChange(diff, "data.aws_ami.ubuntu"); c == nil || c.Action != stateChangeRemoved {
+		t.Fatalf("expected data.aws_ami.ubuntu removed, got %+v", c)
+	}
+
+	moved := findResourceChange(diff, `module.network.aws_subnet.this["a"]`)
+	if moved == nil || moved.Action != stateChangeMoved || moved.PreviousAddress != "aws_subnet.old[0]" {
+		t.Fatalf("expected subnet move, got %+v", moved)
+	}
+	if findResourceChange(diff, "aws_subnet.old[0]") != nil {
+		t.Fatal("moved instance should not also be reported as removed")
+	}
+
+	vpc := findResourceChange(diff, "aws_vpc.main")
+	if vpc == nil || vpc.Action != stateChangeChanged {
+		t.Fatalf("expected aws_vpc.main changed, got %+v", vpc)
+	}
+	if len(vpc.Attributes) != 2 {
+		t.Fatalf("expected 2 attribute changes, got %+v", vpc.Attributes)
+	}
+	if a := vpc.Attributes[0]; a.Path != "cidr_block" || a.Before != `"10.0.0.0/16"` || a.After != `"10.1.0.0/16"` {
+		t.Fatalf("unexpected cidr change %+v", a)
+	}
+	if a := vpc.Attributes[1]; a.Path != "tags.env" || a.Before != "" || a.After != `"prod"` {
+		t.Fatalf("unexpected tag change %+v", a)
+	}
+}
+
+func TestDiffStatesMasksSensitiveValues(t *testing.T) {
+	diff := diffStates(parseTestState(t, stateDiffBefore), parseTestState(t, stateDiffAfter))
+
+	db := findResourceChange(diff, "aws_db_instance.db")
+	if db == nil || len(db.Attributes) != 1 {
+		t.Fatalf("expected one db attribute change, got %+v", db)
+	}
+	if a := db.Attributes[0]; !a.Sensitive || a.Before != stateSensitiveValue || a.After != stateSensitiveValue {
+		t.Fatalf("expected masked password, got %+v", a)
+	}
+
+	data, _ := json.Marshal(diff)
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "correct-horse") {
+		t.Fatalf("sensitive value leaked: %s", data)
+	}
+}
+
+func TestDiffStatesOutputs(t *testing.T) {
+	diff := diffStates(parseTestState(t, stateDiffBefore), parseTestState(t, stateDiffAfter))
+
+	actions := map[string]string{}
+	for _, change := range diff.Outputs {
+		actions[change.Name] = change.Action
+	}
+	want := map[string]string{
+		"db_password": stateChangeChanged,
+		"legacy":      stateChangeRemoved,
+		"subnet_ids":  stateChangeAdded,
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("expected %v, got %v", want, actions)
+	}
+	for name, action := range want {
+		if actions[name] != action {
+			t.Errorf("output %s: expected %s, got %s", name, action, actions[name])
+		}
+	}
+}
+
+func TestDiffStatesIdentical(t *testing.T) {
+	state := parseTestState(t, stateDiffBefore)
+	if diff := diffStates(state, state); !diff.empty() {
+		t.Fatalf("expected no differences, got %+v", diff)
+	}
+}
+
+func TestSensitivePathsIndexSteps(t *testing.T) {
+	inst := &stateInstance{SensitiveAttributes: json.RawMessage(
+		`[[{"type":"get_attr","value":"rule"},{"type":"index","value":{"value":0,"type":"number"}},{"type":"get_attr","value":"secret"}],
+		  [{"type":"get_attr","value":"tags"},{"type":"index","value":{"value":"token","type":"string"}}]]`)}
+
+	paths := inst.sensitivePaths()
+	if len(paths) != 2 || paths[0] != "rule[0].secret" || paths[1] != "tags.token" {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if !isSensitivePath("rule[0].secret", paths) || !isSensitivePath("tags.token", paths) || isSensitivePath("tags.tokens", paths) {
+		t.Fatal("unexpected sensitive path matching")
+	}
+}
+
+func newStateDiffFixture() *mockStateVersionHistoryService {
+	return &mockStateVersionHistoryService{
+		versions: []*tfe.StateVersion{
+			{ID: "sv-2", Serial: 2, DownloadURL: "https://archivist.example/sv-2"},
+			{ID: "sv-1", Serial: 1, DownloadURL: "https://archivist.example/sv-1"},
+		},
+		contents: map[string][]byte{
+			"https://archivist.example/sv-2": []byte(stateDiffAfter),
+			"https://archivist.example/sv-1": []byte(stateDiffBefore),
+		},
+		pageSize: 1,
+	}
+}
+
+func TestStateDiffDefaultsToPreviousAndCurrent(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateDiffCommand{
+		Meta:         newTestMeta(ui),
+		stateSvc:     newStateDiffFixture(),
+		workspaceSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "prod"}},
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var diff stateDiff
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &diff); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if diff.From.ID != "sv-1" || diff.To.ID != "sv-2" {
+		t.Fatalf("expected sv-1 -> sv-2, got %s -> %s", diff.From.ID, diff.To.ID)
+	}
+	if len(diff.Resources) != 5 || len(diff.Outputs) != 3 {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+}
+
+func TestStateDiffMarkdown(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateDiffCommand{Meta: newTestMeta(ui), stateSvc: newStateDiffFixture()}
+
+	if code := cmd.Run([]string{"-from=sv-1", "-to=sv-2", "-output=markdown"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	for _, want := range []string{"## State diff", "### Resources", "| moved | `module.network.aws_subnet.this[\"a\"]` | from aws_subnet.old[0] |", "### Outputs", stateSensitiveValue} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected markdown to contain %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Fatal("sensitive value leaked into markdown")
+	}
+}
+
+func TestStateDiffTable(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateDiffCommand{Meta: newTestMeta(ui), stateSvc: newStateDiffFixture()}
+
+	if code := cmd.Run([]string{"-from=sv-1", "-to=sv-2"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	for _, want := range []string{"Comparing sv-1 (serial 1) to sv-2 (serial 2)", "Attribute changes:", "cidr_block", "Outputs:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestStateDiffFirstVersionHasNoPrevious(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateDiffCommand{Meta: newTestMeta(ui), stateSvc: newStateDiffFixture()}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-to=sv-1"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "first version") {
+		t.Fatalf("unexpected error %q", out)
+	}
+}
+
+func TestStateDiffValidation(t *testing.T) {
+	cases := map[string][]string{
+		"missing workspace": {"-org=my-org", "-from=sv-1"},
+		"bad output":        {"-from=sv-1", "-to=sv-2", "-output=yaml"},
+	}
+	for name, args := range cases {
+		ui := cli.NewMockUi()
+		cmd := &StateDiffCommand{Meta: newTestMeta(ui), stateSvc: newStateDiffFixture()}
+		if code := cmd.Run(args); code != 1 {
+			t.Errorf("%s: expected exit 1, got %d", name, code)
+		}
+	}
+}
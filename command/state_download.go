@@ -1,11 +1,10 @@
 package command
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
 	tfe "github.com/hashicorp/go-tfe"
@@ -18,23 +17,44 @@ type StateDownloadCommand struct {
 	organization         string
 	workspace            string
 	stateVersionID       string
+	serial               int64
+	at                   string
+	all                  bool
+	dir                  string
 	outputFile           string
-	stateDownloadSvc     stateVersionReader
+	stateDownloadSvc     stateVersionHistoryService
 	workspaceDownloadSvc workspaceReader
 }
 
 // Run executes the state download command
 func (c *StateDownloadCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet("state download")
-	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
 	flags.StringVar(&c.workspace, "workspace", "", "Workspace name")
 	flags.StringVar(&c.stateVersionID, "id", "", "State version ID (optional, defaults to current)")
+	flags.Int64Var(&c.serial, "serial", -1, "Download the state version with this serial")
+	flags.StringVar(&c.at, "at", "", "Download the state version that was current at this time (RFC 3339)")
+	flags.BoolVar(&c.all, "all", false, "Download every state version into -dir")
+	flags.StringVar(&c.dir, "dir", "", "Directory for -all downloads")
 	flags.StringVar(&c.outputFile, "output", "", "Output file path (required)")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
+	sel, err := newStateVersionSelector(c.stateVersionID, c.serial, c.at)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	if c.all && (c.stateVersionID != "" || c.serial >= 0 || c.at != "") {
+		c.Ui.Error("Error: only one of -id, -serial, -at, or -all may be used")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
 	// Must provide either (org + workspace) or state version ID
 	if c.stateVersionID == "" && (c.organization == "" || c.workspace == "") {
 		c.Ui.Error("Error: must provide either -id or both -org and -workspace")
@@ -42,19 +62,24 @@ func (c *StateDownloadCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.all && c.dir == "" {
+		c.Ui.Error("Error: -dir is required with -all")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
 	// Get API client
 	client, err := c.Meta.Client()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
 		return 1
 	}
+	ctx := client.Context()
 
-	var stateVersion *tfe.StateVersion
-
-	// If state version ID not provided, get current state from workspace
-	if c.stateVersionID == "" && c.organization != "" && c.workspace != "" {
-		// Get workspace to find current state version
-		ws, err := c.workspaceService(client).Read(client.Context(), c.organization, c.workspace)
+	// Workspace context is needed for everything except a lookup by ID
+	var ws *tfe.Workspace
+	if c.stateVersionID == "" {
+		ws, err = c.workspaceService(client).Read(ctx, c.organization, c.workspace)
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
 			return 1
@@ -64,61 +89,23 @@ func (c *StateDownloadCommand) Run(args []string) int {
 			c.Ui.Error("Error: workspace has no state version")
 			return 1
 		}
-
-		// Read full state version details using workspace ID
-		stateVersion, err = c.stateService(client).ReadCurrent(client.Context(), ws.ID)
-		if err != nil {
-			c.Ui.Error(fmt.Sprintf("Error reading state version: %s", err))
-			return 1
-		}
-	} else if c.stateVersionID != "" {
-		// For now, we need workspace context to get state version
-		// The go-tfe library doesn't have a ReadByID method for state versions
-		c.Ui.Error("Error: -id flag not yet supported, please use -org and -workspace")
-		return 1
 	}
 
-	if stateVersion.DownloadURL == "" {
-		c.Ui.Error("Error: state version has no download URL")
-		return 1
+	if c.all {
+		return c.downloadAll(ctx, c.stateService(client), ws)
 	}
 
-	// Download state file with authentication
-	req, err := http.NewRequest("GET", stateVersion.DownloadURL, nil)
+	stateVersion, err := resolveStateVersion(ctx, c.stateService(client), ws, c.organization, sel)
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Error creating download request: %s", err))
+		c.Ui.Error(fmt.Sprintf("Error reading state version: %s", err))
 		return 1
 	}
 
-	// Add authentication token
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", client.Token()))
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	stateContent, _, err := downloadStateFile(ctx, c.stateService(client), stateVersion)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error downloading state file: %s", err))
 		return 1
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		c.Ui.Error(fmt.Sprintf("Error: received status code %d from download URL", resp.StatusCode))
-		return 1
-	}
-
-	// Read the state content
-	stateContent, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Error reading state content: %s", err))
-		return 1
-	}
-
-	// Validate it's valid JSON
-	var jsonCheck interface{}
-	if err := json.Unmarshal(stateContent, &jsonCheck); err != nil {
-		c.Ui.Error(fmt.Sprintf("Error: downloaded content is not valid JSON: %s", err))
-		return 1
-	}
 
 	// If output file specified, write to file
 	if c.outputFile != "" {
@@ -133,12 +120,51 @@ func (c *StateDownloadCommand) Run(args []string) int {
 		c.Ui.Info(fmt.Sprintf("Resources: %d", len(stateVersion.Resources)))
 	} else {
 		// Otherwise, print to stdout
-		fmt.Println(string(stateContent))
+		c.Ui.Output(string(stateContent))
 	}
 
 	return 0
 }
 
+// downloadAll writes every state version of the workspace into c.dir as
+// <serial>-<state version ID>.json. Files that already exist are skipped so
+// an interrupted download can be resumed.
+func (c *StateDownloadCommand) downloadAll(ctx context.Context, svc stateVersionHistoryService, ws *tfe.Workspace) int {
+	versions, err := listAllStateVersions(ctx, svc, c.organization, ws.Name, 0)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing state versions: %s", err))
+		return 1
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating directory: %s", err))
+		return 1
+	}
+
+	downloaded, skipped := 0, 0
+	for _, sv := range versions {
+		path := filepath.Join(c.dir, fmt.Sprintf("%d-%s.json", sv.Serial, sv.ID))
+		if _, err := os.Stat(path); err == nil {
+			skipped++
+			continue
+		}
+
+		content, _, err := downloadStateFile(ctx, svc, sv)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error downloading state file: %s", err))
+			return 1
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+			return 1
+		}
+		downloaded++
+	}
+
+	c.Ui.Info(fmt.Sprintf("Downloaded %d state versions to %s (%d already present)", downloaded, c.dir, skipped))
+	return 0
+}
+
 // Help returns help text for the state download command
 func (c *StateDownloadCommand) Help() string {
 	helpText := `
@@ -146,11 +172,19 @@ Usage: hcptf state download [options]
 
   Download state file contents as JSON.
 
+  By default the workspace's current state is downloaded. Use -id, -serial,
+  or -at to pick an earlier version, or -all to download the full history.
+
 Options:
 
-  -org=<name>           Organization name (required with -workspace)
+  -organization=<name>  Organization name (required with -workspace)
+  -org=<name>           Alias for -organization
   -workspace=<name>     Workspace name (required with -org)
   -id=<state-version>   State version ID (optional, defaults to current)
+  -serial=<n>           State version with this serial
+  -at=<timestamp>       State version that was current at this time (RFC 3339)
+  -all                  Download every state version (requires -dir)
+  -dir=<path>           Directory for -all, files are named <serial>-<id>.json
   -output=<file>        Output file path (optional, prints to stdout if omitted)
 
 Examples:
@@ -161,13 +195,22 @@ Examples:
   # Download current state to file
   hcptf state download -org=my-org -workspace=my-workspace -output=state.json
 
+  # Download a specific state version by ID
+  hcptf state download -id=sv-ABC123 -output=state.json
+
+  # State as it was before an incident
+  hcptf state download -org=my-org -workspace=my-workspace -at=2026-03-01T09:00:00Z
+
+  # Download the full history
+  hcptf state download -org=my-org -workspace=my-workspace -all -dir=./history
+
   # Pipe to jq for analysis
   hcptf state download -org=my-org -workspace=my-workspace | jq '.resources | length'
 `
 	return strings.TrimSpace(helpText)
 }
 
-func (c *StateDownloadCommand) stateService(client *client.Client) stateVersionReader {
+func (c *StateDownloadCommand) stateService(client *client.Client) stateVersionHistoryService {
 	if c.stateDownloadSvc != nil {
 		return c.stateDownloadSvc
 	}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newStateDownloadCommand(ui cli.Ui, svc *mockStateVersionHistoryService) *StateDownloadCommand {
+	return &StateDownloadCommand{
+		Meta:             newTestMeta(ui),
+		stateDownloadSvc: svc,
+		workspaceDownloadSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{
+			ID:                  "ws-1",
+			Name:                "prod",
+			CurrentStateVersion: &tfe.StateVersion{ID: "sv-3"},
+		}},
+	}
+}
+
+func TestStateDownloadRequiresWorkspaceOrID(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-id") {
+		t.Fatalf("expected usage error, got %q", out)
+	}
+}
+
+func TestStateDownloadRejectsMultipleSelectors(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-serial=1", "-at=2026-03-01"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "only one of") {
+		t.Fatalf("expected selector error, got %q", out)
+	}
+}
+
+func TestStateDownloadCurrent(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, `"serial":3`) {
+		t.Fatalf("expected current state, got %q", out)
+	}
+}
+
+func TestStateDownloadByID(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := stateHistoryFixture()
+	cmd := newStateDownloadCommand(ui, svc)
+	out := filepath.Join(t.TempDir(), "state.json")
+
+	if code := cmd.Run([]string{"-id=sv-2", "-output=" + out}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected state file: %v", err)
+	}
+	if !strings.Contains(string(data), `"serial":2`) {
+		t.Fatalf("unexpected state content %q", data)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "State version: sv-2") {
+		t.Fatalf("expected summary, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStateDownloadBySerialAndTimestamp(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-serial=1"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, `"serial":1`) {
+		t.Fatalf("expected serial 1, got %q", out)
+	}
+
+	ui = cli.NewMockUi()
+	cmd = newStateDownloadCommand(ui, stateHistoryFixture())
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-at=2026-03-02T12:00:00Z"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, `"serial":2`) {
+		t.Fatalf("expected serial 2, got %q", out)
+	}
+}
+
+func TestStateDownloadRejectsInvalidTimestamp(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-at=last-tuesday"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestStateDownloadAll(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := stateHistoryFixture()
+	svc.pageSize = 2
+	cmd := newStateDownloadCommand(ui, svc)
+	dir := t.TempDir()
+
+	// Pre-existing files are skipped so interrupted downloads can resume.
+	if err := os.WriteFile(filepath.Join(dir, "3-sv-3.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-all", "-dir=" + dir}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	for _, name := range []string{"1-sv-1.json", "2-sv-2.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+	}
+	if len(svc.downloads) != 2 {
+		t.Fatalf("expected 2 downloads, got %v", svc.downloads)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "Downloaded 2 state versions") {
+		t.Fatalf("unexpected summary %q", out)
+	}
+}
+
+func TestStateDownloadAllRequiresDir(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newStateDownloadCommand(ui, stateHistoryFixture())
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-all"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-dir") {
+		t.Fatalf("expected -dir error, got %q", out)
+	}
+}
+
+func TestStateDownloadHelp(t *testing.T) {
+	cmd := &StateDownloadCommand{}
+	help := cmd.Help()
+	for _, want := range []string{"-serial", "-at", "-all", "-dir"} {
+		if !strings.Contains(help, want) {
+			t.Fatalf("expected help to contain %q", want)
+		}
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
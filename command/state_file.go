@@ -0,0 +1,416 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// terraformState is the subset of a Terraform state file the state commands
+// work with.
+type terraformState struct {
+	Version          int                     `json:"version"`
+	TerraformVersion string                  `json:"terraform_version"`
+	Serial           int64                   `json:"serial"`
+	Lineage          string                  `json:"lineage"`
+	Outputs          map[string]*stateOutput `json:"outputs"`
+	Resources        []*stateResource        `json:"resources"`
+}
+
+// stateOutput is a root module output value.
+type stateOutput struct {
+	Value     interface{}     `json:"value"`
+	Type      json.RawMessage `json:"type,omitempty"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+}
+
+// stateResource is a resource block and its instances.
+type stateResource struct {
+	Module    string           `json:"module,omitempty"`
+	Mode      string           `json:"mode"`
+	Type      string           `json:"type"`
+	Name      string           `json:"name"`
+	Provider  string           `json:"provider"`
+	Instances []*stateInstance `json:"instances"`
+}
+
+// stateInstance is a single instance of a resource. SensitiveAttributes holds
+// the paths Terraform marked sensitive, as lists of get_attr/index steps.
+type stateInstance struct {
+	IndexKey            interface{}            `json:"index_key,omitempty"`
+	SchemaVersion       int                    `json:"schema_version"`
+	Attributes          map[string]interface{} `json:"attributes"`
+	SensitiveAttributes json.RawMessage        `json:"sensitive_attributes,omitempty"`
+	Status              string                 `json:"status,omitempty"`
+	Dependencies        []string               `json:"dependencies,omitempty"`
+}
+
+// stateInstanceEntry pairs an instance with its resource and address.
+type stateInstanceEntry struct {
+	Address  string
+	Resource *stateResource
+	Instance *stateInstance
+}
+
+// parseStateFile decodes raw state file contents. Numbers are kept as
+// json.Number so large IDs and counts survive comparison and display intact.
+func parseStateFile(data []byte) (*terraformState, error) {
+	var state terraformState
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&state); err != nil {
+		return nil, fmt.Errorf("state is not valid JSON: %w", err)
+	}
+	return &state, nil
+}
+
+// resourceAddress returns the Terraform address of a resource, without an
+// instance key, e.g. module.net.aws_vpc.main or data.aws_ami.ubuntu.
+func (r *stateResource) resourceAddress() string {
+	addr := r.Type + "." + r.Name
+	if r.Mode == "data" {
+		addr = "data." + addr
+	}
+	if r.Module != "" {
+		addr = r.Module + "." + addr
+	}
+	return addr
+}
+
+// instanceAddress returns the address of one instance of the resource.
+func (r *stateResource) instanceAddress(inst *stateInstance) string {
+	return r.resourceAddress() + formatInstanceKey(inst.IndexKey)
+}
+
+func formatInstanceKey(key interface{}) string {
+	switch k := key.(type) {
+	case nil:
+		return ""
+	case string:
+		return fmt.Sprintf("[%q]", k)
+	default:
+		return fmt.Sprintf("[%v]", k)
+	}
+}
+
+// providerName returns the provider source address without the
+// provider["..."] wrapper, keeping any alias, e.g.
+// registry.terraform.io/hashicorp/aws.west.
+func (r *stateResource) providerName() string {
+	name := r.Provider
+	if i := strings.Index(name, "provider["); i >= 0 {
+		name = name[i+len("provider["):]
+	}
+	name = strings.Replace(name, "\"]", "", 1)
+	return strings.Trim(name, "\"")
+}
+
+// instances returns every resource instance in the state keyed by address.
+func (s *terraformState) instances() map[string]*stateInstanceEntry {
+	entries := map[string]*stateInstanceEntry{}
+	for _, r := range s.Resources {
+		for _, inst := range r.Instances {
+			addr := r.instanceAddress(inst)
+			entries[addr] = &stateInstanceEntry{Address: addr, Resource: r, Instance: inst}
+		}
+	}
+	return entries
+}
+
+// sensitivePaths returns the instance's sensitive attribute paths in the
+// notation used by flattenStateValue. Unrecognised markers are ignored.
+func (inst *stateInstance) sensitivePaths() []string {
+	if len(inst.SensitiveAttributes) == 0 {
+		return nil
+	}
+
+	var raw [][]struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(inst.SensitiveAttributes, &raw); err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, steps := range raw {
+		path := ""
+		for _, step := range steps {
+			switch step.Type {
+			case "get_attr":
+				var name string
+				if err := json.Unmarshal(step.Value, &name); err != nil {
+					continue
+				}
+				path = joinStatePath(path, name)
+			case "index":
+				var key struct {
+					Value interface{} `json:"value"`
+					Type  string      `json:"type"`
+				}
+				if err := json.Unmarshal(step.Value, &key); err != nil {
+					continue
+				}
+				if name, ok := key.Value.(string); ok {
+					path = joinStatePath(path, name)
+				} else {
+					path += fmt.Sprintf("[%v]", key.Value)
+				}
+			}
+		}
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// flattenStateValue flattens nested attribute values into leaf paths such as
+// tags.Name or ingress[0].cidr_blocks[1]. Empty objects and lists are kept as
+// leaves so that clearing a value still shows up.
+func flattenStateValue(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for key, child := range v {
+			flattenStateValue(joinStatePath(prefix, key), child, out)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			out[prefix] = v
+			return
+		}
+		for i, child := range v {
+			flattenStateValue(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+func joinStatePath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// isSensitivePath reports whether path is, or is nested under, one of the
+// sensitive paths.
+func isSensitivePath(path string, sensitive []string) bool {
+	for _, s := range sensitive {
+		if path == s || strings.HasPrefix(path, s+".") || strings.HasPrefix(path, s+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// renderStateValue formats a state value the way it appears in JSON.
+func renderStateValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// sortedStateKeys returns the keys of a flattened attribute map in order.
+func sortedStateKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// downloadStateFile fetches the raw contents of a state version and checks
+// that they parse as a state file.
+func downloadStateFile(ctx context.Context, svc stateVersionDownloader, sv *tfe.StateVersion) ([]byte, *terraformState, error) {
+	if sv.DownloadURL == "" {
+		return nil, nil, fmt.Errorf("state version %s has no download URL", sv.ID)
+	}
+
+	data, err := svc.Download(ctx, sv.DownloadURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("downloading state version %s: %w", sv.ID, err)
+	}
+
+	state, err := parseStateFile(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("state version %s: %w", sv.ID, err)
+	}
+	return data, state, nil
+}
+
+// loadSelectedState resolves the state version chosen by sel and downloads
+// it. The workspace is only read when sel does not name a version by ID.
+func loadSelectedState(ctx context.Context, svc stateVersionHistoryService, wsSvc workspaceReader, organization, workspace string, sel stateVersionSelector) (*tfe.StateVersion, *terraformState, error) {
+	var ws *tfe.Workspace
+	if sel.ID == "" {
+		var err error
+		ws, err = wsSvc.Read(ctx, organization, workspace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading workspace: %w", err)
+		}
+	}
+
+	sv, err := resolveStateVersion(ctx, svc, ws, organization, sel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading state version: %w", err)
+	}
+
+	_, state, err := downloadStateFile(ctx, svc, sv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sv, state, nil
+}
+
+// stateVersionSelector picks one state version of a workspace. At most one
+// of ID, Serial, and At is set; when none are, the current version is used.
+type stateVersionSelector struct {
+	ID     string
+	Serial int64
+	At     time.Time
+
+	hasSerial bool
+}
+
+// newStateVersionSelector builds a selector from the -id, -serial, and -at
+// flag values. A negative serial means the flag was not set.
+func newStateVersionSelector(id string, serial int64, at string) (stateVersionSelector, error) {
+	set := 0
+	for _, ok := range []bool{id != "", serial >= 0, at != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set > 1 {
+		return stateVersionSelector{}, fmt.Errorf("only one of -id, -serial, or -at may be used")
+	}
+
+	sel := stateVersionSelector{ID: id}
+	if serial >= 0 {
+		sel.Serial = serial
+		sel.hasSerial = true
+	}
+	if at != "" {
+		t, err := parseStateTimestamp(at)
+		if err != nil {
+			return stateVersionSelector{}, fmt.Errorf("-at: %w", err)
+		}
+		sel.At = t
+	}
+	return sel, nil
+}
+
+// resolveStateVersion finds the state version chosen by sel in the given
+// workspace. Serial and timestamp lookups page through the history.
+func resolveStateVersion(ctx context.Context, svc stateVersionHistoryService, ws *tfe.Workspace, organization string, sel stateVersionSelector) (*tfe.StateVersion, error) {
+	if sel.ID != "" {
+		return svc.Read(ctx, sel.ID)
+	}
+
+	if !sel.hasSerial && sel.At.IsZero() {
+		if ws == nil {
+			return nil, fmt.Errorf("a workspace is required to read the current state version")
+		}
+		return svc.ReadCurrent(ctx, ws.ID)
+	}
+
+	opts := &tfe.StateVersionListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		Organization: organization,
+		Workspace:    ws.Name,
+	}
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		// Versions are listed newest first, so the first version created at
+		// or before the timestamp is the one that was current then.
+		for _, sv := range list.Items {
+			if sel.hasSerial && sv.Serial == sel.Serial {
+				return sv, nil
+			}
+			if !sel.At.IsZero() && !sv.CreatedAt.After(sel.At) {
+				return sv, nil
+			}
+		}
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			break
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+
+	if sel.hasSerial {
+		return nil, fmt.Errorf("no state version with serial %d", sel.Serial)
+	}
+	return nil, fmt.Errorf("no state version created at or before %s", sel.At.Format(time.RFC3339))
+}
+
+// previousStateVersion returns the state version written immediately before
+// the given one, paging through the history until it is found.
+func previousStateVersion(ctx context.Context, svc stateVersionLister, organization, workspace, svID string) (*tfe.StateVersion, error) {
+	opts := &tfe.StateVersionListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		Organization: organization,
+		Workspace:    workspace,
+	}
+
+	found := false
+	for {
+		list, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, sv := range list.Items {
+			if found {
+				return sv, nil
+			}
+			found = sv.ID == svID
+		}
+
+		if !hasNextPage(list.Pagination, opts.PageNumber) {
+			break
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+
+	if found {
+		return nil, fmt.Errorf("state version %s is the first version of the workspace", svID)
+	}
+	return nil, fmt.Errorf("state version %s not found in workspace %s", svID, workspace)
+}
+
+// parseStateTimestamp accepts RFC 3339 timestamps and, for convenience, plain
+// dates or date-times, which are read as UTC.
+func parseStateTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q (use RFC 3339, e.g. 2026-01-02T15:04:05Z)", value)
+}
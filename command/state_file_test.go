@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+var stateHistoryStart = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// stateHistoryFixture returns a history of three state versions, newest
+// first, created a day apart and written by runs run-1 to run-3.
+func stateHistoryFixture() *mockStateVersionHistoryService {
+	svc := &mockStateVersionHistoryService{contents: map[string][]byte{}}
+	for serial := 3; serial >= 1; serial-- {
+		id := fmt.Sprintf("sv-%d", serial)
+		url := "https://archivist.example/" + id
+		svc.versions = append(svc.versions, &tfe.StateVersion{
+			ID:          id,
+			Serial:      int64(serial),
+			CreatedAt:   stateHistoryStart.Add(time.Duration(serial-1) * 24 * time.Hour),
+			DownloadURL: url,
+			Run:         &tfe.Run{ID: fmt.Sprintf("run-%d", serial)},
+		})
+		svc.contents[url] = []byte(fmt.Sprintf(`{"version":4,"terraform_version":"1.9.0","serial":%d,"lineage":"lineage-a","outputs":{},"resources":[]}`, serial))
+	}
+	return svc
+}
+
+func TestResolveStateVersion(t *testing.T) {
+	ws := &tfe.Workspace{ID: "ws-1", Name: "prod"}
+	cases := map[string]struct {
+		sel  stateVersionSelector
+		want string
+	}{
+		"current":         {stateVersionSelector{}, "sv-3"},
+		"by id":           {stateVersionSelector{ID: "sv-2"}, "sv-2"},
+		"by serial":       {stateVersionSelector{Serial: 1, hasSerial: true}, "sv-1"},
+		"at exact time":   {stateVersionSelector{At: stateHistoryStart.Add(24 * time.Hour)}, "sv-2"},
+		"between version": {stateVersionSelector{At: stateHistoryStart.Add(36 * time.Hour)}, "sv-2"},
+	}
+
+	for name, tc := range cases {
+		svc := stateHistoryFixture()
+		svc.pageSize = 1
+		sv, err := resolveStateVersion(context.Background(), svc, ws, "my-org", tc.sel)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if sv.ID != tc.want {
+			t.Errorf("%s: expected %s, got %s", name, tc.want, sv.ID)
+		}
+	}
+}
+
+func TestResolveStateVersionNotFound(t *testing.T) {
+	ws := &tfe.Workspace{ID: "ws-1", Name: "prod"}
+
+	if _, err := resolveStateVersion(context.Background(), stateHistoryFixture(), ws, "my-org", stateVersionSelector{Serial: 9, hasSerial: true}); err == nil {
+		t.Fatal("expected error for unknown serial")
+	}
+	if _, err := resolveStateVersion(context.Background(), stateHistoryFixture(), ws, "my-org", stateVersionSelector{At: stateHistoryStart.Add(-time.Hour)}); err == nil {
+		t.Fatal("expected error for timestamp before the first version")
+	}
+}
+
+func TestDownloadStateFileRejectsInvalidJSON(t *testing.T) {
+	svc := &mockStateVersionHistoryService{contents: map[string][]byte{"https://x": []byte("not json")}}
+
+	if _, _, err := downloadStateFile(context.Background(), svc, &tfe.StateVersion{ID: "sv-1", DownloadURL: "https://x"}); err == nil {
+		t.Fatal("expected invalid JSON error")
+	}
+	if _, _, err := downloadStateFile(context.Background(), svc, &tfe.StateVersion{ID: "sv-2"}); err == nil {
+		t.Fatal("expected missing download URL error")
+	}
+}
+
+func TestParseStateTimestamp(t *testing.T) {
+	for _, value := range []string{"2026-03-01T09:30:00Z", "2026-03-01T09:30:00+02:00", "2026-03-01 09:30:00", "2026-03-01"} {
+		if _, err := parseStateTimestamp(value); err != nil {
+			t.Errorf("expected %q to parse: %v", value, err)
+		}
+	}
+	if _, err := parseStateTimestamp("yesterday"); err == nil {
+		t.Fatal("expected error for invalid timestamp")
+	}
+}
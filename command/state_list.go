@@ -1,10 +1,12 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
 )
 
 // StateListCommand is a command to list state versions
@@ -12,7 +14,11 @@ type StateListCommand struct {
 	Meta
 	organization string
 	workspace    string
+	limit        int
+	lineage      bool
 	format       string
+	stateSvc     stateVersionHistoryService
+	runSvc       runDetailReader
 }
 
 // Run executes the state list command
@@ -21,6 +27,8 @@ func (c *StateListCommand) Run(args []string) int {
 	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
 	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
 	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.IntVar(&c.limit, "limit", 0, "Only list the most recent N state versions (default: all)")
+	flags.BoolVar(&c.lineage, "lineage", false, "Download each state file to show its lineage")
 	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
 
 	if err := flags.Parse(args); err != nil {
@@ -40,21 +48,21 @@ func (c *StateListCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.limit < 0 {
+		c.Ui.Error("Error: -limit must not be negative")
+		return 1
+	}
+
 	// Get API client
 	client, err := c.Meta.Client()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
 		return 1
 	}
+	ctx := client.Context()
 
-	// List state versions
-	stateVersions, err := client.StateVersions.List(client.Context(), &tfe.StateVersionListOptions{
-		ListOptions: tfe.ListOptions{
-			PageSize: 50,
-		},
-		Organization: c.organization,
-		Workspace:    c.workspace,
-	})
+	// List the full state version history
+	stateVersions, err := listAllStateVersions(ctx, c.stateService(client), c.organization, c.workspace, c.limit)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error listing state versions: %s", err))
 		return 1
@@ -63,50 +71,110 @@ func (c *StateListCommand) Run(args []string) int {
 	// Format output
 	formatter := c.Meta.NewFormatter(c.format)
 
-	if len(stateVersions.Items) == 0 {
+	if len(stateVersions) == 0 {
 		c.Ui.Output("No state versions found")
 		return 0
 	}
 
 	// Prepare table data
-	headers := []string{"ID", "Serial", "Created At", "Resources"}
+	headers := []string{"ID", "Serial", "Run ID", "Created By", "Created At", "Resources"}
+	if c.lineage {
+		headers = []string{"ID", "Serial", "Lineage", "Run ID", "Created By", "Created At", "Resources"}
+	}
 	var rows [][]string
 
-	for _, sv := range stateVersions.Items {
+	creators := map[string]string{}
+	for _, sv := range stateVersions {
 		resources := "N/A"
 		if sv.ResourcesProcessed {
 			resources = fmt.Sprintf("%d", len(sv.Resources))
 		}
 
-		rows = append(rows, []string{
-			sv.ID,
-			fmt.Sprintf("%d", sv.Serial),
+		runID, createdBy := "", ""
+		if sv.Run != nil && sv.Run.ID != "" {
+			runID = sv.Run.ID
+			createdBy = c.runCreator(ctx, client, runID, creators)
+		}
+
+		row := []string{sv.ID, fmt.Sprintf("%d", sv.Serial)}
+		if c.lineage {
+			// The lineage is only recorded in the state file itself.
+			lineage := ""
+			if _, state, err := downloadStateFile(ctx, c.stateService(client), sv); err != nil {
+				c.Ui.Warn(fmt.Sprintf("Warning: could not read lineage: %s", err))
+			} else {
+				lineage = state.Lineage
+			}
+			row = append(row, lineage)
+		}
+		rows = append(rows, append(row,
+			runID,
+			createdBy,
 			sv.CreatedAt.Format("2006-01-02 15:04:05"),
 			resources,
-		})
+		))
 	}
 
 	formatter.Table(headers, rows)
 	return 0
 }
 
+// runCreator returns the username that queued a run, caching lookups since
+// consecutive state versions often come from the same run.
+func (c *StateListCommand) runCreator(ctx context.Context, client *client.Client, runID string, cache map[string]string) string {
+	if name, ok := cache[runID]; ok {
+		return name
+	}
+
+	name := ""
+	run, err := c.runService(client).ReadWithOptions(ctx, runID, &tfe.RunReadOptions{
+		Include: []tfe.RunIncludeOpt{tfe.RunCreatedBy},
+	})
+	if err == nil && run.CreatedBy != nil {
+		name = run.CreatedBy.Username
+	}
+	cache[runID] = name
+	return name
+}
+
+func (c *StateListCommand) stateService(client *client.Client) stateVersionHistoryService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateListCommand) runService(client *client.Client) runDetailReader {
+	if c.runSvc != nil {
+		return c.runSvc
+	}
+	return client.Runs
+}
+
 // Help returns help text for the state list command
 func (c *StateListCommand) Help() string {
 	helpText := `
 Usage: hcptf state list [options]
 
-  List state versions for a workspace.
+  List the state version history of a workspace, newest first, with each
+  version's serial, the run that wrote it, and who queued that run.
+
+  Lineage is only recorded in the state file itself, so -lineage downloads
+  every listed state file; combine it with -limit on long histories.
 
 Options:
 
   -organization=<name>  Organization name (required)
   -org=<name>          Alias for -organization
   -workspace=<name>    Workspace name (required)
+  -limit=<n>           Only list the most recent N versions (default: all)
+  -lineage             Download each state file to show its lineage
   -output=<format>     Output format: table (default) or json
 
 Example:
 
   hcptf state list -org=my-org -workspace=prod
+  hcptf state list -org=my-org -workspace=prod -limit=20 -lineage
   hcptf state list -org=my-org -workspace=prod -output=json
 `
 	return strings.TrimSpace(helpText)
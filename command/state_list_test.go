@@ -1,9 +1,11 @@
 package command
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/mitchellh/cli"
 )
 
@@ -159,3 +161,74 @@ func TestStateListFlagParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestStateListFullHistory(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := stateHistoryFixture()
+	svc.pageSize = 2
+	runs := &mockRunDetailService{runs: map[string]*tfe.Run{
+		"run-1": {ID: "run-1", CreatedBy: &tfe.User{Username: "alice"}},
+		"run-2": {ID: "run-2", CreatedBy: &tfe.User{Username: "bob"}},
+		"run-3": {ID: "run-3", CreatedBy: &tfe.User{Username: "alice"}},
+	}}
+	cmd := &StateListCommand{Meta: newTestMeta(ui), stateSvc: svc, runSvc: runs}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-lineage", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var rows []map[string]string
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &rows); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 state versions across pages, got %d", len(rows))
+	}
+	if svc.listCalls != 2 {
+		t.Fatalf("expected 2 list calls, got %d", svc.listCalls)
+	}
+
+	first := rows[0]
+	if first["ID"] != "sv-3" || first["Serial"] != "3" || first["Lineage"] != "lineage-a" {
+		t.Fatalf("unexpected first row %v", first)
+	}
+	if first["Run ID"] != "run-3" || first["Created By"] != "alice" {
+		t.Fatalf("expected run and creator, got %v", first)
+	}
+	if rows[1]["Created By"] != "bob" {
+		t.Fatalf("expected bob for sv-2, got %v", rows[1])
+	}
+}
+
+func TestStateListLimit(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := stateHistoryFixture()
+	cmd := &StateListCommand{Meta: newTestMeta(ui), stateSvc: svc, runSvc: &mockRunDetailService{}}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-limit=1", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var rows []map[string]string
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &rows); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["ID"] != "sv-3" {
+		t.Fatalf("expected only the newest version, got %v", rows)
+	}
+	if len(svc.downloads) != 0 {
+		t.Fatalf("expected no state file downloads without -lineage, got %d", len(svc.downloads))
+	}
+	if _, ok := rows[0]["Lineage"]; ok {
+		t.Fatalf("expected no lineage column without -lineage, got %v", rows[0])
+	}
+}
+
+func TestStateListRejectsNegativeLimit(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateListCommand{Meta: newTestMeta(ui), stateSvc: stateHistoryFixture()}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-limit=-1"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
@@ -1,16 +1,39 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	stateOutputsFormatEnv          = "env"
+	stateOutputsFormatDotenv       = "dotenv"
+	stateOutputsFormatTfvars       = "tfvars"
+	stateOutputsFormatGitHubOutput = "github-output"
 )
 
+var envNameInvalidChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
 // StateOutputsCommand is a command to display state outputs
 type StateOutputsCommand struct {
 	Meta
-	organization string
-	workspace    string
-	format       string
+	organization     string
+	workspace        string
+	format           string
+	name             string
+	raw              bool
+	includeSensitive bool
+	exportFormat     string
+	workspaceSvc     workspaceReader
+	stateSvc         stateVersionReader
+	outputSvc        stateVersionOutputService
 }
 
 // Run executes the state outputs command
@@ -20,6 +43,10 @@ func (c *StateOutputsCommand) Run(args []string) int {
 	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
 	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
 	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+	flags.StringVar(&c.name, "name", "", "Only show the output with this name")
+	flags.BoolVar(&c.raw, "raw", false, "Print the value of -name without quotes or formatting")
+	flags.BoolVar(&c.includeSensitive, "include-sensitive", false, "Fetch and show sensitive output values")
+	flags.StringVar(&c.exportFormat, "format", "", "Export format: env, dotenv, tfvars, or github-output")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -38,22 +65,40 @@ func (c *StateOutputsCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.raw && c.name == "" {
+		c.Ui.Error("Error: -raw requires -name")
+		return 1
+	}
+
+	if c.raw && c.exportFormat != "" {
+		c.Ui.Error("Error: -raw and -format cannot be used together")
+		return 1
+	}
+
+	switch c.exportFormat {
+	case "", stateOutputsFormatEnv, stateOutputsFormatDotenv, stateOutputsFormatTfvars, stateOutputsFormatGitHubOutput:
+	default:
+		c.Ui.Error("Error: -format must be one of: env, dotenv, tfvars, github-output")
+		return 1
+	}
+
 	// Get API client
 	client, err := c.Meta.Client()
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
 		return 1
 	}
+	ctx := client.Context()
 
 	// Get workspace first
-	ws, err := client.Workspaces.Read(client.Context(), c.organization, c.workspace)
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
 		return 1
 	}
 
 	// Get current state version
-	currentStateVersion, err := client.StateVersions.ReadCurrent(client.Context(), ws.ID)
+	currentStateVersion, err := c.stateService(client).ReadCurrent(ctx, ws.ID)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error reading current state version: %s", err))
 		return 1
@@ -65,16 +110,77 @@ func (c *StateOutputsCommand) Run(args []string) int {
 	}
 
 	// Read state outputs
-	outputsList, err := client.StateVersionOutputs.ReadCurrent(client.Context(), ws.ID)
+	outputsList, err := c.outputService(client).ReadCurrent(ctx, ws.ID)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error reading state outputs: %s", err))
 		return 1
 	}
 
+	outputs := outputsList.Items
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].Name < outputs[j].Name })
+
+	if c.name != "" {
+		var selected []*tfe.StateVersionOutput
+		for _, out := range outputs {
+			if out.Name == c.name {
+				selected = append(selected, out)
+			}
+		}
+		if len(selected) == 0 {
+			c.Ui.Error(fmt.Sprintf("Error: output %q not found in current state", c.name))
+			return 1
+		}
+		outputs = selected
+	}
+
+	// The current outputs endpoint omits sensitive values; reading each
+	// output by ID returns them to callers allowed to see them.
+	if c.includeSensitive {
+		for i, out := range outputs {
+			if !out.Sensitive {
+				continue
+			}
+			full, err := c.outputService(client).Read(ctx, out.ID)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading sensitive output %s: %s", out.Name, err))
+				return 1
+			}
+			outputs[i] = full
+		}
+	}
+
+	if c.raw {
+		out := outputs[0]
+		if out.Sensitive && !c.includeSensitive {
+			c.Ui.Error(fmt.Sprintf("Error: output %q is sensitive; use -include-sensitive to print it", out.Name))
+			return 1
+		}
+		value, ok := rawOutputValue(out.Value)
+		if !ok {
+			c.Ui.Error(fmt.Sprintf("Error: output %q is not a string, number, or bool; -raw only supports primitive values", out.Name))
+			return 1
+		}
+		c.Ui.Output(value)
+		return 0
+	}
+
+	if c.exportFormat != "" {
+		var exported []*tfe.StateVersionOutput
+		for _, out := range outputs {
+			if out.Sensitive && !c.includeSensitive {
+				c.Ui.Warn(fmt.Sprintf("Warning: skipping sensitive output %s; use -include-sensitive to export it", out.Name))
+				continue
+			}
+			exported = append(exported, out)
+		}
+		c.Ui.Output(renderStateOutputs(c.exportFormat, exported))
+		return 0
+	}
+
 	// Format output
 	formatter := c.Meta.NewFormatter(c.format)
 
-	if len(outputsList.Items) == 0 {
+	if len(outputs) == 0 {
 		c.Ui.Output("No outputs found in current state")
 		return 0
 	}
@@ -83,13 +189,13 @@ func (c *StateOutputsCommand) Run(args []string) int {
 	if c.format == "json" {
 		// For JSON output, create a structured map
 		jsonOutputs := make(map[string]interface{})
-		for _, out := range outputsList.Items {
+		for _, out := range outputs {
 			outputData := map[string]interface{}{
 				"sensitive": out.Sensitive,
 				"type":      out.Type,
 			}
 
-			if out.Sensitive {
+			if out.Sensitive && !c.includeSensitive {
 				outputData["value"] = "<sensitive>"
 			} else {
 				outputData["value"] = out.Value
@@ -107,9 +213,9 @@ func (c *StateOutputsCommand) Run(args []string) int {
 		headers := []string{"Name", "Value", "Sensitive", "Type"}
 		var rows [][]string
 
-		for _, out := range outputsList.Items {
+		for _, out := range outputs {
 			value := ""
-			if out.Sensitive {
+			if out.Sensitive && !c.includeSensitive {
 				value = "<sensitive>"
 			} else {
 				value = fmt.Sprintf("%v", out.Value)
@@ -129,6 +235,103 @@ func (c *StateOutputsCommand) Run(args []string) int {
 	return 0
 }
 
+// rawOutputValue formats a primitive output value the way
+// terraform output -raw does.
+func rawOutputValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// scalarOutputValue returns strings as-is and everything else as JSON, for
+// formats whose values are plain strings.
+func scalarOutputValue(value interface{}) string {
+	if s, ok := rawOutputValue(value); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// envOutputName turns an output name into an environment variable name.
+func envOutputName(name string) string {
+	name = envNameInvalidChars.ReplaceAllString(strings.ToUpper(name), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// renderStateOutputs writes outputs in one of the export formats.
+func renderStateOutputs(format string, outputs []*tfe.StateVersionOutput) string {
+	var lines []string
+	for _, out := range outputs {
+		switch format {
+		case stateOutputsFormatEnv:
+			value := strings.ReplaceAll(scalarOutputValue(out.Value), "'", `'\''`)
+			lines = append(lines, fmt.Sprintf("export %s='%s'", envOutputName(out.Name), value))
+		case stateOutputsFormatDotenv:
+			lines = append(lines, fmt.Sprintf("%s=%s", envOutputName(out.Name), quoteDotenvValue(scalarOutputValue(out.Value))))
+		case stateOutputsFormatTfvars:
+			var b strings.Builder
+			fmt.Fprintf(&b, "%s = ", out.Name)
+			writeHCLValue(&b, out.Value, 0, "", nil)
+			lines = append(lines, b.String())
+		case stateOutputsFormatGitHubOutput:
+			value := scalarOutputValue(out.Value)
+			if !strings.Contains(value, "\n") {
+				lines = append(lines, fmt.Sprintf("%s=%s", out.Name, value))
+				continue
+			}
+			// Multi-line values use the heredoc form with a delimiter that
+			// does not occur in the value.
+			delimiter := "HCPTF_EOF"
+			for i := 1; strings.Contains(value, delimiter); i++ {
+				delimiter = fmt.Sprintf("HCPTF_EOF_%d", i)
+			}
+			lines = append(lines, fmt.Sprintf("%s<<%s\n%s\n%s", out.Name, delimiter, value, delimiter))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quoteDotenvValue(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func (c *StateOutputsCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *StateOutputsCommand) stateService(client *client.Client) stateVersionReader {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateOutputsCommand) outputService(client *client.Client) stateVersionOutputService {
+	if c.outputSvc != nil {
+		return c.outputSvc
+	}
+	return client.StateVersionOutputs
+}
+
 // Help returns help text for the state outputs command
 func (c *StateOutputsCommand) Help() string {
 	helpText := `
@@ -136,17 +339,36 @@ Usage: hcptf state outputs [options]
 
   Display the outputs from the current state version of a workspace.
 
+  Sensitive values are masked unless -include-sensitive is set, which reads
+  each sensitive output individually and requires permission to read state
+  outputs. With -format, outputs are printed for another job to consume;
+  sensitive outputs are skipped unless -include-sensitive is set.
+
 Options:
 
   -organization=<name>  Organization name (required)
   -org=<name>          Alias for -organization
   -workspace=<name>    Workspace name (required)
+  -name=<output>       Only show the output with this name
+  -raw                 Print the value of -name without quotes, like
+                       terraform output -raw (strings, numbers, and bools)
+  -include-sensitive   Fetch and show sensitive output values
+  -format=<format>     Export format:
+                         env            export NAME='value' lines for eval
+                         dotenv         NAME="value" lines for .env files
+                         tfvars         name = value in HCL syntax
+                         github-output  name=value lines for $GITHUB_OUTPUT
+                       env and dotenv upper-case names; complex values are
+                       written as JSON except in tfvars.
   -output=<format>     Output format: table (default) or json
 
 Example:
 
   hcptf state outputs -org=my-org -workspace=prod
-  hcptf state outputs -org=my-org -workspace=prod -output=json
+  hcptf state outputs -org=my-org -workspace=prod -name=vpc_id -raw
+  eval $(hcptf state outputs -org=my-org -workspace=network -format=env)
+  hcptf state outputs -org=my-org -workspace=network -format=tfvars > network.auto.tfvars
+  hcptf state outputs -org=my-org -workspace=network -format=github-output >> "$GITHUB_OUTPUT"
 `
 	return strings.TrimSpace(helpText)
 }
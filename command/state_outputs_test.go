@@ -1,9 +1,11 @@
 package command
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/mitchellh/cli"
 )
 
@@ -152,3 +154,174 @@ func TestStateOutputsFlagParsing(t *testing.T) {
 		})
 	}
 }
+
+type mockStateVersionOutputService struct {
+	outputs   []*tfe.StateVersionOutput
+	sensitive map[string]interface{}
+	reads     []string
+}
+
+func (m *mockStateVersionOutputService) ReadCurrent(_ context.Context, _ string) (*tfe.StateVersionOutputsList, error) {
+	// Like the API, the list omits sensitive values.
+	items := make([]*tfe.StateVersionOutput, 0, len(m.outputs))
+	for _, out := range m.outputs {
+		copied := *out
+		if copied.Sensitive {
+			copied.Value = nil
+		}
+		items = append(items, &copied)
+	}
+	return &tfe.StateVersionOutputsList{Items: items}, nil
+}
+
+func (m *mockStateVersionOutputService) Read(_ context.Context, outputID string) (*tfe.StateVersionOutput, error) {
+	m.reads = append(m.reads, outputID)
+	for _, out := range m.outputs {
+		if out.ID == outputID {
+			copied := *out
+			copied.Value = m.sensitive[out.Name]
+			return &copied, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func newStateOutputsCommand(ui cli.Ui) (*StateOutputsCommand, *mockStateVersionOutputService) {
+	outputs := &mockStateVersionOutputService{
+		outputs: []*tfe.StateVersionOutput{
+			{ID: "wsout-1", Name: "vpc_id", Type: "string", Value: "vpc-123"},
+			{ID: "wsout-2", Name: "subnet_ids", Type: "array", Value: []interface{}{"subnet-a", "subnet-b"}},
+			{ID: "wsout-3", Name: "db_password", Type: "string", Sensitive: true},
+			{ID: "wsout-4", Name: "replicas", Type: "number", Value: float64(3)},
+			{ID: "wsout-5", Name: "motd", Type: "string", Value: "it's\nfine"},
+		},
+		sensitive: map[string]interface{}{"db_password": "s3cr3t"},
+	}
+	cmd := &StateOutputsCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "network"}},
+		stateSvc:     stateHistoryFixture(),
+		outputSvc:    outputs,
+	}
+	return cmd, outputs
+}
+
+func TestStateOutputsRaw(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newStateOutputsCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-name=vpc_id", "-raw"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); out != "vpc-123\n" {
+		t.Fatalf("expected raw value, got %q", out)
+	}
+}
+
+func TestStateOutputsRawRejectsComplexAndSensitive(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newStateOutputsCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-name=subnet_ids", "-raw"}); code != 1 {
+		t.Fatalf("expected exit 1 for a list, got %d", code)
+	}
+
+	ui = cli.NewMockUi()
+	cmd, _ = newStateOutputsCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-name=db_password", "-raw"}); code != 1 {
+		t.Fatalf("expected exit 1 for a sensitive output, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-include-sensitive") {
+		t.Fatalf("expected hint about -include-sensitive, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestStateOutputsIncludeSensitive(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, outputs := newStateOutputsCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-name=db_password", "-raw", "-include-sensitive"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); out != "s3cr3t\n" {
+		t.Fatalf("expected sensitive value, got %q", out)
+	}
+	if len(outputs.reads) != 1 || outputs.reads[0] != "wsout-3" {
+		t.Fatalf("expected only the sensitive output to be read, got %v", outputs.reads)
+	}
+}
+
+func TestStateOutputsEnvFormat(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newStateOutputsCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-format=env"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	want := "export MOTD='it'\\''s\nfine'\n" +
+		"export REPLICAS='3'\n" +
+		"export SUBNET_IDS='[\"subnet-a\",\"subnet-b\"]'\n" +
+		"export VPC_ID='vpc-123'\n"
+	if out := ui.OutputWriter.String(); out != want {
+		t.Fatalf("unexpected env output:\n%s\nwant:\n%s", out, want)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "skipping sensitive output db_password") {
+		t.Fatalf("expected sensitive skip warning, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestStateOutputsDotenvAndTfvars(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newStateOutputsCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-format=dotenv", "-include-sensitive"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	out := ui.OutputWriter.String()
+	for _, want := range []string{`DB_PASSWORD="s3cr3t"`, `MOTD="it's\nfine"`, `SUBNET_IDS="[\"subnet-a\",\"subnet-b\"]"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dotenv output to contain %s, got:\n%s", want, out)
+		}
+	}
+
+	ui = cli.NewMockUi()
+	cmd, _ = newStateOutputsCommand(ui)
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-format=tfvars", "-name=subnet_ids"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	want := "subnet_ids = [\n    \"subnet-a\",\n    \"subnet-b\",\n]\n"
+	if out := ui.OutputWriter.String(); out != want {
+		t.Fatalf("unexpected tfvars output:\n%s", out)
+	}
+}
+
+func TestStateOutputsGitHubOutputFormat(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newStateOutputsCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-format=github-output"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "motd<<HCPTF_EOF\nit's\nfine\nHCPTF_EOF\n") {
+		t.Fatalf("expected heredoc for multi-line value, got:\n%s", out)
+	}
+	if !strings.Contains(out, "vpc_id=vpc-123") {
+		t.Fatalf("expected name=value line, got:\n%s", out)
+	}
+}
+
+func TestStateOutputsValidatesExportFlags(t *testing.T) {
+	cases := map[string][]string{
+		"raw without name": {"-org=my-org", "-workspace=network", "-raw"},
+		"raw with format":  {"-org=my-org", "-workspace=network", "-name=vpc_id", "-raw", "-format=env"},
+		"unknown format":   {"-org=my-org", "-workspace=network", "-format=yaml"},
+		"unknown name":     {"-org=my-org", "-workspace=network", "-name=missing"},
+	}
+	for name, args := range cases {
+		ui := cli.NewMockUi()
+		cmd, _ := newStateOutputsCommand(ui)
+		if code := cmd.Run(args); code != 1 {
+			t.Errorf("%s: expected exit 1, got %d", name, code)
+		}
+	}
+}
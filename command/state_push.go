@@ -0,0 +1,151 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// StatePushCommand is a command to upload a local state file as a new state version
+type StatePushCommand struct {
+	Meta
+	organization string
+	workspace    string
+	file         string
+	force        bool
+	autoApprove  bool
+	stateSvc     stateVersionWriteService
+	workspaceSvc workspaceStateLocker
+}
+
+// Run executes the state push command
+func (c *StatePushCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state push")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.StringVar(&c.file, "file", "", "Path to the state file to push (required)")
+	flags.BoolVar(&c.force, "force", false, "Skip the lineage and serial checks")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation prompt")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Error("Error: -file flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state file: %s", err))
+		return 1
+	}
+	state, err := parseStateFile(data)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state file: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	current, currentState, err := readCurrentState(ctx, c.stateService(client), ws)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	return writeStateVersion(ctx, &c.Meta, c.stateService(client), c.workspaceService(client), &stateWrite{
+		action:       "push",
+		organization: c.organization,
+		workspace:    ws,
+		current:      current,
+		currentState: currentState,
+		source:       c.file,
+		data:         data,
+		state:        state,
+		force:        c.force,
+	}, c.autoApprove)
+}
+
+func (c *StatePushCommand) stateService(client *client.Client) stateVersionWriteService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StatePushCommand) workspaceService(client *client.Client) workspaceStateLocker {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the state push command
+func (c *StatePushCommand) Help() string {
+	helpText := `
+Usage: hcptf state push [options]
+
+  Upload a local state file as a new state version of a workspace, like
+  terraform state push, without the terraform binary or a backend
+  configuration.
+
+  The state's lineage must match the current state and its serial must be
+  greater than the current serial. The resource changes are shown before
+  asking for confirmation. The workspace is locked while the state is
+  written and unlocked afterwards, and the stored state's MD5 is checked
+  against the local file. If a run writes a new state before the lock is
+  taken, the checks run again and the new changes are shown for
+  confirmation.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required)
+  -file=<path>         Path to the state file to push (required)
+  -force               Skip the lineage and serial checks
+  -auto-approve        Skip confirmation prompt
+
+Example:
+
+  hcptf state push -org=my-org -workspace=prod -file=terraform.tfstate
+  hcptf state push -org=my-org -workspace=prod -file=fixed.tfstate -dry-run
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state push command
+func (c *StatePushCommand) Synopsis() string {
+	return "Upload a local state file as a new state version"
+}
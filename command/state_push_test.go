@@ -0,0 +1,248 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newStateWriteFixture() (*mockStateVersionWriteService, *mockWorkspaceStateLocker) {
+	svc := &mockStateVersionWriteService{mockStateVersionHistoryService: stateHistoryFixture()}
+	locker := &mockWorkspaceStateLocker{workspace: &tfe.Workspace{
+		ID:                  "ws-1",
+		Name:                "prod",
+		CurrentStateVersion: &tfe.StateVersion{ID: "sv-3"},
+	}}
+	return svc, locker
+}
+
+func writeTestStateFile(t *testing.T, serial int, lineage string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	content := fmt.Sprintf(`{"version":4,"terraform_version":"1.9.0","serial":%d,"lineage":%q,"outputs":{},"resources":[`+
+		`{"mode":"managed","type":"null_resource","name":"a","provider":"provider[\"registry.terraform.io/hashicorp/null\"]",`+
+		`"instances":[{"schema_version":0,"attributes":{"id":"1"}}]}]}`, serial, lineage)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newStatePushCommand(ui *cli.MockUi, svc *mockStateVersionWriteService, locker *mockWorkspaceStateLocker) *StatePushCommand {
+	return &StatePushCommand{Meta: newTestMeta(ui), stateSvc: svc, workspaceSvc: locker}
+}
+
+func TestStatePushWritesNewVersion(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("yes\n")
+	svc, locker := newStateWriteFixture()
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(svc.created) != 1 {
+		t.Fatalf("expected 1 state version created, got %d", len(svc.created))
+	}
+	opts := svc.created[0]
+	if *opts.Serial != 4 || *opts.Lineage != "lineage-a" || *opts.MD5 == "" {
+		t.Fatalf("unexpected create options serial=%d lineage=%s md5=%s", *opts.Serial, *opts.Lineage, *opts.MD5)
+	}
+	if locker.lockCalls != 1 || locker.unlockCalls != 1 || locker.locked {
+		t.Fatalf("expected lock and unlock once, got lock=%d unlock=%d", locker.lockCalls, locker.unlockCalls)
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "null_resource.a") {
+		t.Fatalf("expected resource diff, got %q", out)
+	}
+	if !strings.Contains(out, "Created state version sv-new-1 (serial 4)") {
+		t.Fatalf("expected confirmation message, got %q", out)
+	}
+}
+
+func TestStatePushRejectsLineageMismatch(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	file := writeTestStateFile(t, 4, "lineage-b")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "lineage") {
+		t.Fatalf("expected lineage error, got %q", ui.ErrorWriter.String())
+	}
+	if len(svc.created) != 0 || locker.lockCalls != 0 {
+		t.Fatal("expected no write or lock")
+	}
+}
+
+func TestStatePushRejectsOldSerialUnlessForced(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	file := writeTestStateFile(t, 3, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "must be greater than the current serial 3") {
+		t.Fatalf("expected serial error, got %q", ui.ErrorWriter.String())
+	}
+
+	ui = cli.NewMockUi()
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve", "-force"}); code != 0 {
+		t.Fatalf("expected exit 0 with -force, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(svc.created) != 1 || !*svc.created[0].Force {
+		t.Fatal("expected a forced write")
+	}
+}
+
+func TestStatePushCancelled(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("no\n")
+	svc, locker := newStateWriteFixture()
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if len(svc.created) != 0 || locker.lockCalls != 0 {
+		t.Fatal("expected no write after declining")
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "cancelled") {
+		t.Fatalf("expected cancellation message, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStatePushDetectsMD5Mismatch(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	svc.stored = []byte(`{"serial":4}`)
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "MD5") {
+		t.Fatalf("expected MD5 error, got %q", ui.ErrorWriter.String())
+	}
+	if locker.locked {
+		t.Fatal("expected workspace to be unlocked")
+	}
+}
+
+// newerStateOnLock makes a run write serial 4 between the plan and the lock.
+func newerStateOnLock(svc *mockStateVersionWriteService, locker *mockWorkspaceStateLocker) {
+	locker.onLock = func() {
+		url := "https://archivist.example/sv-4"
+		svc.versions = append([]*tfe.StateVersion{{ID: "sv-4", Serial: 4, DownloadURL: url}}, svc.versions...)
+		svc.contents[url] = []byte(`{"version":4,"terraform_version":"1.9.0","serial":4,"lineage":"lineage-a","outputs":{},"resources":[]}`)
+	}
+}
+
+func TestStatePushRechecksStateUnderLock(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	newerStateOnLock(svc, locker)
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "must be greater than the current serial 4") {
+		t.Fatalf("expected serial error against the new state, got %q", ui.ErrorWriter.String())
+	}
+	if len(svc.created) != 0 {
+		t.Fatal("expected no write over the newer state")
+	}
+	if locker.unlockCalls != 1 || locker.locked {
+		t.Fatal("expected workspace to be unlocked")
+	}
+}
+
+func TestStatePushReconfirmsChangedState(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("yes\nno\n")
+	svc, locker := newStateWriteFixture()
+	newerStateOnLock(svc, locker)
+	file := writeTestStateFile(t, 5, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "changed from sv-3 to sv-4") {
+		t.Fatalf("expected changed state warning, got %q", ui.ErrorWriter.String())
+	}
+	if len(svc.created) != 0 {
+		t.Fatal("expected no write after declining the new diff")
+	}
+	if locker.unlockCalls != 1 || locker.locked {
+		t.Fatal("expected workspace to be unlocked")
+	}
+}
+
+func TestStatePushUnlocksAfterCreateError(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	svc.createErr = errors.New("boom")
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if locker.unlockCalls != 1 || locker.locked {
+		t.Fatal("expected workspace to be unlocked after a failed write")
+	}
+}
+
+func TestStatePushRefusesLockedWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	locker.workspace.Locked = true
+	file := writeTestStateFile(t, 4, "lineage-a")
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if locker.lockCalls != 0 || len(svc.created) != 0 {
+		t.Fatal("expected no lock or write on a locked workspace")
+	}
+}
+
+func TestStatePushDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	file := writeTestStateFile(t, 4, "lineage-a")
+	cmd := newStatePushCommand(ui, svc, locker)
+	cmd.Meta.DryRun = true
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + file}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(svc.created) != 0 || locker.lockCalls != 0 {
+		t.Fatal("expected no write in dry-run")
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, `"action": "push"`) || !strings.Contains(out, `"md5"`) {
+		t.Fatalf("unexpected dry-run output %q", out)
+	}
+}
+
+func TestStatePushRequiresFile(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+
+	if code := newStatePushCommand(ui, svc, locker).Run([]string{"-org=my-org", "-workspace=prod"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-file") {
+		t.Fatalf("expected -file error, got %q", ui.ErrorWriter.String())
+	}
+}
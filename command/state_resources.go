@@ -0,0 +1,200 @@
+package command
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// StateResourcesCommand is a command to list the resources in a state version
+type StateResourcesCommand struct {
+	Meta
+	organization   string
+	workspace      string
+	stateVersionID string
+	serial         int64
+	at             string
+	resourceType   string
+	module         string
+	provider       string
+	format         string
+	stateSvc       stateVersionHistoryService
+	workspaceSvc   workspaceReader
+}
+
+// Run executes the state resources command
+func (c *StateResourcesCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state resources")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name")
+	flags.StringVar(&c.stateVersionID, "id", "", "State version ID (default: current)")
+	flags.Int64Var(&c.serial, "serial", -1, "Use the state version with this serial")
+	flags.StringVar(&c.at, "at", "", "Use the state version that was current at this time (RFC 3339)")
+	flags.StringVar(&c.resourceType, "type", "", "Only list resources of this type (glob patterns allowed)")
+	flags.StringVar(&c.module, "module", "", "Only list resources in this module and its children (root for the root module)")
+	flags.StringVar(&c.provider, "provider", "", "Only list resources managed by this provider")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.stateVersionID == "" && (c.organization == "" || c.workspace == "") {
+		c.Ui.Error("Error: must provide either -id or both -org and -workspace")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	sel, err := newStateVersionSelector(c.stateVersionID, c.serial, c.at)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	if c.resourceType != "" {
+		if _, err := path.Match(c.resourceType, ""); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: invalid -type pattern: %s", err))
+			return 1
+		}
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	_, state, err := loadSelectedState(client.Context(), c.stateService(client), c.workspaceService(client), c.organization, c.workspace, sel)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	var entries []*stateInstanceEntry
+	for _, entry := range state.instances() {
+		if c.matches(entry.Resource) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if len(entries) == 0 {
+		if c.format == "json" {
+			formatter.JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output("No resources found")
+		return 0
+	}
+
+	headers := []string{"Address", "Type", "Module", "Provider"}
+	var rows [][]string
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Address,
+			entry.Resource.Type,
+			entry.Resource.Module,
+			entry.Resource.providerName(),
+		})
+	}
+
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// matches applies the -type, -module, and -provider filters.
+func (c *StateResourcesCommand) matches(r *stateResource) bool {
+	if c.resourceType != "" {
+		if ok, _ := path.Match(c.resourceType, r.Type); !ok {
+			return false
+		}
+	}
+
+	if c.module != "" {
+		if c.module == "root" {
+			if r.Module != "" {
+				return false
+			}
+		} else {
+			module := c.module
+			if !strings.HasPrefix(module, "module.") {
+				module = "module." + module
+			}
+			if r.Module != module && !strings.HasPrefix(r.Module, module+".") && !strings.HasPrefix(r.Module, module+"[") {
+				return false
+			}
+		}
+	}
+
+	if c.provider != "" {
+		// Accept the full source address, namespace/type, or just the type.
+		name := r.providerName()
+		if i := strings.LastIndex(name, "."); i > strings.LastIndex(name, "/") {
+			name = name[:i]
+		}
+		if name != c.provider && !strings.HasSuffix(name, "/"+c.provider) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *StateResourcesCommand) stateService(client *client.Client) stateVersionHistoryService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateResourcesCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the state resources command
+func (c *StateResourcesCommand) Help() string {
+	helpText := `
+Usage: hcptf state resources [options]
+
+  List the resource instances recorded in a workspace's state, like
+  terraform state list. The state file is downloaded directly, so neither
+  terraform nor backend configuration is needed.
+
+Options:
+
+  -organization=<name>  Organization name
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required unless -id is set)
+  -id=<state-version>  State version ID (default: current)
+  -serial=<n>          Use the state version with this serial
+  -at=<timestamp>      Use the state version that was current at this time
+  -type=<pattern>      Only list resources of this type, e.g. aws_subnet or aws_*
+  -module=<module>     Only list resources in this module and its children,
+                       e.g. module.vpc; use root for the root module
+  -provider=<name>     Only list resources managed by this provider, e.g. aws,
+                       hashicorp/aws, or registry.terraform.io/hashicorp/aws
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf state resources -org=my-org -workspace=prod
+  hcptf state resources -org=my-org -workspace=prod -module=vpc -type='aws_*'
+  hcptf state resources -id=sv-ABC123 -provider=google -output=json
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state resources command
+func (c *StateResourcesCommand) Synopsis() string {
+	return "List resources in a workspace's state"
+}
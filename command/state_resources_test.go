@@ -0,0 +1,135 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+const stateInspectFixture = `{
+  "version": 4,
+  "serial": 7,
+  "lineage": "lineage-a",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed", "type": "aws_instance", "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [{"schema_version": 1, "attributes": {
+        "id": "i-123", "ami": "ami-1", "instance_type": "t3.micro", "monitoring": false, "cpu_core_count": 2,
+        "password": "hunter2", "user_data": null, "tags": {"Name": "web", "team name": "platform"},
+        "security_groups": ["sg-1", "sg-2"], "ebs_block_device": [], "metadata": {}
+      }, "sensitive_attributes": [[{"type": "get_attr", "value": "password"}]]}]
+    },
+    {
+      "module": "module.vpc", "mode": "managed", "type": "aws_subnet", "name": "private",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {"index_key": 0, "schema_version": 1, "attributes": {"id": "subnet-0", "cidr_block": "10.0.0.0/24"}},
+        {"index_key": 1, "schema_version": 1, "attributes": {"id": "subnet-1", "cidr_block": "10.0.1.0/24"}}
+      ]
+    },
+    {
+      "module": "module.vpc.module.endpoints", "mode": "managed", "type": "aws_vpc_endpoint", "name": "s3",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"].west",
+      "instances": [{"schema_version": 0, "attributes": {"id": "vpce-1"}}]
+    },
+    {
+      "mode": "data", "type": "google_project", "name": "this",
+      "provider": "provider[\"registry.terraform.io/hashicorp/google\"]",
+      "instances": [{"schema_version": 0, "attributes": {"id": "projects/demo"}}]
+    }
+  ]
+}`
+
+func newStateInspectFixture() *mockStateVersionHistoryService {
+	return &mockStateVersionHistoryService{
+		versions: []*tfe.StateVersion{{ID: "sv-7", Serial: 7, DownloadURL: "https://archivist.example/sv-7"}},
+		contents: map[string][]byte{"https://archivist.example/sv-7": []byte(stateInspectFixture)},
+	}
+}
+
+func runStateResources(t *testing.T, args ...string) []map[string]string {
+	t.Helper()
+	ui := cli.NewMockUi()
+	cmd := &StateResourcesCommand{
+		Meta:         newTestMeta(ui),
+		stateSvc:     newStateInspectFixture(),
+		workspaceSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "prod"}},
+	}
+
+	args = append([]string{"-org=my-org", "-workspace=prod", "-output=json"}, args...)
+	if code := cmd.Run(args); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var rows []map[string]string
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	return rows
+}
+
+func stateResourceAddresses(rows []map[string]string) string {
+	var addrs []string
+	for _, row := range rows {
+		addrs = append(addrs, row["Address"])
+	}
+	return strings.Join(addrs, ",")
+}
+
+func TestStateResourcesListsAllInstances(t *testing.T) {
+	rows := runStateResources(t)
+
+	want := "aws_instance.web,data.google_project.this,module.vpc.aws_subnet.private[0],module.vpc.aws_subnet.private[1],module.vpc.module.endpoints.aws_vpc_endpoint.s3"
+	if got := stateResourceAddresses(rows); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if rows[4]["Provider"] != "registry.terraform.io/hashicorp/aws.west" {
+		t.Fatalf("expected aliased provider, got %q", rows[4]["Provider"])
+	}
+}
+
+func TestStateResourcesFilters(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		want string
+	}{
+		"type glob":       {[]string{"-type=aws_*"}, "aws_instance.web,module.vpc.aws_subnet.private[0],module.vpc.aws_subnet.private[1],module.vpc.module.endpoints.aws_vpc_endpoint.s3"},
+		"module children": {[]string{"-module=vpc"}, "module.vpc.aws_subnet.private[0],module.vpc.aws_subnet.private[1],module.vpc.module.endpoints.aws_vpc_endpoint.s3"},
+		"nested module":   {[]string{"-module=module.vpc.module.endpoints"}, "module.vpc.module.endpoints.aws_vpc_endpoint.s3"},
+		"root module":     {[]string{"-module=root"}, "aws_instance.web,data.google_project.this"},
+		"provider type":   {[]string{"-provider=google"}, "data.google_project.this"},
+		"provider source": {[]string{"-provider=hashicorp/aws", "-module=vpc", "-type=aws_vpc_endpoint"}, "module.vpc.module.endpoints.aws_vpc_endpoint.s3"},
+	}
+
+	for name, tc := range cases {
+		if got := stateResourceAddresses(runStateResources(t, tc.args...)); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", name, tc.want, got)
+		}
+	}
+}
+
+func TestStateResourcesRequiresWorkspaceOrID(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateResourcesCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestStateResourcesRejectsMultipleSelectors(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateResourcesCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-id=sv-7", "-serial=7"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "only one of") {
+		t.Fatalf("unexpected error %q", out)
+	}
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// StateRollbackCommand is a command to restore an earlier state version
+type StateRollbackCommand struct {
+	Meta
+	organization string
+	workspace    string
+	to           string
+	autoApprove  bool
+	stateSvc     stateVersionWriteService
+	workspaceSvc workspaceStateLocker
+}
+
+// Run executes the state rollback command
+func (c *StateRollbackCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state rollback")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.StringVar(&c.to, "to", "", "State version ID to restore (required)")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation prompt")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.to == "" {
+		c.Ui.Error("Error: -to flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+	svc := c.stateService(client)
+
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	current, currentState, err := readCurrentState(ctx, svc, ws)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+	if current == nil {
+		c.Ui.Error(fmt.Sprintf("Error: workspace '%s' has no state to roll back", ws.Name))
+		return 1
+	}
+	if current.ID == c.to {
+		c.Ui.Error(fmt.Sprintf("Error: %s is already the current state version", c.to))
+		return 1
+	}
+
+	target, err := svc.Read(ctx, c.to)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading state version: %s", err))
+		return 1
+	}
+	data, _, err := downloadStateFile(ctx, svc, target)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error downloading state file: %s", err))
+		return 1
+	}
+
+	// The restored state is written as a new version after the current one.
+	data, err = setStateSerial(data, currentState.Serial+1)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error preparing state file: %s", err))
+		return 1
+	}
+	state, err := parseStateFile(data)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error preparing state file: %s", err))
+		return 1
+	}
+
+	return writeStateVersion(ctx, &c.Meta, svc, c.workspaceService(client), &stateWrite{
+		action:       "rollback",
+		organization: c.organization,
+		workspace:    ws,
+		current:      current,
+		currentState: currentState,
+		source:       target.ID,
+		data:         data,
+		state:        state,
+	}, c.autoApprove)
+}
+
+func (c *StateRollbackCommand) stateService(client *client.Client) stateVersionWriteService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateRollbackCommand) workspaceService(client *client.Client) workspaceStateLocker {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the state rollback command
+func (c *StateRollbackCommand) Help() string {
+	helpText := `
+Usage: hcptf state rollback [options]
+
+  Restore an earlier state version of a workspace by writing its contents as
+  a new state version. The serial is set to one more than the current serial,
+  and the lineage must match the current state.
+
+  The resource changes are shown before asking for confirmation. The
+  workspace is locked while the state is written and unlocked afterwards,
+  and the stored state's MD5 is checked. If a run writes a new state before
+  the lock is taken, nothing is written; run the command again.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required)
+  -to=<state-version>  State version ID to restore (required)
+  -auto-approve        Skip confirmation prompt
+
+Example:
+
+  hcptf state list -org=my-org -workspace=prod
+  hcptf state rollback -org=my-org -workspace=prod -to=sv-ABC123
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state rollback command
+func (c *StateRollbackCommand) Synopsis() string {
+	return "Restore an earlier state version"
+}
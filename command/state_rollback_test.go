@@ -0,0 +1,90 @@
+package command
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateRollbackBumpsSerial(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	cmd := &StateRollbackCommand{Meta: newTestMeta(ui), stateSvc: svc, workspaceSvc: locker}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-to=sv-1", "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(svc.created) != 1 {
+		t.Fatalf("expected 1 state version created, got %d", len(svc.created))
+	}
+	opts := svc.created[0]
+	if *opts.Serial != 4 || *opts.Lineage != "lineage-a" {
+		t.Fatalf("expected serial 4 and lineage-a, got %d %s", *opts.Serial, *opts.Lineage)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*opts.State)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := parseStateFile(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Serial != 4 {
+		t.Fatalf("expected uploaded state to carry serial 4, got %d", state.Serial)
+	}
+	if locker.lockCalls != 1 || locker.unlockCalls != 1 {
+		t.Fatalf("expected lock and unlock once, got lock=%d unlock=%d", locker.lockCalls, locker.unlockCalls)
+	}
+}
+
+func TestStateRollbackRejectsCurrentVersion(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc, locker := newStateWriteFixture()
+	cmd := &StateRollbackCommand{Meta: newTestMeta(ui), stateSvc: svc, workspaceSvc: locker}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-to=sv-3", "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "already the current") {
+		t.Fatalf("unexpected error %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestStateRollbackRequiresTarget(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateRollbackCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-to") {
+		t.Fatalf("expected -to error, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestSetStateSerialKeepsKeyOrder(t *testing.T) {
+	in := []byte(`{"version":4,"terraform_version":"1.9.0","serial":3,"lineage":"l","outputs":{},"resources":[],"check_results":null}`)
+
+	out, err := setStateSerial(in, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{
+  "version": 4,
+  "terraform_version": "1.9.0",
+  "serial": 9,
+  "lineage": "l",
+  "outputs": {},
+  "resources": [],
+  "check_results": null
+}
+`
+	if string(out) != want {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,362 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+	"github.com/hashicorp/hcptf-cli/internal/config"
+)
+
+const defaultStateSearchConcurrency = 8
+
+// stateSearchMatch is one attribute value that matched the query.
+type stateSearchMatch struct {
+	Workspace      string `json:"workspace"`
+	WorkspaceID    string `json:"workspace_id"`
+	StateVersionID string `json:"state_version_id"`
+	Address        string `json:"address"`
+	Attribute      string `json:"attribute"`
+	Value          string `json:"value"`
+}
+
+// stateFileCache stores downloaded state files by state version ID. State
+// versions never change once written, so entries never need invalidating.
+type stateFileCache struct {
+	dir string
+}
+
+func (s *stateFileCache) path(svID string) string {
+	return filepath.Join(s.dir, "state", filepath.Base(svID)+".json")
+}
+
+func (s *stateFileCache) get(svID string) ([]byte, bool) {
+	if s == nil || svID == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(s.path(svID))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// put writes a state file readable only by the current user, since state
+// may hold secrets.
+func (s *stateFileCache) put(svID string, data []byte) error {
+	if s == nil || svID == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path(svID)), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(svID), data, 0600)
+}
+
+// StateSearchCommand is a command to find the workspace that manages a resource
+type StateSearchCommand struct {
+	Meta
+	organization string
+	projectID    string
+	query        string
+	regex        bool
+	concurrency  int
+	noCache      bool
+	format       string
+	cacheDir     string
+	workspaceSvc workspaceLister
+	stateSvc     currentStateDownloader
+}
+
+// Run executes the state search command
+func (c *StateSearchCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state search")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "project-id", "", "Only search workspaces in this project")
+	flags.StringVar(&c.query, "query", "", "Value to search for, such as an ARN, ID, or hostname (required)")
+	flags.BoolVar(&c.regex, "regex", false, "Treat -query as a regular expression")
+	flags.IntVar(&c.concurrency, "concurrency", defaultStateSearchConcurrency, "Number of workspaces to scan at once")
+	flags.BoolVar(&c.noCache, "no-cache", false, "Do not read or write the local state cache")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.query == "" {
+		c.Ui.Error("Error: -query flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.concurrency < 1 {
+		c.Ui.Error("Error: -concurrency must be at least 1")
+		return 1
+	}
+
+	match, err := newStateSearchMatcher(c.query, c.regex)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: invalid -query pattern: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	var cache *stateFileCache
+	if !c.noCache {
+		dir := c.cacheDir
+		if dir == "" {
+			dir = config.GetCacheDir()
+		}
+		if dir != "" {
+			cache = &stateFileCache{dir: dir}
+		}
+	}
+
+	matches, failures := c.scan(ctx, c.stateService(client), cache, workspaces, match)
+	for _, failure := range failures {
+		c.Ui.Warn(fmt.Sprintf("Warning: %s", failure))
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if len(matches) == 0 {
+		if c.format == "json" {
+			formatter.JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("No resources matching %q in %d workspaces", c.query, len(workspaces)))
+		return 0
+	}
+
+	if c.format == "json" {
+		formatter.JSON(matches)
+		return 0
+	}
+
+	headers := []string{"Workspace", "Address", "Attribute", "Value"}
+	var rows [][]string
+	for _, m := range matches {
+		rows = append(rows, []string{m.Workspace, m.Address, m.Attribute, m.Value})
+	}
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// scan searches the current state of each workspace, at most c.concurrency
+// at a time. Workspaces whose state cannot be read are reported as failures
+// rather than stopping the search.
+func (c *StateSearchCommand) scan(ctx context.Context, svc currentStateDownloader, cache *stateFileCache, workspaces []*tfe.Workspace, match func(string) bool) ([]*stateSearchMatch, []string) {
+	var (
+		mu       sync.Mutex
+		matches  []*stateSearchMatch
+		failures []string
+	)
+
+	var withState []*tfe.Workspace
+	for _, ws := range workspaces {
+		if ws.CurrentStateVersion != nil {
+			withState = append(withState, ws)
+		}
+	}
+
+	forEachWorkspace(withState, c.concurrency, func(ws *tfe.Workspace) {
+		svID, state, err := loadWorkspaceState(ctx, svc, cache, ws)
+		if err != nil {
+			mu.Lock()
+			failures = append(failures, fmt.Sprintf("skipping workspace %s: %s", ws.Name, err))
+			mu.Unlock()
+			return
+		}
+		if state == nil {
+			return
+		}
+
+		found := searchState(state, match)
+		for _, m := range found {
+			m.Workspace = ws.Name
+			m.WorkspaceID = ws.ID
+			m.StateVersionID = svID
+		}
+
+		mu.Lock()
+		matches = append(matches, found...)
+		mu.Unlock()
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Workspace != matches[j].Workspace {
+			return matches[i].Workspace < matches[j].Workspace
+		}
+		if matches[i].Address != matches[j].Address {
+			return matches[i].Address < matches[j].Address
+		}
+		return matches[i].Attribute < matches[j].Attribute
+	})
+	sort.Strings(failures)
+	return matches, failures
+}
+
+// loadWorkspaceState returns the workspace's current state, from the cache
+// when the current state version ID is already known and cached.
+func loadWorkspaceState(ctx context.Context, svc currentStateDownloader, cache *stateFileCache, ws *tfe.Workspace) (string, *terraformState, error) {
+	if data, ok := cache.get(ws.CurrentStateVersion.ID); ok {
+		if state, err := parseStateFile(data); err == nil {
+			return ws.CurrentStateVersion.ID, state, nil
+		}
+	}
+
+	sv, err := svc.ReadCurrent(ctx, ws.ID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("reading current state version: %w", err)
+	}
+
+	if data, ok := cache.get(sv.ID); ok {
+		if state, err := parseStateFile(data); err == nil {
+			return sv.ID, state, nil
+		}
+	}
+
+	data, state, err := downloadStateFile(ctx, svc, sv)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cache.put(sv.ID, data); err != nil {
+		return "", nil, fmt.Errorf("caching state version %s: %w", sv.ID, err)
+	}
+	return sv.ID, state, nil
+}
+
+// newStateSearchMatcher matches values case-insensitively by substring, or
+// by regular expression when regex is set.
+func newStateSearchMatcher(query string, regex bool) (func(string) bool, error) {
+	if regex {
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+
+	query = strings.ToLower(query)
+	return func(value string) bool {
+		return strings.Contains(strings.ToLower(value), query)
+	}, nil
+}
+
+// searchState returns the resource attributes whose values match. Sensitive
+// attributes are never searched so the command cannot be used to probe
+// secrets.
+func searchState(state *terraformState, match func(string) bool) []*stateSearchMatch {
+	var matches []*stateSearchMatch
+	for _, entry := range state.instances() {
+		values := map[string]interface{}{}
+		flattenStateValue("", map[string]interface{}(entry.Instance.Attributes), values)
+		sensitive := entry.Instance.sensitivePaths()
+
+		for _, path := range sortedStateKeys(values) {
+			if isSensitivePath(path, sensitive) {
+				continue
+			}
+			value, ok := rawOutputValue(values[path])
+			if !ok || value == "" || !match(value) {
+				continue
+			}
+			matches = append(matches, &stateSearchMatch{
+				Address:   entry.Address,
+				Attribute: path,
+				Value:     value,
+			})
+		}
+	}
+	return matches
+}
+
+func (c *StateSearchCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *StateSearchCommand) stateService(client *client.Client) currentStateDownloader {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+// Help returns help text for the state search command
+func (c *StateSearchCommand) Help() string {
+	helpText := `
+Usage: hcptf state search [options]
+
+  Find the workspaces and resource addresses that manage a real-world object,
+  such as an ARN, resource ID, or hostname, by searching the attribute values
+  in the current state of every workspace in an organization or project.
+
+  Values match case-insensitively by substring, or by regular expression with
+  -regex. Sensitive attributes are not searched.
+
+  State files are cached under the hcptf cache directory (HCPTF_CACHE_DIR,
+  or the user cache directory) keyed by state version ID, so repeat searches
+  only download workspaces whose state has changed. Cached files contain
+  state and are readable only by the current user.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -project-id=<id>     Only search workspaces in this project
+  -query=<value>       Value to search for (required)
+  -regex               Treat -query as a regular expression
+  -concurrency=<n>     Number of workspaces to scan at once (default: 8)
+  -no-cache            Do not read or write the local state cache
+  -output=<format>     Output format: table (default), json, or csv
+
+Example:
+
+  hcptf state search -org=my-org -query=sg-0123456789abcdef0
+  hcptf state search -org=my-org -query='arn:aws:iam::123456789012:role/deploy'
+  hcptf state search -org=my-org -query='^db-[0-9]+\.internal$' -regex
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state search command
+func (c *StateSearchCommand) Synopsis() string {
+	return "Find which workspace manages a cloud resource"
+}
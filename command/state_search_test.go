@@ -0,0 +1,241 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+// mockCurrentStateService serves each workspace's current state and tracks
+// how many reads run at once.
+type mockCurrentStateService struct {
+	mu        sync.Mutex
+	current   map[string]*tfe.StateVersion
+	contents  map[string][]byte
+	reads     int
+	downloads int
+	active    int
+	maxActive int
+}
+
+func (m *mockCurrentStateService) ReadCurrent(_ context.Context, workspaceID string) (*tfe.StateVersion, error) {
+	m.mu.Lock()
+	m.reads++
+	m.active++
+	if m.active > m.maxActive {
+		m.maxActive = m.active
+	}
+	m.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active--
+	sv, ok := m.current[workspaceID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return sv, nil
+}
+
+func (m *mockCurrentStateService) Download(_ context.Context, url string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downloads++
+	data, ok := m.contents[url]
+	if !ok {
+		return nil, fmt.Errorf("no content at %s", url)
+	}
+	return data, nil
+}
+
+func newStateSearchFixture(count int) (*mockWorkspacePagedListService, *mockCurrentStateService) {
+	states := &mockCurrentStateService{current: map[string]*tfe.StateVersion{}, contents: map[string][]byte{}}
+	var workspaces []*tfe.Workspace
+	for i := 1; i <= count; i++ {
+		wsID := fmt.Sprintf("ws-%d", i)
+		svID := fmt.Sprintf("sv-%d", i)
+		url := "https://archivist.example/" + svID
+		workspaces = append(workspaces, &tfe.Workspace{
+			ID:                  wsID,
+			Name:                fmt.Sprintf("app-%02d", i),
+			CurrentStateVersion: &tfe.StateVersion{ID: svID},
+		})
+		states.current[wsID] = &tfe.StateVersion{ID: svID, DownloadURL: url}
+		states.contents[url] = []byte(fmt.Sprintf(`{"version":4,"serial":1,"lineage":"l","resources":[
+			{"mode":"managed","type":"aws_security_group","name":"web","provider":"provider[\"registry.terraform.io/hashicorp/aws\"]",
+			 "instances":[{"schema_version":1,"attributes":{"id":"sg-%04d","arn":"arn:aws:ec2:us-east-1:123:security-group/sg-%04d","port":%d,"secret":"sg-0001"},
+			 "sensitive_attributes":[[{"type":"get_attr","value":"secret"}]]}]}]}`, i, i, 8000+i))
+	}
+	// One workspace has never been applied.
+	workspaces = append(workspaces, &tfe.Workspace{ID: "ws-empty", Name: "empty"})
+	return &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{workspaces}}, states
+}
+
+func newStateSearchCommand(ui cli.Ui, workspaces workspaceLister, states currentStateDownloader, cacheDir string) *StateSearchCommand {
+	return &StateSearchCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: workspaces,
+		stateSvc:     states,
+		cacheDir:     cacheDir,
+	}
+}
+
+func TestStateSearchFindsOwningWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(3)
+	cmd := newStateSearchCommand(ui, workspaces, states, t.TempDir())
+
+	if code := cmd.Run([]string{"-org=my-org", "-query=SG-0002", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []stateSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected arn and id matches, got %+v", matches)
+	}
+	for _, m := range matches {
+		if m.Workspace != "app-02" || m.Address != "aws_security_group.web" || m.StateVersionID != "sv-2" {
+			t.Fatalf("unexpected match %+v", m)
+		}
+	}
+	if matches[0].Attribute != "arn" || matches[1].Attribute != "id" {
+		t.Fatalf("expected arn and id attributes, got %s and %s", matches[0].Attribute, matches[1].Attribute)
+	}
+}
+
+func TestStateSearchSkipsSensitiveAttributes(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(3)
+	cmd := newStateSearchCommand(ui, workspaces, states, "")
+	cmd.noCache = true
+
+	if code := cmd.Run([]string{"-org=my-org", "-query=sg-0001", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []stateSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, m := range matches {
+		if m.Attribute == "secret" {
+			t.Fatalf("sensitive attribute matched: %+v", m)
+		}
+		if m.Workspace != "app-01" {
+			t.Fatalf("unexpected workspace %s", m.Workspace)
+		}
+	}
+}
+
+func TestStateSearchRegex(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(3)
+	cmd := newStateSearchCommand(ui, workspaces, states, t.TempDir())
+
+	if code := cmd.Run([]string{"-org=my-org", "-query=^800[13]$", "-regex", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []stateSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Workspace != "app-01" || matches[1].Workspace != "app-03" || matches[0].Attribute != "port" {
+		t.Fatalf("unexpected regex matches %+v", matches)
+	}
+}
+
+func TestStateSearchBoundsConcurrency(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(12)
+	cmd := newStateSearchCommand(ui, workspaces, states, "")
+	cmd.noCache = true
+
+	if code := cmd.Run([]string{"-org=my-org", "-query=nothing-matches", "-concurrency=3"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if states.maxActive > 3 {
+		t.Fatalf("expected at most 3 concurrent reads, got %d", states.maxActive)
+	}
+	if states.reads != 12 {
+		t.Fatalf("expected 12 reads, got %d", states.reads)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "No resources matching") {
+		t.Fatalf("unexpected output %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStateSearchUsesCache(t *testing.T) {
+	dir := t.TempDir()
+	workspaces, states := newStateSearchFixture(2)
+
+	ui := cli.NewMockUi()
+	if code := newStateSearchCommand(ui, workspaces, states, dir).Run([]string{"-org=my-org", "-query=sg-"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if states.downloads != 2 {
+		t.Fatalf("expected 2 downloads, got %d", states.downloads)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "state", "sv-1.json"))
+	if err != nil {
+		t.Fatalf("expected cached state file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected cache file mode 0600, got %v", info.Mode().Perm())
+	}
+
+	ui = cli.NewMockUi()
+	if code := newStateSearchCommand(ui, workspaces, states, dir).Run([]string{"-org=my-org", "-query=sg-"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if states.downloads != 2 || states.reads != 2 {
+		t.Fatalf("expected second search to be served from cache, got %d reads and %d downloads", states.reads, states.downloads)
+	}
+}
+
+func TestStateSearchWarnsOnUnreadableState(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, states := newStateSearchFixture(2)
+	delete(states.contents, "https://archivist.example/sv-2")
+	cmd := newStateSearchCommand(ui, workspaces, states, "")
+	cmd.noCache = true
+
+	if code := cmd.Run([]string{"-org=my-org", "-query=sg-0001"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "skipping workspace app-02") {
+		t.Fatalf("expected warning, got %q", ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "app-01") {
+		t.Fatalf("expected results from readable workspaces, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestStateSearchValidation(t *testing.T) {
+	cases := map[string][]string{
+		"missing query":   {"-org=my-org"},
+		"bad regex":       {"-org=my-org", "-query=(", "-regex"},
+		"bad concurrency": {"-org=my-org", "-query=x", "-concurrency=0"},
+	}
+	for name, args := range cases {
+		ui := cli.NewMockUi()
+		if code := newStateSearchCommand(ui, nil, nil, "").Run(args); code != 1 {
+			t.Errorf("%s: expected exit 1, got %d", name, code)
+		}
+	}
+}
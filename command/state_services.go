@@ -9,3 +9,41 @@ import (
 type stateVersionReader interface {
 	ReadCurrent(ctx context.Context, workspaceID string) (*tfe.StateVersion, error)
 }
+
+type stateVersionIDReader interface {
+	Read(ctx context.Context, svID string) (*tfe.StateVersion, error)
+}
+
+type stateVersionLister interface {
+	List(ctx context.Context, options *tfe.StateVersionListOptions) (*tfe.StateVersionList, error)
+}
+
+type stateVersionDownloader interface {
+	Download(ctx context.Context, url string) ([]byte, error)
+}
+
+type stateVersionHistoryService interface {
+	stateVersionReader
+	stateVersionIDReader
+	stateVersionLister
+	stateVersionDownloader
+}
+
+type stateVersionCreator interface {
+	Create(ctx context.Context, workspaceID string, options tfe.StateVersionCreateOptions) (*tfe.StateVersion, error)
+}
+
+type stateVersionWriteService interface {
+	stateVersionHistoryService
+	stateVersionCreator
+}
+
+type stateVersionOutputService interface {
+	ReadCurrent(ctx context.Context, workspaceID string) (*tfe.StateVersionOutputsList, error)
+	Read(ctx context.Context, outputID string) (*tfe.StateVersionOutput, error)
+}
+
+type currentStateDownloader interface {
+	stateVersionReader
+	stateVersionDownloader
+}
@@ -0,0 +1,302 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const stateShowSensitiveValue = "(sensitive value)"
+
+var hclIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// StateShowCommand is a command to show one resource instance from a state version
+type StateShowCommand struct {
+	Meta
+	organization   string
+	workspace      string
+	stateVersionID string
+	serial         int64
+	at             string
+	address        string
+	format         string
+	stateSvc       stateVersionHistoryService
+	workspaceSvc   workspaceReader
+}
+
+// Run executes the state show command
+func (c *StateShowCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("state show")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name")
+	flags.StringVar(&c.stateVersionID, "id", "", "State version ID (default: current)")
+	flags.Int64Var(&c.serial, "serial", -1, "Use the state version with this serial")
+	flags.StringVar(&c.at, "at", "", "Use the state version that was current at this time (RFC 3339)")
+	flags.StringVar(&c.address, "address", "", "Resource instance address (required)")
+	flags.StringVar(&c.format, "output", "text", "Output format: text or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.address == "" {
+		c.Ui.Error("Error: -address flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.stateVersionID == "" && (c.organization == "" || c.workspace == "") {
+		c.Ui.Error("Error: must provide either -id or both -org and -workspace")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	sel, err := newStateVersionSelector(c.stateVersionID, c.serial, c.at)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	_, state, err := loadSelectedState(client.Context(), c.stateService(client), c.workspaceService(client), c.organization, c.workspace, sel)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	instances := state.instances()
+	entry, ok := instances[c.address]
+	if !ok {
+		c.Ui.Error(fmt.Sprintf("Error: no resource instance %q in state", c.address))
+		if candidates := stateInstancesOf(instances, c.address); len(candidates) > 0 {
+			c.Ui.Error(fmt.Sprintf("The resource has multiple instances; use one of: %s", strings.Join(candidates, ", ")))
+		}
+		return 1
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"address":    entry.Address,
+			"mode":       entry.Resource.Mode,
+			"type":       entry.Resource.Type,
+			"name":       entry.Resource.Name,
+			"provider":   entry.Resource.providerName(),
+			"status":     entry.Instance.Status,
+			"attributes": maskStateValue("", entry.Instance.Attributes, entry.Instance.sensitivePaths()),
+		})
+		return 0
+	}
+
+	c.Ui.Output(renderStateShow(entry))
+	return 0
+}
+
+// stateInstancesOf returns the instance addresses of a resource address
+// given without an instance key.
+func stateInstancesOf(instances map[string]*stateInstanceEntry, address string) []string {
+	var matches []string
+	for addr, entry := range instances {
+		if entry.Resource.resourceAddress() == address {
+			matches = append(matches, addr)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// maskStateValue returns a copy of value with sensitive paths replaced.
+func maskStateValue(path string, value interface{}, sensitive []string) interface{} {
+	if path != "" && isSensitivePath(path, sensitive) {
+		return stateShowSensitiveValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			masked[key] = maskStateValue(joinStatePath(path, key), child, sensitive)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, child := range v {
+			masked[i] = maskStateValue(fmt.Sprintf("%s[%d]", path, i), child, sensitive)
+		}
+		return masked
+	}
+	return value
+}
+
+// renderStateShow renders an instance the way terraform state show does.
+// Without provider schemas nested blocks cannot be told apart from object
+// attributes, so everything is rendered as attributes and null values are
+// omitted.
+func renderStateShow(entry *stateInstanceEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s:", entry.Address)
+	if entry.Instance.Status == "tainted" {
+		b.WriteString(" (tainted)")
+	}
+	b.WriteString("\n")
+
+	keyword := "resource"
+	if entry.Resource.Mode == "data" {
+		keyword = "data"
+	}
+	fmt.Fprintf(&b, "%s %q %q {\n", keyword, entry.Resource.Type, entry.Resource.Name)
+	writeHCLObjectBody(&b, entry.Instance.Attributes, 1, "", entry.Instance.sensitivePaths(), false)
+	b.WriteString("}")
+	return b.String()
+}
+
+// writeHCLObjectBody writes key = value lines with the equals signs aligned.
+// Map keys are quoted; top-level attribute names are not.
+func writeHCLObjectBody(b *strings.Builder, object map[string]interface{}, depth int, path string, sensitive []string, quoteKeys bool) {
+	var keys []string
+	width := 0
+	labels := map[string]string{}
+	for key, value := range object {
+		if value == nil {
+			continue
+		}
+		label := key
+		if quoteKeys || !hclIdentifierPattern.MatchString(key) {
+			label = quoteHCLString(key)
+		}
+		labels[key] = label
+		if len(label) > width {
+			width = len(label)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("    ", depth)
+	for _, key := range keys {
+		fmt.Fprintf(b, "%s%-*s = ", indent, width, labels[key])
+		writeHCLValue(b, object[key], depth, joinStatePath(path, key), sensitive)
+		b.WriteString("\n")
+	}
+}
+
+func writeHCLValue(b *strings.Builder, value interface{}, depth int, path string, sensitive []string) {
+	if isSensitivePath(path, sensitive) {
+		b.WriteString(stateShowSensitiveValue)
+		return
+	}
+
+	indent := strings.Repeat("    ", depth)
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		if strings.Contains(strings.TrimSuffix(v, "\n"), "\n") {
+			b.WriteString("<<-EOT\n")
+			for _, line := range strings.Split(strings.TrimSuffix(v, "\n"), "\n") {
+				line = strings.ReplaceAll(line, "${", "$${")
+				b.WriteString(indent + "    " + strings.ReplaceAll(line, "%{", "%%{") + "\n")
+			}
+			b.WriteString(indent + "EOT")
+			return
+		}
+		b.WriteString(quoteHCLString(v))
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		writeHCLObjectBody(b, v, depth+1, path, sensitive, true)
+		b.WriteString(indent + "}")
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, child := range v {
+			b.WriteString(indent + "    ")
+			writeHCLValue(b, child, depth+1, fmt.Sprintf("%s[%d]", path, i), sensitive)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "]")
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		fmt.Fprintf(b, "%v", v)
+	}
+}
+
+// quoteHCLString quotes a string and escapes template sequences.
+func quoteHCLString(s string) string {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+	quoted := strings.TrimSuffix(buf.String(), "\n")
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+func (c *StateShowCommand) stateService(client *client.Client) stateVersionHistoryService {
+	if c.stateSvc != nil {
+		return c.stateSvc
+	}
+	return client.StateVersions
+}
+
+func (c *StateShowCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the state show command
+func (c *StateShowCommand) Help() string {
+	helpText := `
+Usage: hcptf state show [options]
+
+  Show the attributes of one resource instance in a workspace's state, in the
+  same layout as terraform state show. Sensitive attributes are masked. The
+  state file is downloaded directly, so neither terraform nor backend
+  configuration is needed.
+
+Options:
+
+  -organization=<name>  Organization name
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required unless -id is set)
+  -address=<address>   Resource instance address (required), e.g.
+                       module.vpc.aws_subnet.private[0]
+  -id=<state-version>  State version ID (default: current)
+  -serial=<n>          Use the state version with this serial
+  -at=<timestamp>      Use the state version that was current at this time
+  -output=<format>     Output format: text (default) or json
+
+Example:
+
+  hcptf state show -org=my-org -workspace=prod -address=aws_instance.web
+  hcptf state show -org=my-org -workspace=prod \
+    -address='module.vpc.aws_subnet.private[0]' -serial=41
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the state show command
+func (c *StateShowCommand) Synopsis() string {
+	return "Show a resource instance from a workspace's state"
+}
@@ -0,0 +1,150 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateShowRendersLikeTerraform(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateShowCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-id=sv-7", "-address=aws_instance.web"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	want := `# aws_instance.web:
+resource "aws_instance" "web" {
+    ami              = "ami-1"
+    cpu_core_count   = 2
+    ebs_block_device = []
+    id               = "i-123"
+    instance_type    = "t3.micro"
+    metadata         = {}
+    monitoring       = false
+    password         = (sensitive value)
+    security_groups  = [
+        "sg-1",
+        "sg-2",
+    ]
+    tags             = {
+        "Name"      = "web"
+        "team name" = "platform"
+    }
+}
+`
+	if got := ui.OutputWriter.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStateShowModuleInstance(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateShowCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-id=sv-7", "-address=module.vpc.aws_subnet.private[1]"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	out := ui.OutputWriter.String()
+	if !strings.HasPrefix(out, "# module.vpc.aws_subnet.private[1]:\nresource \"aws_subnet\" \"private\" {") {
+		t.Fatalf("unexpected header:\n%s", out)
+	}
+	if !strings.Contains(out, `cidr_block = "10.0.1.0/24"`) {
+		t.Fatalf("expected instance attributes:\n%s", out)
+	}
+}
+
+func TestStateShowSuggestsInstances(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateShowCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-id=sv-7", "-address=module.vpc.aws_subnet.private"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "module.vpc.aws_subnet.private[0], module.vpc.aws_subnet.private[1]") {
+		t.Fatalf("expected instance suggestions, got %q", out)
+	}
+}
+
+func TestStateShowJSONMasksSensitive(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateShowCommand{Meta: newTestMeta(ui), stateSvc: newStateInspectFixture()}
+
+	if code := cmd.Run([]string{"-id=sv-7", "-address=aws_instance.web", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result struct {
+		Address    string                 `json:"address"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Attributes["password"] != stateShowSensitiveValue {
+		t.Fatalf("expected masked password, got %v", result.Attributes["password"])
+	}
+}
+
+func TestRenderHCLStrings(t *testing.T) {
+	entry := &stateInstanceEntry{
+		Address:  "data.template_file.x",
+		Resource: &stateResource{Mode: "data", Type: "template_file", Name: "x"},
+		Instance: &stateInstance{Attributes: map[string]interface{}{
+			"rendered": "line one\nline two\n",
+			"template": "${var.name} <b>",
+		}},
+	}
+
+	want := `# data.template_file.x:
+data "template_file" "x" {
+    rendered = <<-EOT
+        line one
+        line two
+    EOT
+    template = "$${var.name} <b>"
+}`
+	if got := renderStateShow(entry); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderHCLHeredocTemplatesAndNumbers(t *testing.T) {
+	entry := &stateInstanceEntry{
+		Address:  "aws_instance.web",
+		Resource: &stateResource{Mode: "managed", Type: "aws_instance", Name: "web"},
+		Instance: &stateInstance{Attributes: map[string]interface{}{
+			"user_data": "echo ${HOME}\n%{ if x }y%{ endif }\n",
+			"volume":    float64(1000000),
+			"weight":    0.25,
+		}},
+	}
+
+	want := `# aws_instance.web:
+resource "aws_instance" "web" {
+    user_data = <<-EOT
+        echo $${HOME}
+        %%{ if x }y%%{ endif }
+    EOT
+    volume    = 1000000
+    weight    = 0.25
+}`
+	if got := renderStateShow(entry); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStateShowRequiresAddress(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &StateShowCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-id=sv-7"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-address") {
+		t.Fatalf("unexpected error %q", out)
+	}
+}
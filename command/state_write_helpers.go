@@ -0,0 +1,305 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// stateWrite is a state file about to be written as a new state version of a
+// workspace, along with the version it replaces.
+type stateWrite struct {
+	action       string
+	organization string
+	workspace    *tfe.Workspace
+	current      *tfe.StateVersion
+	currentState *terraformState
+	source       string
+	data         []byte
+	state        *terraformState
+	force        bool
+}
+
+// readCurrentState returns the workspace's current state version and its
+// parsed contents, or nils when the workspace has no state yet.
+func readCurrentState(ctx context.Context, svc stateVersionHistoryService, ws *tfe.Workspace) (*tfe.StateVersion, *terraformState, error) {
+	if ws.CurrentStateVersion == nil {
+		return nil, nil, nil
+	}
+	return readCurrentStateVersion(ctx, svc, ws.ID)
+}
+
+// readCurrentStateVersion is readCurrentState without the shortcut for
+// workspaces that had no state when they were read.
+func readCurrentStateVersion(ctx context.Context, svc stateVersionHistoryService, workspaceID string) (*tfe.StateVersion, *terraformState, error) {
+	current, err := svc.ReadCurrent(ctx, workspaceID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading current state version: %w", err)
+	}
+
+	_, state, err := downloadStateFile(ctx, svc, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, state, nil
+}
+
+// check verifies the new state continues the current one: same lineage and
+// a higher serial. force skips both checks.
+func (w *stateWrite) check() error {
+	if w.state.Lineage == "" {
+		return fmt.Errorf("state has no lineage")
+	}
+	if w.currentState == nil || w.force {
+		return nil
+	}
+	if w.state.Lineage != w.currentState.Lineage {
+		return fmt.Errorf("lineage %q does not match the current state's lineage %q", w.state.Lineage, w.currentState.Lineage)
+	}
+	if w.state.Serial <= w.currentState.Serial {
+		return fmt.Errorf("serial %d must be greater than the current serial %d", w.state.Serial, w.currentState.Serial)
+	}
+	return nil
+}
+
+func (w *stateWrite) md5() string {
+	return fmt.Sprintf("%x", md5.Sum(w.data))
+}
+
+// diff compares the current state with the one being written.
+func (w *stateWrite) diff() *stateDiff {
+	current := w.currentState
+	if current == nil {
+		current = &terraformState{}
+	}
+	diff := diffStates(current, w.state)
+	if w.current != nil {
+		diff.From = stateDiffSide{ID: w.current.ID, Serial: current.Serial, Lineage: current.Lineage}
+	} else {
+		diff.From = stateDiffSide{ID: "(none)"}
+	}
+	diff.To = stateDiffSide{ID: w.source, Serial: w.state.Serial, Lineage: w.state.Lineage}
+	return diff
+}
+
+// writeStateVersion shows the resource diff, asks for confirmation, and then
+// creates the new state version while holding the workspace lock. Once the
+// lock is held the current state is read again, since a run may have written
+// a new version in the meantime. After the write the stored state is
+// downloaded again and its MD5 compared. It returns the exit code for the
+// calling command.
+func writeStateVersion(ctx context.Context, m *Meta, svc stateVersionWriteService, lockSvc workspaceStateLocker, w *stateWrite, autoApprove bool) int {
+	ws := w.workspace
+	if err := w.check(); err != nil {
+		m.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	diff := w.diff()
+	checksum := w.md5()
+
+	if m.DryRun {
+		m.NewFormatter("json").JSON(map[string]interface{}{
+			"action":    w.action,
+			"resource":  "state-version",
+			"workspace": ws.Name,
+			"serial":    w.state.Serial,
+			"lineage":   w.state.Lineage,
+			"md5":       checksum,
+			"diff":      diff,
+		})
+		return 0
+	}
+
+	if ws.Locked {
+		m.Ui.Error(fmt.Sprintf("Error: workspace '%s' is locked; unlock it before writing state", ws.Name))
+		return 1
+	}
+
+	renderStateDiffTable(m, diff)
+
+	if ok, err := w.confirm(m, autoApprove); err != nil {
+		m.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	} else if !ok {
+		return 0
+	}
+
+	if _, err := lockSvc.Lock(ctx, ws.ID, tfe.WorkspaceLockOptions{
+		Reason: tfe.String(fmt.Sprintf("hcptf state %s", w.action)),
+	}); err != nil {
+		m.Ui.Error(fmt.Sprintf("Error locking workspace: %s", err))
+		return 1
+	}
+
+	created, createErr := w.createLocked(ctx, m, svc, autoApprove)
+
+	if _, err := lockSvc.Unlock(ctx, ws.ID); err != nil {
+		m.Ui.Error(fmt.Sprintf("Error unlocking workspace: %s", err))
+		m.Ui.Error(fmt.Sprintf("Run 'hcptf workspace unlock -org=%s -name=%s' to release the lock.", w.organization, ws.Name))
+		if createErr == nil {
+			m.Ui.Error(fmt.Sprintf("State version %s was created.", created.ID))
+		}
+		return 1
+	}
+
+	if errors.Is(createErr, errStateWriteCancelled) {
+		return 0
+	}
+	if createErr != nil {
+		m.Ui.Error(fmt.Sprintf("Error: %s", createErr))
+		return 1
+	}
+
+	if created.DownloadURL == "" {
+		m.Ui.Warn("Warning: the new state version has no download URL yet; MD5 was not verified")
+	} else {
+		stored, err := svc.Download(ctx, created.DownloadURL)
+		if err != nil {
+			m.Ui.Error(fmt.Sprintf("Error verifying state version %s: %s", created.ID, err))
+			return 1
+		}
+		if got := fmt.Sprintf("%x", md5.Sum(stored)); got != checksum {
+			m.Ui.Error(fmt.Sprintf("Error: stored state MD5 %s does not match %s", got, checksum))
+			return 1
+		}
+	}
+
+	m.Ui.Output(fmt.Sprintf("Created state version %s (serial %d) in workspace '%s'", created.ID, w.state.Serial, ws.Name))
+	return 0
+}
+
+// errStateWriteCancelled is returned by createLocked when the user declines
+// to write over a state that changed since it was first read.
+var errStateWriteCancelled = errors.New("state write cancelled")
+
+// confirm asks the user to confirm the write unless autoApprove is set. It
+// reports whether to go ahead.
+func (w *stateWrite) confirm(m *Meta, autoApprove bool) (bool, error) {
+	if autoApprove {
+		return true, nil
+	}
+
+	m.Ui.Output("")
+	m.Ui.Output(fmt.Sprintf("This will write serial %d as the current state of workspace '%s'.", w.state.Serial, w.workspace.Name))
+	m.Ui.Output("Only 'yes' will be accepted to confirm.")
+	m.Ui.Output("")
+
+	response, err := m.Ui.Ask("Enter a value: ")
+	if err != nil {
+		return false, fmt.Errorf("reading input: %w", err)
+	}
+	if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+		m.Ui.Output("State write cancelled.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// createLocked creates the new state version. The caller holds the
+// workspace lock, so the current state read here is the one the new version
+// replaces. If it changed since it was first read the checks run again and
+// the new diff is shown and confirmed before anything is written.
+func (w *stateWrite) createLocked(ctx context.Context, m *Meta, svc stateVersionWriteService, autoApprove bool) (*tfe.StateVersion, error) {
+	current, currentState, err := readCurrentStateVersion(ctx, svc, w.workspace.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateVersionID(current) != stateVersionID(w.current) {
+		m.Ui.Warn(fmt.Sprintf("Warning: the current state of workspace '%s' changed from %s to %s since it was read", w.workspace.Name, stateVersionID(w.current), stateVersionID(current)))
+		w.current, w.currentState = current, currentState
+		if err := w.check(); err != nil {
+			return nil, err
+		}
+		renderStateDiffTable(m, w.diff())
+		if ok, err := w.confirm(m, autoApprove); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errStateWriteCancelled
+		}
+	}
+
+	created, err := svc.Create(ctx, w.workspace.ID, tfe.StateVersionCreateOptions{
+		Lineage: tfe.String(w.state.Lineage),
+		MD5:     tfe.String(w.md5()),
+		Serial:  tfe.Int64(w.state.Serial),
+		State:   tfe.String(base64.StdEncoding.EncodeToString(w.data)),
+		Force:   tfe.Bool(w.force),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating state version: %w", err)
+	}
+	return created, nil
+}
+
+// stateVersionID returns a state version's ID, or "(none)" for a workspace
+// without state.
+func stateVersionID(sv *tfe.StateVersion) string {
+	if sv == nil {
+		return "(none)"
+	}
+	return sv.ID
+}
+
+// stateTopLevelKeys is the order Terraform writes the top-level keys of a
+// state file in.
+var stateTopLevelKeys = []string{"version", "terraform_version", "serial", "lineage", "outputs", "resources", "check_results"}
+
+// setStateSerial returns the state file with its serial replaced, keeping
+// Terraform's key order and indentation.
+func setStateSerial(data []byte, serial int64) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("state is not valid JSON: %w", err)
+	}
+	fields["serial"] = json.RawMessage(fmt.Sprintf("%d", serial))
+
+	keys := make([]string, 0, len(fields))
+	known := map[string]bool{}
+	for _, key := range stateTopLevelKeys {
+		known[key] = true
+		if _, ok := fields[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	var extra []string
+	for key := range fields {
+		if !known[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	keys = append(keys, extra...)
+
+	var compact bytes.Buffer
+	compact.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			compact.WriteString(",")
+		}
+		name, _ := json.Marshal(key)
+		compact.Write(name)
+		compact.WriteString(":")
+		compact.Write(fields[key])
+	}
+	compact.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
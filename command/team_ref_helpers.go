@@ -0,0 +1,43 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// resolveTeamRef reads a team given either its name or its ID. Names are
+// looked up with a filtered list, since the teams API reads only by ID.
+func resolveTeamRef(ctx context.Context, svc teamRefReader, organization, ref string) (*tfe.Team, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("empty team reference")
+	}
+
+	if strings.HasPrefix(ref, "team-") {
+		team, err := svc.Read(ctx, ref)
+		if err == nil {
+			return team, nil
+		}
+		if organization == "" {
+			return nil, fmt.Errorf("team %q: %w", ref, err)
+		}
+	}
+
+	if organization == "" {
+		return nil, fmt.Errorf("team %q: -organization is required to look up teams by name", ref)
+	}
+
+	teams, err := svc.List(ctx, organization, &tfe.TeamListOptions{Names: []string{ref}})
+	if err != nil {
+		return nil, fmt.Errorf("team %q: %w", ref, err)
+	}
+	for _, team := range teams.Items {
+		if team.Name == ref {
+			return team, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q not found in organization %s", ref, organization)
+}
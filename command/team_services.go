@@ -13,3 +13,27 @@ type teamLister interface {
 type teamReader interface {
 	Read(ctx context.Context, teamName string) (*tfe.Team, error)
 }
+
+type teamRefReader interface {
+	teamLister
+	teamReader
+}
+
+type teamCreator interface {
+	Create(ctx context.Context, organization string, options tfe.TeamCreateOptions) (*tfe.Team, error)
+}
+
+type teamUpdater interface {
+	Update(ctx context.Context, teamID string, options tfe.TeamUpdateOptions) (*tfe.Team, error)
+}
+
+type teamSyncService interface {
+	teamLister
+	teamCreator
+	teamUpdater
+}
+
+type teamMemberManager interface {
+	Add(ctx context.Context, teamID string, options tfe.TeamMemberAddOptions) error
+	Remove(ctx context.Context, teamID string, options tfe.TeamMemberRemoveOptions) error
+}
@@ -0,0 +1,678 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	teamSyncCreateTeam   = "create-team"
+	teamSyncUpdateTeam   = "update-team"
+	teamSyncInvite       = "invite"
+	teamSyncAddMember    = "add-member"
+	teamSyncRemoveMember = "remove-member"
+)
+
+// teamSyncActionOrder is the order changes are planned and applied in:
+// teams must exist before users are invited to them or added to them.
+var teamSyncActionOrder = map[string]int{
+	teamSyncCreateTeam:   0,
+	teamSyncUpdateTeam:   1,
+	teamSyncInvite:       2,
+	teamSyncAddMember:    3,
+	teamSyncRemoveMember: 4,
+}
+
+// teamSyncFile is the decoded form of a team sync file, YAML or JSON.
+type teamSyncFile struct {
+	Teams []*teamSyncFileTeam `yaml:"teams" json:"teams"`
+}
+
+// teamSyncFileTeam is one declared team. Organization access and members
+// that are left out are not reconciled.
+type teamSyncFileTeam struct {
+	Name               string    `yaml:"name" json:"name"`
+	Visibility         string    `yaml:"visibility" json:"visibility"`
+	OrganizationAccess *[]string `yaml:"organization_access" json:"organization_access"`
+	Members            *[]string `yaml:"members" json:"members"`
+}
+
+// teamSyncChange is one planned change to a team or its members.
+type teamSyncChange struct {
+	Action string `json:"action"`
+	Team   string `json:"team"`
+	TeamID string `json:"team_id,omitempty"`
+	Member string `json:"member,omitempty"`
+	Detail string `json:"detail,omitempty"`
+
+	// What the change needs to be applied.
+	teams        []string
+	visibility   string
+	access       *tfe.OrganizationAccessOptions
+	username     string
+	membershipID string
+}
+
+// loadTeamSyncFile reads and validates a team sync file.
+func loadTeamSyncFile(filename string) (*teamSyncFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var file teamSyncFile
+	if filepath.Ext(filename) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&file); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, team := range file.Teams {
+		if team == nil || strings.TrimSpace(team.Name) == "" {
+			return nil, fmt.Errorf("team %d: name is required", i+1)
+		}
+		if seen[team.Name] {
+			return nil, fmt.Errorf("team %q is defined more than once", team.Name)
+		}
+		seen[team.Name] = true
+
+		if team.Visibility != "" && team.Visibility != "secret" && team.Visibility != "organization" {
+			return nil, fmt.Errorf("team %q: visibility must be 'secret' or 'organization'", team.Name)
+		}
+		if team.OrganizationAccess != nil {
+			if team.Name == "owners" {
+				return nil, fmt.Errorf("team %q: the owners team's organization access cannot be changed", team.Name)
+			}
+			if _, err := organizationAccessOptions(*team.OrganizationAccess); err != nil {
+				return nil, fmt.Errorf("team %q: %w", team.Name, err)
+			}
+		}
+		if team.Members != nil {
+			for _, member := range *team.Members {
+				if strings.TrimSpace(member) == "" {
+					return nil, fmt.Errorf("team %q: empty member", team.Name)
+				}
+			}
+		}
+	}
+	return &file, nil
+}
+
+// organizationPermissionImplies lists the organization permissions that
+// grant others. The API reports implied permissions as set, so they are
+// added to both sides before declared and current access are compared.
+var organizationPermissionImplies = map[string][]string{
+	"manage-workspaces": {"read-workspaces"},
+	"manage-projects":   {"read-projects", "read-workspaces"},
+}
+
+// expandOrganizationPermissions returns the permission names with the ones
+// they imply added, sorted and without duplicates.
+func expandOrganizationPermissions(names []string) []string {
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		seen[name] = true
+		for _, implied := range organizationPermissionImplies[name] {
+			seen[implied] = true
+		}
+	}
+	expanded := make([]string, 0, len(seen))
+	for name := range seen {
+		expanded = append(expanded, name)
+	}
+	sort.Strings(expanded)
+	return expanded
+}
+
+// organizationAccessOptions converts organization permission names, as
+// listed by organizationPermissionNames, to options that set every
+// permission: the named ones on and the rest off. Permissions implied by a
+// named one are left unset rather than turned off.
+func organizationAccessOptions(names []string) (*tfe.OrganizationAccessOptions, error) {
+	options := &tfe.OrganizationAccessOptions{}
+	fields := map[string]**bool{
+		"manage-policies":            &options.ManagePolicies,
+		"manage-policy-overrides":    &options.ManagePolicyOverrides,
+		"manage-workspaces":          &options.ManageWorkspaces,
+		"manage-vcs-settings":        &options.ManageVCSSettings,
+		"manage-providers":           &options.ManageProviders,
+		"manage-modules":             &options.ManageModules,
+		"manage-run-tasks":           &options.ManageRunTasks,
+		"manage-projects":            &options.ManageProjects,
+		"read-workspaces":            &options.ReadWorkspaces,
+		"read-projects":              &options.ReadProjects,
+		"manage-membership":          &options.ManageMembership,
+		"manage-teams":               &options.ManageTeams,
+		"manage-organization-access": &options.ManageOrganizationAccess,
+		"access-secret-teams":        &options.AccessSecretTeams,
+		"manage-agent-pools":         &options.ManageAgentPools,
+	}
+	for _, field := range fields {
+		*field = tfe.Bool(false)
+	}
+
+	for _, name := range names {
+		field, ok := fields[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown organization permission %q", name)
+		}
+		*field = tfe.Bool(true)
+	}
+	for _, name := range names {
+		for _, implied := range organizationPermissionImplies[strings.TrimSpace(name)] {
+			if field := fields[implied]; *field != nil && !**field {
+				*field = nil
+			}
+		}
+	}
+	return options, nil
+}
+
+// findMembership returns the organization membership for a username or
+// email address.
+func findMembership(memberships []*tfe.OrganizationMembership, ref string) *tfe.OrganizationMembership {
+	for _, m := range memberships {
+		if m.User != nil && m.User.Username != "" && m.User.Username == ref {
+			return m
+		}
+		if m.Email != "" && strings.EqualFold(m.Email, ref) {
+			return m
+		}
+	}
+	return nil
+}
+
+// membershipName returns the username of a membership, or its email while
+// the invitation is pending.
+func membershipName(m *tfe.OrganizationMembership) string {
+	if m.User != nil && m.User.Username != "" {
+		return m.User.Username
+	}
+	return m.Email
+}
+
+// planTeamSync compares the declared teams with the organization's teams
+// and memberships. Users listed by email who are not yet in the
+// organization are invited straight onto their teams. Extra members are
+// only removed with prune.
+func planTeamSync(file *teamSyncFile, teams []*tfe.Team, memberships []*tfe.OrganizationMembership, prune bool) ([]*teamSyncChange, error) {
+	byName := map[string]*tfe.Team{}
+	for _, team := range teams {
+		byName[team.Name] = team
+	}
+	current := map[string][]*tfe.OrganizationMembership{}
+	for _, m := range memberships {
+		for _, team := range m.Teams {
+			current[team.ID] = append(current[team.ID], m)
+		}
+	}
+
+	var changes []*teamSyncChange
+	invites := map[string]*teamSyncChange{}
+	for _, want := range file.Teams {
+		team := byName[want.Name]
+		var access *tfe.OrganizationAccessOptions
+		if want.OrganizationAccess != nil {
+			access, _ = organizationAccessOptions(*want.OrganizationAccess)
+		}
+
+		if team == nil {
+			detail := "visibility " + teamSyncVisibility(want.Visibility)
+			if want.OrganizationAccess != nil && len(*want.OrganizationAccess) > 0 {
+				detail += "; organization access: " + strings.Join(sortedNames(*want.OrganizationAccess), ", ")
+			}
+			changes = append(changes, &teamSyncChange{
+				Action:     teamSyncCreateTeam,
+				Team:       want.Name,
+				Detail:     detail,
+				visibility: teamSyncVisibility(want.Visibility),
+				access:     access,
+			})
+		} else {
+			var details []string
+			change := &teamSyncChange{Action: teamSyncUpdateTeam, Team: team.Name, TeamID: team.ID}
+			if want.Visibility != "" && want.Visibility != team.Visibility {
+				details = append(details, fmt.Sprintf("visibility %s -> %s", team.Visibility, want.Visibility))
+				change.visibility = want.Visibility
+			}
+			if want.OrganizationAccess != nil {
+				have := sortedNames(organizationPermissionNames(team))
+				wantNames := sortedNames(*want.OrganizationAccess)
+				if strings.Join(expandOrganizationPermissions(have), ",") != strings.Join(expandOrganizationPermissions(wantNames), ",") {
+					details = append(details, fmt.Sprintf("organization access: %s -> %s", teamSyncNames(have), teamSyncNames(wantNames)))
+					change.access = access
+				}
+			}
+			if len(details) > 0 {
+				change.Detail = strings.Join(details, "; ")
+				changes = append(changes, change)
+			}
+		}
+
+		if want.Members == nil {
+			continue
+		}
+
+		var teamID string
+		if team != nil {
+			teamID = team.ID
+		}
+		onTeam := map[string]bool{}
+		for _, m := range current[teamID] {
+			onTeam[m.ID] = true
+		}
+
+		keep := map[string]bool{}
+		for _, ref := range *want.Members {
+			ref = strings.TrimSpace(ref)
+			m := findMembership(memberships, ref)
+			if m == nil {
+				if !strings.Contains(ref, "@") {
+					return nil, fmt.Errorf("team %q: %s is not a member of the organization; list them by email to invite them", want.Name, ref)
+				}
+				email := strings.ToLower(ref)
+				invite := invites[email]
+				if invite == nil {
+					invite = &teamSyncChange{Action: teamSyncInvite, Member: ref}
+					invites[email] = invite
+					changes = append(changes, invite)
+				}
+				invite.teams = append(invite.teams, want.Name)
+				invite.Team = strings.Join(invite.teams, ", ")
+				continue
+			}
+			if keep[m.ID] {
+				continue
+			}
+			keep[m.ID] = true
+			if onTeam[m.ID] {
+				continue
+			}
+
+			change := &teamSyncChange{Action: teamSyncAddMember, Team: want.Name, TeamID: teamID, Member: membershipName(m)}
+			if m.Status == tfe.OrganizationMembershipActive && m.User != nil && m.User.Username != "" {
+				change.username = m.User.Username
+			} else {
+				change.membershipID = m.ID
+				change.Detail = "invitation pending"
+			}
+			changes = append(changes, change)
+		}
+
+		if prune {
+			for _, m := range current[teamID] {
+				if keep[m.ID] {
+					continue
+				}
+				change := &teamSyncChange{Action: teamSyncRemoveMember, Team: want.Name, TeamID: teamID, Member: membershipName(m)}
+				if m.Status == tfe.OrganizationMembershipActive && m.User != nil && m.User.Username != "" {
+					change.username = m.User.Username
+				} else {
+					change.membershipID = m.ID
+					change.Detail = "invitation pending"
+				}
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return teamSyncActionOrder[changes[i].Action] < teamSyncActionOrder[changes[j].Action]
+	})
+	return changes, nil
+}
+
+func teamSyncVisibility(visibility string) string {
+	if visibility == "" {
+		return "secret"
+	}
+	return visibility
+}
+
+func teamSyncNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// sortedNames returns a trimmed, sorted copy of the names.
+func sortedNames(names []string) []string {
+	sorted := make([]string, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, strings.TrimSpace(name))
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// TeamSyncCommand is a command to make teams and their members match a file
+type TeamSyncCommand struct {
+	Meta
+	organization  string
+	file          string
+	prune         bool
+	autoApprove   bool
+	format        string
+	teamSvc       teamSyncService
+	teamMemberSvc teamMemberManager
+	membershipSvc organizationMembershipInviter
+}
+
+// Run executes the team sync command
+func (c *TeamSyncCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("team sync")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.file, "file", "", "Team file, YAML or JSON (required)")
+	flags.BoolVar(&c.prune, "prune", false, "Remove team members that are not in the file")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Error("Error: -file flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	file, err := loadTeamSyncFile(c.file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading %s: %s", c.file, err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	teams, err := listAllTeams(ctx, c.teamService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing teams: %s", err))
+		return 1
+	}
+
+	memberships, err := listAllOrganizationMemberships(ctx, c.membershipService(client), c.organization, &tfe.OrganizationMembershipListOptions{
+		Include: []tfe.OrgMembershipIncludeOpt{tfe.OrgMembershipUser, tfe.OrgMembershipTeam},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing organization memberships: %s", err))
+		return 1
+	}
+
+	changes, err := planTeamSync(file, teams, memberships, c.prune)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+	if changes == nil {
+		changes = []*teamSyncChange{}
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":       "sync",
+			"resource":     "team",
+			"organization": c.organization,
+			"changes":      changes,
+		})
+		return 0
+	}
+
+	if len(changes) == 0 {
+		if c.format == "json" {
+			c.Meta.NewFormatter("json").JSON(changes)
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("Teams in '%s' already match %s", c.organization, c.file))
+		return 0
+	}
+
+	if !c.autoApprove {
+		c.renderPlan(changes)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Do you want to apply these changes to teams in '%s'?", c.organization))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Sync cancelled.")
+			return 0
+		}
+	}
+
+	teamIDs := map[string]string{}
+	for _, team := range teams {
+		teamIDs[team.Name] = team.ID
+	}
+	for _, change := range changes {
+		if err := c.apply(ctx, client, change, teamIDs); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: failed to %s %s: %s", change.Action, change.describe(), err))
+			return 1
+		}
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(changes)
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	c.Ui.Output(fmt.Sprintf("Synced teams in '%s': %d created, %d updated; %d users invited, %d members added, %d removed",
+		c.organization, counts[teamSyncCreateTeam], counts[teamSyncUpdateTeam],
+		counts[teamSyncInvite], counts[teamSyncAddMember], counts[teamSyncRemoveMember]))
+	return 0
+}
+
+// apply makes one planned change. Teams created earlier in the sync are
+// recorded in teamIDs so later invitations and members can refer to them.
+func (c *TeamSyncCommand) apply(ctx context.Context, client *client.Client, change *teamSyncChange, teamIDs map[string]string) error {
+	teamID := change.TeamID
+	if teamID == "" {
+		teamID = teamIDs[change.Team]
+	}
+
+	switch change.Action {
+	case teamSyncCreateTeam:
+		team, err := c.teamService(client).Create(ctx, c.organization, tfe.TeamCreateOptions{
+			Name:               tfe.String(change.Team),
+			Visibility:         tfe.String(change.visibility),
+			OrganizationAccess: change.access,
+		})
+		if err != nil {
+			return err
+		}
+		teamIDs[change.Team] = team.ID
+		change.TeamID = team.ID
+		return nil
+	case teamSyncUpdateTeam:
+		options := tfe.TeamUpdateOptions{OrganizationAccess: change.access}
+		if change.visibility != "" {
+			options.Visibility = tfe.String(change.visibility)
+		}
+		_, err := c.teamService(client).Update(ctx, teamID, options)
+		return err
+	case teamSyncInvite:
+		teams := make([]*tfe.Team, 0, len(change.teams))
+		for _, name := range change.teams {
+			teams = append(teams, &tfe.Team{ID: teamIDs[name]})
+		}
+		_, err := c.membershipService(client).Create(ctx, c.organization, tfe.OrganizationMembershipCreateOptions{
+			Email: tfe.String(change.Member),
+			Teams: teams,
+		})
+		return err
+	case teamSyncAddMember:
+		change.TeamID = teamID
+		options := tfe.TeamMemberAddOptions{}
+		if change.username != "" {
+			options.Usernames = []string{change.username}
+		} else {
+			options.OrganizationMembershipIDs = []string{change.membershipID}
+		}
+		return c.teamMemberService(client).Add(ctx, teamID, options)
+	case teamSyncRemoveMember:
+		options := tfe.TeamMemberRemoveOptions{}
+		if change.username != "" {
+			options.Usernames = []string{change.username}
+		} else {
+			options.OrganizationMembershipIDs = []string{change.membershipID}
+		}
+		return c.teamMemberService(client).Remove(ctx, teamID, options)
+	}
+	return fmt.Errorf("unknown action %q", change.Action)
+}
+
+// describe names what the change applies to, for error messages.
+func (change *teamSyncChange) describe() string {
+	if change.Member == "" {
+		return fmt.Sprintf("team %q", change.Team)
+	}
+	return fmt.Sprintf("%s (team %s)", change.Member, change.Team)
+}
+
+// renderPlan prints the planned changes and a summary line.
+func (c *TeamSyncCommand) renderPlan(changes []*teamSyncChange) {
+	counts := map[string]int{}
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		counts[change.Action]++
+		member, detail := change.Member, change.Detail
+		if member == "" {
+			member = "-"
+		}
+		if detail == "" {
+			detail = "-"
+		}
+		rows = append(rows, []string{change.Action, change.Team, member, detail})
+	}
+	c.Meta.NewFormatter("table").Table([]string{"Action", "Team", "Member", "Detail"}, rows)
+	c.Ui.Output("")
+	c.Ui.Output(fmt.Sprintf("Plan: %d teams to create, %d to update; %d users to invite, %d members to add, %d to remove.",
+		counts[teamSyncCreateTeam], counts[teamSyncUpdateTeam],
+		counts[teamSyncInvite], counts[teamSyncAddMember], counts[teamSyncRemoveMember]))
+}
+
+func (c *TeamSyncCommand) teamService(client *client.Client) teamSyncService {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *TeamSyncCommand) teamMemberService(client *client.Client) teamMemberManager {
+	if c.teamMemberSvc != nil {
+		return c.teamMemberSvc
+	}
+	return client.TeamMembers
+}
+
+func (c *TeamSyncCommand) membershipService(client *client.Client) organizationMembershipInviter {
+	if c.membershipSvc != nil {
+		return c.membershipSvc
+	}
+	return client.OrganizationMemberships
+}
+
+// Help returns help text for the team sync command
+func (c *TeamSyncCommand) Help() string {
+	helpText := `
+Usage: hcptf team sync [options]
+
+  Make teams, their organization access, and their members match a YAML
+  or JSON file. Use this in place of SCIM provisioning.
+
+  The planned changes are shown and must be confirmed before anything is
+  changed; use -dry-run to print the plan as JSON without applying it.
+
+  File format (YAML; the same structure is accepted as JSON):
+
+    teams:
+      - name: platform
+        visibility: organization        # optional: secret (default) or organization
+        organization_access:            # optional: omit to leave unchanged
+          - manage-workspaces
+          - read-projects
+        members:                        # optional: omit to leave unchanged
+          - alice                       # username
+          - bob@example.com             # email
+
+  Teams that do not exist are created. Organization access lists the
+  permissions to grant, using the names shown by "access explain";
+  permissions not listed are turned off, except those implied by a listed
+  one: manage-workspaces implies read-workspaces, and manage-projects
+  implies read-projects and read-workspaces. The owners team's access
+  cannot be changed.
+
+  Members are matched against organization memberships by username or
+  email. Users listed by email who are not in the organization yet are
+  invited and placed on their teams. Users listed by username must already
+  be members of the organization. Members not in the file are only removed
+  with -prune. Teams not in the file are left alone.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -file=<path>          Team file, YAML or JSON (required)
+  -prune                Remove team members that are not in the file
+  -auto-approve         Skip confirmation
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf team sync -org=my-org -file=teams.yaml -dry-run
+  hcptf team sync -org=my-org -file=teams.yaml
+  hcptf team sync -org=my-org -file=teams.json -prune -auto-approve
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the team sync command
+func (c *TeamSyncCommand) Synopsis() string {
+	return "Sync teams and team members from a file"
+}
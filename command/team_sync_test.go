@@ -0,0 +1,295 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockTeamSyncService struct {
+	teams   []*tfe.Team
+	created []tfe.TeamCreateOptions
+	updated map[string]tfe.TeamUpdateOptions
+}
+
+func (m *mockTeamSyncService) List(_ context.Context, _ string, _ *tfe.TeamListOptions) (*tfe.TeamList, error) {
+	return &tfe.TeamList{Items: m.teams}, nil
+}
+
+func (m *mockTeamSyncService) Create(_ context.Context, _ string, options tfe.TeamCreateOptions) (*tfe.Team, error) {
+	m.created = append(m.created, options)
+	return &tfe.Team{ID: fmt.Sprintf("team-new-%d", len(m.created)), Name: *options.Name}, nil
+}
+
+func (m *mockTeamSyncService) Update(_ context.Context, teamID string, options tfe.TeamUpdateOptions) (*tfe.Team, error) {
+	if m.updated == nil {
+		m.updated = map[string]tfe.TeamUpdateOptions{}
+	}
+	m.updated[teamID] = options
+	return &tfe.Team{ID: teamID}, nil
+}
+
+type mockTeamMemberManager struct {
+	added   []string
+	removed []string
+}
+
+func (m *mockTeamMemberManager) Add(_ context.Context, teamID string, options tfe.TeamMemberAddOptions) error {
+	for _, name := range append(options.Usernames, options.OrganizationMembershipIDs...) {
+		m.added = append(m.added, teamID+"/"+name)
+	}
+	return nil
+}
+
+func (m *mockTeamMemberManager) Remove(_ context.Context, teamID string, options tfe.TeamMemberRemoveOptions) error {
+	for _, name := range append(options.Usernames, options.OrganizationMembershipIDs...) {
+		m.removed = append(m.removed, teamID+"/"+name)
+	}
+	return nil
+}
+
+type mockOrganizationMembershipInviter struct {
+	memberships []*tfe.OrganizationMembership
+	invited     []string
+}
+
+func (m *mockOrganizationMembershipInviter) List(_ context.Context, _ string, _ *tfe.OrganizationMembershipListOptions) (*tfe.OrganizationMembershipList, error) {
+	return &tfe.OrganizationMembershipList{Items: m.memberships}, nil
+}
+
+func (m *mockOrganizationMembershipInviter) Create(_ context.Context, _ string, options tfe.OrganizationMembershipCreateOptions) (*tfe.OrganizationMembership, error) {
+	var teams []string
+	for _, team := range options.Teams {
+		teams = append(teams, team.ID)
+	}
+	m.invited = append(m.invited, *options.Email+"->"+strings.Join(teams, ","))
+	return &tfe.OrganizationMembership{ID: "ou-new", Email: *options.Email}, nil
+}
+
+func writeTeamSyncFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newTeamSyncCommand(ui cli.Ui) (*TeamSyncCommand, *mockTeamSyncService, *mockTeamMemberManager, *mockOrganizationMembershipInviter) {
+	platform := &tfe.Team{ID: "team-platform", Name: "platform", Visibility: "secret",
+		OrganizationAccess: &tfe.OrganizationAccess{ManageWorkspaces: true}}
+	teams := &mockTeamSyncService{teams: []*tfe.Team{platform}}
+	members := &mockTeamMemberManager{}
+	memberships := &mockOrganizationMembershipInviter{memberships: []*tfe.OrganizationMembership{
+		{ID: "ou-alice", Status: tfe.OrganizationMembershipActive, Email: "alice@example.com",
+			User: &tfe.User{Username: "alice"}, Teams: []*tfe.Team{{ID: "team-platform"}}},
+		{ID: "ou-bob", Status: tfe.OrganizationMembershipActive, Email: "bob@example.com",
+			User: &tfe.User{Username: "bob"}},
+		{ID: "ou-carol", Status: tfe.OrganizationMembershipActive, Email: "carol@example.com",
+			User: &tfe.User{Username: "carol"}, Teams: []*tfe.Team{{ID: "team-platform"}}},
+		{ID: "ou-dan", Status: tfe.OrganizationMembershipInvited, Email: "dan@example.com"},
+	}}
+	cmd := &TeamSyncCommand{
+		Meta:          newTestMeta(ui),
+		teamSvc:       teams,
+		teamMemberSvc: members,
+		membershipSvc: memberships,
+	}
+	return cmd, teams, members, memberships
+}
+
+const teamSyncYAML = `
+teams:
+  - name: platform
+    organization_access: [manage-workspaces, read-projects]
+    members:
+      - alice
+      - bob@example.com
+      - dan@example.com
+  - name: contractors
+    visibility: organization
+    members:
+      - bob
+      - erin@example.com
+`
+
+func TestLoadTeamSyncFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{name: "yaml", file: "teams.yaml", content: teamSyncYAML},
+		{name: "json", file: "teams.json", content: `{"teams": [{"name": "ci", "members": ["alice"]}]}`},
+		{name: "unknown field", file: "teams.yaml", content: "teams:\n  - name: ci\n    memebers: [alice]\n", wantErr: "memebers"},
+		{name: "duplicate", file: "teams.json", content: `{"teams": [{"name": "ci"}, {"name": "ci"}]}`, wantErr: "more than once"},
+		{name: "unknown permission", file: "teams.yaml", content: "teams:\n  - name: ci\n    organization_access: [manage-everything]\n", wantErr: "unknown organization permission"},
+		{name: "owners access", file: "teams.yaml", content: "teams:\n  - name: owners\n    organization_access: []\n", wantErr: "cannot be changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadTeamSyncFile(writeTeamSyncFile(t, tt.file, tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTeamSyncApply(t *testing.T) {
+	path := writeTeamSyncFile(t, "teams.yaml", teamSyncYAML)
+
+	ui := cli.NewMockUi()
+	cmd, teams, members, memberships := newTeamSyncCommand(ui)
+
+	code := cmd.Run([]string{"-org=my-org", "-file=" + path, "-auto-approve"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(teams.created) != 1 || *teams.created[0].Name != "contractors" || *teams.created[0].Visibility != "organization" {
+		t.Fatalf("unexpected team creations: %+v", teams.created)
+	}
+	update, ok := teams.updated["team-platform"]
+	if !ok || update.OrganizationAccess == nil || !*update.OrganizationAccess.ReadProjects || !*update.OrganizationAccess.ManageWorkspaces {
+		t.Fatalf("expected platform organization access update, got %+v", teams.updated)
+	}
+	if got := strings.Join(memberships.invited, ";"); got != "erin@example.com->team-new-1" {
+		t.Fatalf("unexpected invitations: %s", got)
+	}
+	if got := strings.Join(members.added, ","); got != "team-platform/bob,team-platform/ou-dan,team-new-1/bob" {
+		t.Fatalf("unexpected members added: %s", got)
+	}
+	if len(members.removed) != 0 {
+		t.Fatalf("expected no removals without -prune, got %v", members.removed)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "1 created, 1 updated; 1 users invited, 3 members added, 0 removed") {
+		t.Fatalf("unexpected summary: %q", ui.OutputWriter.String())
+	}
+}
+
+func TestTeamSyncPrune(t *testing.T) {
+	path := writeTeamSyncFile(t, "teams.json", `{"teams": [{"name": "platform", "members": ["alice"]}]}`)
+
+	ui := cli.NewMockUi()
+	cmd, _, members, _ := newTeamSyncCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-file=" + path, "-prune", "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(members.added) != 0 {
+		t.Fatalf("unexpected members added: %v", members.added)
+	}
+	if got := strings.Join(members.removed, ","); got != "team-platform/carol" {
+		t.Fatalf("unexpected members removed: %s", got)
+	}
+}
+
+func TestTeamSyncDryRun(t *testing.T) {
+	path := writeTeamSyncFile(t, "teams.yaml", teamSyncYAML)
+
+	ui := cli.NewMockUi()
+	cmd, teams, members, memberships := newTeamSyncCommand(ui)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-file=" + path, "-prune", "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(teams.created) != 0 || len(members.added) != 0 || len(members.removed) != 0 || len(memberships.invited) != 0 {
+		t.Fatalf("expected no API changes during dry-run")
+	}
+
+	var result struct {
+		Changes []teamSyncChange `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	var got []string
+	for _, change := range result.Changes {
+		got = append(got, change.Action+":"+change.Team+":"+change.Member)
+	}
+	want := []string{
+		"create-team:contractors:",
+		"update-team:platform:",
+		"invite:contractors:erin@example.com",
+		"add-member:platform:bob",
+		"add-member:platform:dan@example.com",
+		"add-member:contractors:bob",
+		"remove-member:platform:carol",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected plan:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestTeamSyncImpliedOrganizationAccessConverges(t *testing.T) {
+	path := writeTeamSyncFile(t, "teams.yaml", `
+teams:
+  - name: platform
+    organization_access: [manage-workspaces]
+`)
+
+	ui := cli.NewMockUi()
+	cmd, teams, _, _ := newTeamSyncCommand(ui)
+	teams.teams[0].OrganizationAccess = &tfe.OrganizationAccess{ManageWorkspaces: true, ReadWorkspaces: true}
+
+	if code := cmd.Run([]string{"-org=my-org", "-file=" + path, "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(teams.updated) != 0 {
+		t.Fatalf("expected no update for implied read-workspaces, got %+v", teams.updated)
+	}
+}
+
+func TestOrganizationAccessOptionsLeavesImpliedUnset(t *testing.T) {
+	options, err := organizationAccessOptions([]string{"manage-projects"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !*options.ManageProjects || options.ReadProjects != nil || options.ReadWorkspaces != nil {
+		t.Fatalf("expected implied permissions to be left unset, got %+v", options)
+	}
+	if options.ManageWorkspaces == nil || *options.ManageWorkspaces {
+		t.Fatalf("expected other permissions to be turned off, got %+v", options)
+	}
+
+	options, err = organizationAccessOptions([]string{"manage-workspaces"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.ReadWorkspaces != nil || options.ReadProjects == nil || *options.ReadProjects {
+		t.Fatalf("expected only read-workspaces to be left unset, got %+v", options)
+	}
+}
+
+func TestTeamSyncUnknownUsername(t *testing.T) {
+	path := writeTeamSyncFile(t, "teams.yaml", "teams:\n  - name: platform\n    members: [zed]\n")
+
+	ui := cli.NewMockUi()
+	cmd, _, _, _ := newTeamSyncCommand(ui)
+
+	if code := cmd.Run([]string{"-org=my-org", "-file=" + path, "-auto-approve"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "list them by email to invite them") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
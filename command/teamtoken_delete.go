@@ -50,8 +50,13 @@ func (c *TeamTokenDeleteCommand) Run(args []string) int {
 		}
 	}
 
-	// Delete team token
-	err = client.TeamTokens.Delete(client.Context(), c.id)
+	// Delete team token. Token IDs (at-...) are deleted by ID; a team ID
+	// deletes that team's legacy token.
+	if strings.HasPrefix(c.id, "at-") {
+		err = client.TeamTokens.DeleteByID(client.Context(), c.id)
+	} else {
+		err = client.TeamTokens.Delete(client.Context(), c.id)
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error deleting team token: %s", err))
 		return 1
@@ -0,0 +1,471 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// teamTokenIDPattern finds the token ID that a previous rotation recorded in
+// the variable's description.
+var teamTokenIDPattern = regexp.MustCompile(`\bat-[A-Za-z0-9]+\b`)
+
+// TeamTokenRotateCommand is a command to replace a team token and store the
+// new one in a sensitive variable
+type TeamTokenRotateCommand struct {
+	Meta
+	organization    string
+	team            string
+	intoVarSet      string
+	intoWorkspace   string
+	key             string
+	category        string
+	description     string
+	expiresInDays   int
+	previousTokenID string
+	deletePrevious  bool
+	gracePeriod     time.Duration
+	autoApprove     bool
+	format          string
+	teamSvc         teamRefReader
+	tokenSvc        teamTokenRotator
+	varSetVarSvc    variableSetVariableSyncService
+	workspaceSvc    workspaceRefReader
+	variableSvc     variableSyncService
+	now             func() time.Time
+	sleep           func(time.Duration)
+}
+
+// Run executes the team token rotate command
+func (c *TeamTokenRotateCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("team token rotate")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.team, "team", "", "Team name or ID (required)")
+	flags.StringVar(&c.intoVarSet, "into-varset", "", "Variable set ID to write the token into")
+	flags.StringVar(&c.intoWorkspace, "into-workspace", "", "Workspace name or ID to write the token into")
+	flags.StringVar(&c.key, "key", "TFE_TOKEN", "Variable key")
+	flags.StringVar(&c.category, "category", "env", "Variable category: env or terraform")
+	flags.StringVar(&c.description, "description", "", "Token description (default: the key and the rotation time)")
+	flags.IntVar(&c.expiresInDays, "expires-in-days", 45, "Days until the new token expires")
+	flags.StringVar(&c.previousTokenID, "previous-token-id", "", "Token being replaced (default: the one recorded on the variable)")
+	flags.BoolVar(&c.deletePrevious, "delete-previous", false, "Delete the previous token after the new one is written")
+	flags.DurationVar(&c.gracePeriod, "grace-period", 0, "Wait this long before deleting the previous token, without asking")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Delete the previous token without asking")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.team == "" {
+		c.Ui.Error("Error: -team flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if (c.intoVarSet == "") == (c.intoWorkspace == "") {
+		c.Ui.Error("Error: exactly one of -into-varset or -into-workspace is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.intoVarSet != "" && !c.Meta.ValidateID(c.intoVarSet, "-into-varset") {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.key == "" {
+		c.Ui.Error("Error: -key must not be empty")
+		return 1
+	}
+
+	if c.category != "env" && c.category != "terraform" {
+		c.Ui.Error("Error: -category must be 'env' or 'terraform'")
+		return 1
+	}
+
+	if c.expiresInDays < 1 {
+		c.Ui.Error("Error: -expires-in-days must be at least 1")
+		return 1
+	}
+
+	if c.gracePeriod < 0 {
+		c.Ui.Error("Error: -grace-period must not be negative")
+		return 1
+	}
+
+	if c.gracePeriod > 0 && !c.deletePrevious {
+		c.Ui.Error("Error: -grace-period requires -delete-previous")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	team, err := resolveTeamRef(ctx, c.teamService(client), c.organization, c.team)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading team: %s", err))
+		return 1
+	}
+
+	target, err := c.resolveTarget(ctx, client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	variableID, variableDescription, err := c.findVariable(ctx, client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading variable %s: %s", c.key, err))
+		return 1
+	}
+
+	previousID := c.previousTokenID
+	if previousID == "" {
+		previousID = teamTokenIDPattern.FindString(variableDescription)
+	}
+
+	tokenSvc := c.tokenService(client)
+	if previousID != "" && c.deletePrevious {
+		previous, err := tokenSvc.ReadByID(ctx, previousID)
+		switch {
+		case errors.Is(err, tfe.ErrResourceNotFound):
+			c.Ui.Warn(fmt.Sprintf("Warning: previous token %s no longer exists; nothing to delete", previousID))
+			previousID = ""
+		case err != nil:
+			c.Ui.Error(fmt.Sprintf("Error reading previous token: %s", err))
+			return 1
+		case previous.Team != nil && previous.Team.ID != team.ID:
+			c.Ui.Error(fmt.Sprintf("Error: previous token %s belongs to another team; refusing to delete it", previousID))
+			return 1
+		}
+	}
+
+	now := c.timeNow().UTC()
+	expiresAt := now.AddDate(0, 0, c.expiresInDays)
+	description := c.description
+	if description == "" {
+		description = fmt.Sprintf("%s rotated %s", c.key, now.Format(time.RFC3339))
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":            "rotate",
+			"resource":          "team-token",
+			"team":              team.Name,
+			"team_id":           team.ID,
+			"description":       description,
+			"expires_at":        expiresAt.Format(time.RFC3339),
+			"target":            target,
+			"key":               c.key,
+			"variable_exists":   variableID != "",
+			"previous_token_id": previousID,
+			"delete_previous":   c.deletePrevious && previousID != "",
+		})
+		return 0
+	}
+
+	token, err := tokenSvc.CreateWithOptions(ctx, team.ID, tfe.TeamTokenCreateOptions{
+		Description: tfe.String(description),
+		ExpiredAt:   &expiresAt,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating team token: %s", err))
+		return 1
+	}
+
+	// The variable records which token it holds, so that the next rotation
+	// knows which one to replace.
+	varDescription := fmt.Sprintf("Team token %s for team %s, rotated %s", token.ID, team.Name, now.Format(time.RFC3339))
+	variableAction := "updated"
+	if variableID == "" {
+		variableAction = "created"
+	}
+	if err := c.writeVariable(ctx, client, variableID, token.Token, varDescription); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing variable %s: %s", c.key, err))
+		if delErr := tokenSvc.DeleteByID(ctx, token.ID); delErr != nil {
+			c.Ui.Error(fmt.Sprintf("Error: the new token %s could not be deleted and must be removed by hand: %s", token.ID, delErr))
+		} else {
+			c.Ui.Error(fmt.Sprintf("The new token %s was deleted; the previous token is unchanged.", token.ID))
+		}
+		return 1
+	}
+
+	deleted := false
+	if c.deletePrevious && previousID != "" {
+		deleted, err = c.deletePreviousToken(ctx, tokenSvc, previousID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error deleting previous token %s: %s", previousID, err))
+			c.Ui.Error(fmt.Sprintf("The new token %s is in place; delete the previous token with 'hcptf team token delete'.", token.ID))
+			return 1
+		}
+	}
+
+	record := map[string]interface{}{
+		"Team":                 team.Name,
+		"TeamID":               team.ID,
+		"TokenID":              token.ID,
+		"Description":          description,
+		"ExpiresAt":            expiresAt.Format(time.RFC3339),
+		"Target":               target,
+		"Variable":             c.key,
+		"VariableAction":       variableAction,
+		"PreviousTokenID":      previousID,
+		"PreviousTokenDeleted": deleted,
+		"RotatedAt":            now.Format(time.RFC3339),
+	}
+	if c.format != "json" {
+		c.Ui.Output(fmt.Sprintf("Rotated token for team '%s' into %s", team.Name, target))
+	}
+	c.Meta.NewFormatter(c.format).KeyValue(record)
+
+	if previousID != "" && !deleted && c.format != "json" {
+		c.Ui.Warn(fmt.Sprintf("\nThe previous token %s is still valid. Delete it once nothing uses it:\n  hcptf team token delete -id=%s", previousID, previousID))
+	}
+	return 0
+}
+
+// resolveTarget checks the variable set or workspace and returns a
+// description of it for output.
+func (c *TeamTokenRotateCommand) resolveTarget(ctx context.Context, client *client.Client) (string, error) {
+	if c.intoVarSet != "" {
+		return "variable set " + c.intoVarSet, nil
+	}
+
+	ws, err := resolveWorkspaceRef(ctx, c.workspaceService(client), c.organization, c.intoWorkspace)
+	if err != nil {
+		return "", err
+	}
+	c.intoWorkspace = ws.ID
+	return fmt.Sprintf("workspace %s (%s)", ws.Name, ws.ID), nil
+}
+
+// findVariable returns the ID and description of the variable with the
+// configured key and category, or an empty ID if there is none yet.
+func (c *TeamTokenRotateCommand) findVariable(ctx context.Context, client *client.Client) (string, string, error) {
+	if c.intoVarSet != "" {
+		variables, err := listAllVariableSetVariables(ctx, c.varSetVarService(client), c.intoVarSet)
+		if err != nil {
+			return "", "", err
+		}
+		for _, v := range variables {
+			if v.Key == c.key && string(v.Category) == c.category {
+				return v.ID, v.Description, nil
+			}
+		}
+		return "", "", nil
+	}
+
+	variables, err := listAllVariables(ctx, c.variableService(client), c.intoWorkspace)
+	if err != nil {
+		return "", "", err
+	}
+	for _, v := range variables {
+		if v.Key == c.key && string(v.Category) == c.category {
+			return v.ID, v.Description, nil
+		}
+	}
+	return "", "", nil
+}
+
+// writeVariable stores the token as a sensitive variable, updating the
+// existing one if there is one.
+func (c *TeamTokenRotateCommand) writeVariable(ctx context.Context, client *client.Client, variableID, value, description string) error {
+	category := tfe.Category(tfe.CategoryType(c.category))
+
+	if c.intoVarSet != "" {
+		svc := c.varSetVarService(client)
+		if variableID != "" {
+			_, err := svc.Update(ctx, c.intoVarSet, variableID, &tfe.VariableSetVariableUpdateOptions{
+				Value:       tfe.String(value),
+				Description: tfe.String(description),
+				Sensitive:   tfe.Bool(true),
+			})
+			return err
+		}
+		_, err := svc.Create(ctx, c.intoVarSet, &tfe.VariableSetVariableCreateOptions{
+			Key:         tfe.String(c.key),
+			Value:       tfe.String(value),
+			Description: tfe.String(description),
+			Category:    category,
+			HCL:         tfe.Bool(false),
+			Sensitive:   tfe.Bool(true),
+		})
+		return err
+	}
+
+	svc := c.variableService(client)
+	if variableID != "" {
+		_, err := svc.Update(ctx, c.intoWorkspace, variableID, tfe.VariableUpdateOptions{
+			Value:       tfe.String(value),
+			Description: tfe.String(description),
+			Sensitive:   tfe.Bool(true),
+		})
+		return err
+	}
+	_, err := svc.Create(ctx, c.intoWorkspace, tfe.VariableCreateOptions{
+		Key:         tfe.String(c.key),
+		Value:       tfe.String(value),
+		Description: tfe.String(description),
+		Category:    category,
+		HCL:         tfe.Bool(false),
+		Sensitive:   tfe.Bool(true),
+	})
+	return err
+}
+
+// deletePreviousToken deletes the replaced token, after the grace period if
+// one was given and otherwise once confirmed. It reports whether the token
+// was deleted.
+func (c *TeamTokenRotateCommand) deletePreviousToken(ctx context.Context, svc teamTokenRotator, tokenID string) (bool, error) {
+	switch {
+	case c.gracePeriod > 0:
+		if c.format != "json" {
+			c.Ui.Output(fmt.Sprintf("Waiting %s before deleting previous token %s...", c.gracePeriod, tokenID))
+		}
+		c.wait(c.gracePeriod)
+	case !c.autoApprove:
+		c.Ui.Output(fmt.Sprintf("The new token is in place. Delete the previous token %s now?", tokenID))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			return false, fmt.Errorf("reading input: %w", err)
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			return false, nil
+		}
+	}
+
+	if err := svc.DeleteByID(ctx, tokenID); err != nil && !errors.Is(err, tfe.ErrResourceNotFound) {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *TeamTokenRotateCommand) timeNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func (c *TeamTokenRotateCommand) wait(d time.Duration) {
+	if c.sleep != nil {
+		c.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+func (c *TeamTokenRotateCommand) teamService(client *client.Client) teamRefReader {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *TeamTokenRotateCommand) tokenService(client *client.Client) teamTokenRotator {
+	if c.tokenSvc != nil {
+		return c.tokenSvc
+	}
+	return client.TeamTokens
+}
+
+func (c *TeamTokenRotateCommand) varSetVarService(client *client.Client) variableSetVariableSyncService {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+func (c *TeamTokenRotateCommand) workspaceService(client *client.Client) workspaceRefReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *TeamTokenRotateCommand) variableService(client *client.Client) variableSyncService {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+// Help returns help text for the team token rotate command
+func (c *TeamTokenRotateCommand) Help() string {
+	helpText := `
+Usage: hcptf team token rotate [options]
+
+  Create a new team token that expires, write it as a sensitive variable
+  into a variable set or workspace, and optionally delete the token it
+  replaces. The token value is never printed; the output records the
+  rotation instead.
+
+  The variable's description records the ID of the token it holds, so the
+  next rotation knows which token it replaces. Use -previous-token-id for
+  the first rotation of a token that was stored some other way.
+
+  With -delete-previous, the previous token is deleted once the new one has
+  been written: after -grace-period if one is given, so runs already using
+  it can finish, and otherwise once confirmed. If the variable cannot be
+  written, the new token is deleted and nothing else changes.
+
+Options:
+
+  -organization=<name>      Organization name (required)
+  -org=<name>               Alias for -organization
+  -team=<name>              Team name or ID (required)
+  -into-varset=<id>         Variable set to write the token into
+  -into-workspace=<name>    Workspace name or ID to write the token into
+                            (one of -into-varset or -into-workspace is
+                            required)
+  -key=<key>                Variable key (default: TFE_TOKEN)
+  -category=<category>      Variable category: env (default) or terraform
+  -description=<text>       Token description (default: the key and the
+                            rotation time)
+  -expires-in-days=<n>      Days until the new token expires (default: 45)
+  -previous-token-id=<id>   Token being replaced (default: the one recorded
+                            on the variable)
+  -delete-previous          Delete the previous token
+  -grace-period=<duration>  Wait this long (e.g. 30m) before deleting the
+                            previous token, without asking
+  -auto-approve             Delete the previous token without asking
+  -output=<format>          Output format: table (default) or json
+
+Example:
+
+  hcptf team token rotate -org=my-org -team=ci -into-varset=varset-abc123
+  hcptf team token rotate -org=my-org -team=ci -into-varset=varset-abc123 \
+    -delete-previous -grace-period=1h
+  hcptf team token rotate -org=my-org -team=ci -into-workspace=prod \
+    -key=tfe_token -category=terraform -dry-run
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the team token rotate command
+func (c *TeamTokenRotateCommand) Synopsis() string {
+	return "Rotate a team token into a sensitive variable"
+}
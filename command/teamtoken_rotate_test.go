@@ -0,0 +1,253 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockTeamRefService struct {
+	teams []*tfe.Team
+}
+
+func (m *mockTeamRefService) Read(_ context.Context, teamID string) (*tfe.Team, error) {
+	for _, team := range m.teams {
+		if team.ID == teamID {
+			return team, nil
+		}
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockTeamRefService) List(_ context.Context, _ string, options *tfe.TeamListOptions) (*tfe.TeamList, error) {
+	list := &tfe.TeamList{}
+	for _, team := range m.teams {
+		for _, name := range options.Names {
+			if team.Name == name {
+				list.Items = append(list.Items, team)
+			}
+		}
+	}
+	return list, nil
+}
+
+type mockTeamTokenRotator struct {
+	tokens  map[string]*tfe.TeamToken
+	created []tfe.TeamTokenCreateOptions
+	deleted []string
+}
+
+func (m *mockTeamTokenRotator) CreateWithOptions(_ context.Context, teamID string, options tfe.TeamTokenCreateOptions) (*tfe.TeamToken, error) {
+	m.created = append(m.created, options)
+	return &tfe.TeamToken{
+		ID:        fmt.Sprintf("at-new%d", len(m.created)),
+		Token:     "secret-token-value",
+		ExpiredAt: *options.ExpiredAt,
+		Team:      &tfe.Team{ID: teamID},
+	}, nil
+}
+
+func (m *mockTeamTokenRotator) ReadByID(_ context.Context, tokenID string) (*tfe.TeamToken, error) {
+	if token, ok := m.tokens[tokenID]; ok {
+		return token, nil
+	}
+	return nil, tfe.ErrResourceNotFound
+}
+
+func (m *mockTeamTokenRotator) DeleteByID(_ context.Context, tokenID string) error {
+	m.deleted = append(m.deleted, tokenID)
+	return nil
+}
+
+// failingVariableSetVariableService fails every write.
+type failingVariableSetVariableService struct {
+	mockVariableSetVariableSyncService
+}
+
+func (m *failingVariableSetVariableService) Create(_ context.Context, _ string, _ *tfe.VariableSetVariableCreateOptions) (*tfe.VariableSetVariable, error) {
+	return nil, fmt.Errorf("forbidden")
+}
+
+var teamTokenRotateNow = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTeamTokenRotateCommand(ui cli.Ui, tokens *mockTeamTokenRotator, vars variableSetVariableSyncService) *TeamTokenRotateCommand {
+	return &TeamTokenRotateCommand{
+		Meta:         newTestMeta(ui),
+		teamSvc:      &mockTeamRefService{teams: []*tfe.Team{{ID: "team-ci", Name: "ci"}}},
+		tokenSvc:     tokens,
+		varSetVarSvc: vars,
+		now:          func() time.Time { return teamTokenRotateNow },
+	}
+}
+
+func TestTeamTokenRotateRequiresOneTarget(t *testing.T) {
+	for _, args := range [][]string{
+		{"-org=my-org", "-team=ci"},
+		{"-org=my-org", "-team=ci", "-into-varset=varset-1", "-into-workspace=prod"},
+	} {
+		ui := cli.NewMockUi()
+		cmd := newTeamTokenRotateCommand(ui, &mockTeamTokenRotator{}, &mockVariableSetVariableSyncService{})
+		if code := cmd.Run(args); code != 1 {
+			t.Fatalf("%v: expected exit 1, got %d", args, code)
+		}
+		if !strings.Contains(ui.ErrorWriter.String(), "exactly one of -into-varset or -into-workspace") {
+			t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestTeamTokenRotateReplacesRecordedToken(t *testing.T) {
+	tokens := &mockTeamTokenRotator{tokens: map[string]*tfe.TeamToken{
+		"at-old": {ID: "at-old", Team: &tfe.Team{ID: "team-ci"}},
+	}}
+	vars := &mockVariableSetVariableSyncService{variables: []*tfe.VariableSetVariable{
+		{ID: "var-token", Key: "TFE_TOKEN", Category: tfe.CategoryEnv, Sensitive: true,
+			Description: "Team token at-old for team ci, rotated 2026-09-01T12:00:00Z"},
+	}}
+	ui := cli.NewMockUi()
+	cmd := newTeamTokenRotateCommand(ui, tokens, vars)
+	var slept time.Duration
+	cmd.sleep = func(d time.Duration) { slept = d }
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-team=ci", "-into-varset=varset-1", "-delete-previous", "-grace-period=30m", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(tokens.created) != 1 {
+		t.Fatalf("expected one token, got %d", len(tokens.created))
+	}
+	if got := tokens.created[0].ExpiredAt; got == nil || !got.Equal(teamTokenRotateNow.AddDate(0, 0, 45)) {
+		t.Fatalf("unexpected expiry: %v", got)
+	}
+	opts := vars.updated["var-token"]
+	if opts == nil || *opts.Value != "secret-token-value" || !*opts.Sensitive {
+		t.Fatalf("expected sensitive variable update, got %#v", opts)
+	}
+	if !strings.Contains(*opts.Description, "at-new1") {
+		t.Fatalf("expected new token ID in description, got %q", *opts.Description)
+	}
+	if slept != 30*time.Minute {
+		t.Fatalf("expected 30m grace period, got %s", slept)
+	}
+	if len(tokens.deleted) != 1 || tokens.deleted[0] != "at-old" {
+		t.Fatalf("expected at-old to be deleted, got %v", tokens.deleted)
+	}
+
+	if strings.Contains(output+ui.OutputWriter.String(), "secret-token-value") {
+		t.Fatalf("token value printed: %q", output)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &record); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if record["TokenID"] != "at-new1" || record["PreviousTokenID"] != "at-old" || record["PreviousTokenDeleted"] != true {
+		t.Fatalf("unexpected record: %#v", record)
+	}
+}
+
+func TestTeamTokenRotateKeepsPreviousTokenWhenNotConfirmed(t *testing.T) {
+	tokens := &mockTeamTokenRotator{tokens: map[string]*tfe.TeamToken{
+		"at-old": {ID: "at-old", Team: &tfe.Team{ID: "team-ci"}},
+	}}
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("no\n")
+	cmd := newTeamTokenRotateCommand(ui, tokens, &mockVariableSetVariableSyncService{})
+
+	_, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-team=team-ci", "-into-varset=varset-1", "-previous-token-id=at-old", "-delete-previous"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(tokens.deleted) != 0 {
+		t.Fatalf("expected no deletion, got %v", tokens.deleted)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "at-old is still valid") {
+		t.Fatalf("expected reminder about previous token, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestTeamTokenRotateRefusesTokenOfAnotherTeam(t *testing.T) {
+	tokens := &mockTeamTokenRotator{tokens: map[string]*tfe.TeamToken{
+		"at-other": {ID: "at-other", Team: &tfe.Team{ID: "team-owners"}},
+	}}
+	ui := cli.NewMockUi()
+	cmd := newTeamTokenRotateCommand(ui, tokens, &mockVariableSetVariableSyncService{})
+
+	code := cmd.Run([]string{"-org=my-org", "-team=ci", "-into-varset=varset-1", "-previous-token-id=at-other", "-delete-previous", "-auto-approve"})
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if len(tokens.created) != 0 || len(tokens.deleted) != 0 {
+		t.Fatalf("expected no token changes")
+	}
+}
+
+func TestTeamTokenRotateDeletesNewTokenWhenVariableWriteFails(t *testing.T) {
+	tokens := &mockTeamTokenRotator{}
+	ui := cli.NewMockUi()
+	cmd := newTeamTokenRotateCommand(ui, tokens, &failingVariableSetVariableService{})
+
+	if code := cmd.Run([]string{"-org=my-org", "-team=ci", "-into-varset=varset-1"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if len(tokens.deleted) != 1 || tokens.deleted[0] != "at-new1" {
+		t.Fatalf("expected the new token to be deleted, got %v", tokens.deleted)
+	}
+}
+
+func TestTeamTokenRotateCreatesWorkspaceVariable(t *testing.T) {
+	tokens := &mockTeamTokenRotator{}
+	variables := &mockVariableSyncService{}
+	ui := cli.NewMockUi()
+	cmd := newTeamTokenRotateCommand(ui, tokens, nil)
+	cmd.workspaceSvc = &mockRemoteStateConsumerManager{workspaces: []*tfe.Workspace{{ID: "ws-prod", Name: "prod"}}}
+	cmd.variableSvc = variables
+
+	_, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-team=ci", "-into-workspace=prod", "-key=tfe_token", "-category=terraform", "-expires-in-days=7"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(variables.created) != 1 {
+		t.Fatalf("expected one variable, got %#v", variables.created)
+	}
+	created := variables.created[0]
+	if *created.Key != "tfe_token" || *created.Category != tfe.CategoryTerraform || !*created.Sensitive || *created.Value != "secret-token-value" {
+		t.Fatalf("unexpected variable: %#v", created)
+	}
+}
+
+func TestTeamTokenRotateDryRun(t *testing.T) {
+	tokens := &mockTeamTokenRotator{}
+	vars := &mockVariableSetVariableSyncService{}
+	ui := cli.NewMockUi()
+	cmd := newTeamTokenRotateCommand(ui, tokens, vars)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-team=ci", "-into-varset=varset-1", "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(tokens.created) != 0 || len(vars.created) != 0 {
+		t.Fatalf("expected no API changes during dry-run")
+	}
+	var plan map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &plan); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if plan["action"] != "rotate" || plan["variable_exists"] != false {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+}
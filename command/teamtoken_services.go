@@ -0,0 +1,17 @@
+package command
+
+import (
+	"context"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+type teamTokenRotator interface {
+	CreateWithOptions(ctx context.Context, teamID string, options tfe.TeamTokenCreateOptions) (*tfe.TeamToken, error)
+	ReadByID(ctx context.Context, tokenID string) (*tfe.TeamToken, error)
+	DeleteByID(ctx context.Context, tokenID string) error
+}
+
+type teamTokenLister interface {
+	List(ctx context.Context, organization string, options *tfe.TeamTokenListOptions) (*tfe.TeamTokenList, error)
+}
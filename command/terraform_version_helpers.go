@@ -0,0 +1,60 @@
+package command
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseTerraformVersion extracts the numeric major, minor and patch parts of
+// a workspace Terraform version. Constraint operators such as "~>" and
+// pre-release suffixes are ignored. It returns false for values that do not
+// name a concrete version, such as "latest".
+func parseTerraformVersion(value string) ([3]int, bool) {
+	var parts [3]int
+
+	value = strings.TrimSpace(value)
+	value = strings.TrimLeft(value, "~>=<! ")
+	value = strings.TrimPrefix(value, "v")
+	if i := strings.IndexAny(value, "-+"); i >= 0 {
+		value = value[:i]
+	}
+	if value == "" {
+		return parts, false
+	}
+
+	fields := strings.Split(value, ".")
+	if len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// compareTerraformVersions returns -1, 0 or 1 when a is older than, equal to
+// or newer than b. The boolean is false when either version cannot be parsed.
+func compareTerraformVersions(a, b string) (int, bool) {
+	av, ok := parseTerraformVersion(a)
+	if !ok {
+		return 0, false
+	}
+	bv, ok := parseTerraformVersion(b)
+	if !ok {
+		return 0, false
+	}
+
+	for i := range av {
+		switch {
+		case av[i] < bv[i]:
+			return -1, true
+		case av[i] > bv[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
@@ -0,0 +1,26 @@
+package command
+
+import "testing"
+
+func TestCompareTerraformVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"1.5.7", "1.5.7", 0, true},
+		{"1.4.6", "1.5.0", -1, true},
+		{"1.10.0", "1.9.8", 1, true},
+		{"~> 1.5.0", "1.6", -1, true},
+		{"v1.6.0-beta1", "1.6.0", 0, true},
+		{"latest", "1.5.0", 0, false},
+		{"1.5.0", "", 0, false},
+	}
+
+	for _, tc := range cases {
+		got, ok := compareTerraformVersions(tc.a, tc.b)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("compareTerraformVersions(%q, %q) = %d, %v; want %d, %v", tc.a, tc.b, got, ok, tc.want, tc.ok)
+		}
+	}
+}
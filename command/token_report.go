@@ -0,0 +1,456 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	tokenKindUser         = "user"
+	tokenKindTeam         = "team"
+	tokenKindOrganization = "organization"
+	tokenKindAuditTrail   = "audit-trail"
+	tokenKindAgent        = "agent"
+
+	tokenFlagNeverExpires = "never-expires"
+	tokenFlagExpired      = "expired"
+	tokenFlagExpiring     = "expiring"
+	tokenFlagUnused       = "unused"
+)
+
+// tokenKindOrder is the order token kinds are reported in.
+var tokenKindOrder = map[string]int{
+	tokenKindUser:         0,
+	tokenKindTeam:         1,
+	tokenKindOrganization: 2,
+	tokenKindAuditTrail:   3,
+	tokenKindAgent:        4,
+}
+
+// TokenReportCommand is a command to report on every API token in an
+// organization
+type TokenReportCommand struct {
+	Meta
+	organization string
+	expiringDays int
+	unusedDays   int
+	flaggedOnly  bool
+	format       string
+	now          func() time.Time
+	userSvc      userReader
+	userTokenSvc userTokenLister
+	teamSvc      teamLister
+	teamTokenSvc teamTokenLister
+	orgTokenSvc  organizationTokenReader
+	agentPoolSvc agentPoolLister
+	agentSvc     agentTokenLister
+}
+
+// tokenReportEntry is one token in the report. Token values are never
+// included.
+type tokenReportEntry struct {
+	Kind        string     `json:"kind"`
+	ID          string     `json:"id"`
+	Owner       string     `json:"owner"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	Flags       []string   `json:"flags"`
+}
+
+// Run executes the token report command
+func (c *TokenReportCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("token report")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.IntVar(&c.expiringDays, "expiring-days", 30, "Flag tokens that expire within this many days")
+	flags.IntVar(&c.unusedDays, "unused-days", 90, "Flag tokens not used in this many days")
+	flags.BoolVar(&c.flaggedOnly, "flagged", false, "Only show tokens with at least one flag")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" && c.format != "csv" {
+		c.Ui.Error("Error: -output must be one of: table, json, csv")
+		return 1
+	}
+
+	if c.expiringDays < 0 || c.unusedDays < 0 {
+		c.Ui.Error("Error: -expiring-days and -unused-days must not be negative")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	entries, err := c.collect(client.Context(), client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	entries = c.flag(entries)
+	if c.flaggedOnly {
+		var flagged []*tokenReportEntry
+		for _, entry := range entries {
+			if len(entry.Flags) > 0 {
+				flagged = append(flagged, entry)
+			}
+		}
+		entries = flagged
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		if entries == nil {
+			entries = []*tokenReportEntry{}
+		}
+		formatter.JSON(entries)
+		return 0
+	}
+
+	// CSV always gets a header so an empty report can still be parsed.
+	if len(entries) == 0 && c.format == "table" {
+		c.Ui.Output("No tokens found")
+		return 0
+	}
+
+	headers := []string{"Type", "ID", "Owner", "Description", "Created At", "Last Used At", "Expires At", "Flags"}
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		lastUsed := "Never"
+		if entry.LastUsedAt != nil {
+			lastUsed = entry.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+		expiresAt := "Never"
+		if entry.ExpiresAt != nil {
+			expiresAt = entry.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		description := entry.Description
+		if description == "" {
+			description = "-"
+		}
+		rows = append(rows, []string{
+			entry.Kind,
+			entry.ID,
+			entry.Owner,
+			description,
+			entry.CreatedAt.Format("2006-01-02 15:04:05"),
+			lastUsed,
+			expiresAt,
+			strings.Join(entry.Flags, ","),
+		})
+	}
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// collect gathers every kind of token. A kind the caller may not see is
+// skipped with a warning rather than failing the whole report.
+func (c *TokenReportCommand) collect(ctx context.Context, cl *client.Client) ([]*tokenReportEntry, error) {
+	var entries []*tokenReportEntry
+	sources := []struct {
+		kind  string
+		fetch func(context.Context, *client.Client) ([]*tokenReportEntry, error)
+	}{
+		{tokenKindUser, c.userTokens},
+		{tokenKindTeam, c.teamTokens},
+		{tokenKindOrganization, c.organizationToken},
+		{tokenKindAuditTrail, c.auditTrailToken},
+		{tokenKindAgent, c.agentTokens},
+	}
+	for _, source := range sources {
+		found, err := source.fetch(ctx, cl)
+		if errors.Is(err, tfe.ErrUnauthorized) {
+			c.Ui.Warn(fmt.Sprintf("Warning: skipping %s tokens: not authorized", source.kind))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %s tokens: %w", source.kind, err)
+		}
+		entries = append(entries, found...)
+	}
+	return entries, nil
+}
+
+// userTokens returns the caller's own tokens, the only user tokens the API
+// shows.
+func (c *TokenReportCommand) userTokens(ctx context.Context, client *client.Client) ([]*tokenReportEntry, error) {
+	user, err := c.userService(client).ReadCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := c.userTokenService(client).List(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*tokenReportEntry
+	for _, token := range tokens.Items {
+		entries = append(entries, newTokenReportEntry(tokenKindUser, token.ID, user.Username, token.Description, token.CreatedAt, token.LastUsedAt, token.ExpiredAt))
+	}
+	return entries, nil
+}
+
+func (c *TokenReportCommand) teamTokens(ctx context.Context, client *client.Client) ([]*tokenReportEntry, error) {
+	tokens, err := listAllTeamTokens(ctx, c.teamTokenService(client), c.organization)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	// Tokens only reference their team by ID.
+	teams, err := listAllTeams(ctx, c.teamService(client), c.organization, nil)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := map[string]string{}
+	for _, team := range teams {
+		teamNames[team.ID] = team.Name
+	}
+
+	var entries []*tokenReportEntry
+	for _, token := range tokens {
+		owner := ""
+		if token.Team != nil {
+			owner = token.Team.ID
+			if name, ok := teamNames[token.Team.ID]; ok {
+				owner = name
+			}
+		}
+		description := ""
+		if token.Description != nil {
+			description = *token.Description
+		}
+		entries = append(entries, newTokenReportEntry(tokenKindTeam, token.ID, owner, description, token.CreatedAt, token.LastUsedAt, token.ExpiredAt))
+	}
+	return entries, nil
+}
+
+func (c *TokenReportCommand) organizationToken(ctx context.Context, client *client.Client) ([]*tokenReportEntry, error) {
+	token, err := c.orgTokenService(client).Read(ctx, c.organization)
+	if errors.Is(err, tfe.ErrResourceNotFound) || (err == nil && token == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*tokenReportEntry{
+		newTokenReportEntry(tokenKindOrganization, token.ID, c.organization, token.Description, token.CreatedAt, token.LastUsedAt, token.ExpiredAt),
+	}, nil
+}
+
+func (c *TokenReportCommand) auditTrailToken(ctx context.Context, client *client.Client) ([]*tokenReportEntry, error) {
+	tokenType := tfe.AuditTrailToken
+	token, err := c.orgTokenService(client).ReadWithOptions(ctx, c.organization, tfe.OrganizationTokenReadOptions{
+		TokenType: &tokenType,
+	})
+	if errors.Is(err, tfe.ErrResourceNotFound) || (err == nil && token == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*tokenReportEntry{
+		newTokenReportEntry(tokenKindAuditTrail, token.ID, c.organization, token.Description, token.CreatedAt, token.LastUsedAt, token.ExpiredAt),
+	}, nil
+}
+
+// agentTokens returns the tokens of every agent pool. Agent tokens have no
+// expiry.
+func (c *TokenReportCommand) agentTokens(ctx context.Context, client *client.Client) ([]*tokenReportEntry, error) {
+	pools, err := listAllAgentPools(ctx, c.agentPoolService(client), c.organization)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*tokenReportEntry
+	for _, pool := range pools {
+		tokens, err := c.agentTokenService(client).List(ctx, pool.ID)
+		if err != nil {
+			return nil, fmt.Errorf("agent pool %s: %w", pool.Name, err)
+		}
+		for _, token := range tokens.Items {
+			entries = append(entries, newTokenReportEntry(tokenKindAgent, token.ID, pool.Name, token.Description, token.CreatedAt, token.LastUsedAt, time.Time{}))
+		}
+	}
+	return entries, nil
+}
+
+func newTokenReportEntry(kind, id, owner, description string, createdAt, lastUsedAt, expiresAt time.Time) *tokenReportEntry {
+	entry := &tokenReportEntry{
+		Kind:        kind,
+		ID:          id,
+		Owner:       owner,
+		Description: description,
+		CreatedAt:   createdAt,
+	}
+	if !lastUsedAt.IsZero() {
+		entry.LastUsedAt = &lastUsedAt
+	}
+	if !expiresAt.IsZero() {
+		entry.ExpiresAt = &expiresAt
+	}
+	return entry
+}
+
+// flag sets each entry's flags and returns the entries sorted by kind,
+// owner, and creation time.
+func (c *TokenReportCommand) flag(entries []*tokenReportEntry) []*tokenReportEntry {
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	expiring := time.Duration(c.expiringDays) * 24 * time.Hour
+	unused := time.Duration(c.unusedDays) * 24 * time.Hour
+
+	for _, entry := range entries {
+		entry.Flags = []string{}
+		switch {
+		case entry.ExpiresAt == nil:
+			entry.Flags = append(entry.Flags, tokenFlagNeverExpires)
+		case !entry.ExpiresAt.After(now):
+			entry.Flags = append(entry.Flags, tokenFlagExpired)
+		case entry.ExpiresAt.Sub(now) <= expiring:
+			entry.Flags = append(entry.Flags, tokenFlagExpiring)
+		}
+
+		// A token that was never used counts from when it was created.
+		lastActive := entry.CreatedAt
+		if entry.LastUsedAt != nil {
+			lastActive = *entry.LastUsedAt
+		}
+		if now.Sub(lastActive) > unused {
+			entry.Flags = append(entry.Flags, tokenFlagUnused)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Kind != b.Kind {
+			return tokenKindOrder[a.Kind] < tokenKindOrder[b.Kind]
+		}
+		if a.Owner != b.Owner {
+			return a.Owner < b.Owner
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return entries
+}
+
+func (c *TokenReportCommand) userService(client *client.Client) userReader {
+	if c.userSvc != nil {
+		return c.userSvc
+	}
+	return client.Users
+}
+
+func (c *TokenReportCommand) userTokenService(client *client.Client) userTokenLister {
+	if c.userTokenSvc != nil {
+		return c.userTokenSvc
+	}
+	return client.UserTokens
+}
+
+func (c *TokenReportCommand) teamService(client *client.Client) teamLister {
+	if c.teamSvc != nil {
+		return c.teamSvc
+	}
+	return client.Teams
+}
+
+func (c *TokenReportCommand) teamTokenService(client *client.Client) teamTokenLister {
+	if c.teamTokenSvc != nil {
+		return c.teamTokenSvc
+	}
+	return client.TeamTokens
+}
+
+func (c *TokenReportCommand) orgTokenService(client *client.Client) organizationTokenReader {
+	if c.orgTokenSvc != nil {
+		return c.orgTokenSvc
+	}
+	return client.OrganizationTokens
+}
+
+func (c *TokenReportCommand) agentPoolService(client *client.Client) agentPoolLister {
+	if c.agentPoolSvc != nil {
+		return c.agentPoolSvc
+	}
+	return client.AgentPools
+}
+
+func (c *TokenReportCommand) agentTokenService(client *client.Client) agentTokenLister {
+	if c.agentSvc != nil {
+		return c.agentSvc
+	}
+	return client.AgentTokens
+}
+
+// Help returns help text for the token report command
+func (c *TokenReportCommand) Help() string {
+	helpText := `
+Usage: hcptf token report [options]
+
+  Report on every API token in an organization in one place: the caller's
+  user tokens, team tokens, the organization token, the audit trail token,
+  and the tokens of every agent pool. Token values are never shown.
+
+  Each token is flagged with:
+
+    never-expires  The token has no expiry (agent tokens never expire)
+    expired        The token has already expired
+    expiring       The token expires within -expiring-days days
+    unused         The token has not been used in -unused-days days, or
+                   was never used and is older than that
+
+  The API only shows user tokens to their owner, so other members' user
+  tokens are not included. Token types the caller is not allowed to see
+  are skipped with a warning.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>           Alias for -organization
+  -expiring-days=<n>    Flag tokens that expire within this many days
+                        (default: 30)
+  -unused-days=<n>      Flag tokens not used in this many days (default: 90)
+  -flagged              Only show tokens with at least one flag
+  -output=<format>      Output format: table (default), json, or csv
+
+Example:
+
+  hcptf token report -org=my-org
+  hcptf token report -org=my-org -flagged -expiring-days=14
+  hcptf token report -org=my-org -output=csv > tokens.csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the token report command
+func (c *TokenReportCommand) Synopsis() string {
+	return "Report API tokens that never expire, expire soon, or are unused"
+}
@@ -0,0 +1,187 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockTeamTokenListService struct {
+	tokens []*tfe.TeamToken
+	err    error
+}
+
+func (m *mockTeamTokenListService) List(_ context.Context, _ string, _ *tfe.TeamTokenListOptions) (*tfe.TeamTokenList, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &tfe.TeamTokenList{Items: m.tokens}, nil
+}
+
+type mockOrganizationTokenReader struct {
+	token      *tfe.OrganizationToken
+	auditTrail *tfe.OrganizationToken
+}
+
+func (m *mockOrganizationTokenReader) Read(_ context.Context, _ string) (*tfe.OrganizationToken, error) {
+	if m.token == nil {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return m.token, nil
+}
+
+func (m *mockOrganizationTokenReader) ReadWithOptions(_ context.Context, _ string, _ tfe.OrganizationTokenReadOptions) (*tfe.OrganizationToken, error) {
+	if m.auditTrail == nil {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return m.auditTrail, nil
+}
+
+type mockAgentPoolListService struct {
+	pools []*tfe.AgentPool
+}
+
+func (m *mockAgentPoolListService) List(_ context.Context, _ string, _ *tfe.AgentPoolListOptions) (*tfe.AgentPoolList, error) {
+	return &tfe.AgentPoolList{Items: m.pools}, nil
+}
+
+type mockAgentTokenListService struct {
+	tokens map[string][]*tfe.AgentToken
+}
+
+func (m *mockAgentTokenListService) List(_ context.Context, agentPoolID string) (*tfe.AgentTokenList, error) {
+	return &tfe.AgentTokenList{Items: m.tokens[agentPoolID]}, nil
+}
+
+var tokenReportNow = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+func newTokenReportCommand(ui cli.Ui, teamTokens *mockTeamTokenListService) *TokenReportCommand {
+	days := func(n int) time.Time { return tokenReportNow.AddDate(0, 0, n) }
+	return &TokenReportCommand{
+		Meta:    newTestMeta(ui),
+		now:     func() time.Time { return tokenReportNow },
+		userSvc: &mockUserReadService{response: &tfe.User{ID: "user-1", Username: "alice"}},
+		userTokenSvc: &mockUserTokenListService{response: &tfe.UserTokenList{Items: []*tfe.UserToken{
+			{ID: "at-user", Description: "laptop", CreatedAt: days(-10), LastUsedAt: days(-1), ExpiredAt: days(60)},
+		}}},
+		teamSvc:      &mockTeamListService{response: &tfe.TeamList{Items: []*tfe.Team{{ID: "team-ci", Name: "ci"}}}},
+		teamTokenSvc: teamTokens,
+		orgTokenSvc: &mockOrganizationTokenReader{
+			token: &tfe.OrganizationToken{ID: "at-org", CreatedAt: days(-400), LastUsedAt: days(-200)},
+		},
+		agentPoolSvc: &mockAgentPoolListService{pools: []*tfe.AgentPool{{ID: "apool-1", Name: "on-prem"}}},
+		agentSvc: &mockAgentTokenListService{tokens: map[string][]*tfe.AgentToken{
+			"apool-1": {{ID: "at-agent", Description: "runner", CreatedAt: days(-5), LastUsedAt: days(0)}},
+		}},
+	}
+}
+
+func TestTokenReportFlagsTokens(t *testing.T) {
+	days := func(n int) time.Time { return tokenReportNow.AddDate(0, 0, n) }
+	ui := cli.NewMockUi()
+	cmd := newTokenReportCommand(ui, &mockTeamTokenListService{tokens: []*tfe.TeamToken{
+		{ID: "at-team", Description: tfe.String("deploy"), Team: &tfe.Team{ID: "team-ci"}, CreatedAt: days(-30), LastUsedAt: days(-2), ExpiredAt: days(7)},
+		{ID: "at-gone", Team: &tfe.Team{ID: "team-gone"}, CreatedAt: days(-100), ExpiredAt: days(-1)},
+	}})
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var entries []tokenReportEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+
+	got := map[string]string{}
+	var order []string
+	for _, entry := range entries {
+		got[entry.ID] = entry.Owner + " " + strings.Join(entry.Flags, ",")
+		order = append(order, entry.ID)
+	}
+	want := map[string]string{
+		"at-user":  "alice ",
+		"at-team":  "ci expiring",
+		"at-gone":  "team-gone expired,unused",
+		"at-org":   "my-org never-expires,unused",
+		"at-agent": "on-prem never-expires",
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("%s: got %q, want %q", id, got[id], w)
+		}
+	}
+	if strings.Join(order, ",") != "at-user,at-team,at-gone,at-org,at-agent" {
+		t.Fatalf("unexpected order: %v", order)
+	}
+}
+
+func TestTokenReportFlaggedOnlyCSV(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newTokenReportCommand(ui, &mockTeamTokenListService{})
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-flagged", "-output=csv"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if strings.Contains(output, "at-user") {
+		t.Fatalf("expected unflagged user token to be hidden, got %q", output)
+	}
+	if !strings.Contains(output, "at-org") || !strings.Contains(output, "at-agent") {
+		t.Fatalf("expected flagged tokens, got %q", output)
+	}
+}
+
+func TestTokenReportEmptyCSVHasHeader(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newTokenReportCommand(ui, &mockTeamTokenListService{})
+	cmd.orgTokenSvc = &mockOrganizationTokenReader{}
+	cmd.agentPoolSvc = &mockAgentPoolListService{}
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-flagged", "-output=csv"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if output != "Type,ID,Owner,Description,Created At,Last Used At,Expires At,Flags\n" {
+		t.Fatalf("expected only the CSV header, got %q", output)
+	}
+}
+
+func TestTokenReportSkipsUnauthorizedKinds(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newTokenReportCommand(ui, &mockTeamTokenListService{err: tfe.ErrUnauthorized})
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-org=my-org", "-output=json"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "skipping team tokens") {
+		t.Fatalf("expected warning, got %q", ui.ErrorWriter.String())
+	}
+	if !strings.Contains(output, "at-org") {
+		t.Fatalf("expected remaining tokens, got %q", output)
+	}
+}
+
+func TestTokenReportValidatesOutput(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newTokenReportCommand(ui, &mockTeamTokenListService{})
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=yaml"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
@@ -0,0 +1,436 @@
+package command
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	secretConfidenceLow    = "low"
+	secretConfidenceMedium = "medium"
+	secretConfidenceHigh   = "high"
+)
+
+// secretConfidenceRank orders confidence levels so -min-confidence can
+// compare them.
+var secretConfidenceRank = map[string]int{
+	secretConfidenceLow:    1,
+	secretConfidenceMedium: 2,
+	secretConfidenceHigh:   3,
+}
+
+// secretValuePattern is a value format that identifies a kind of secret.
+type secretValuePattern struct {
+	name       string
+	pattern    *regexp.Regexp
+	confidence string
+}
+
+var secretValuePatterns = []secretValuePattern{
+	{"an AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`), secretConfidenceHigh},
+	{"a private key", regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`), secretConfidenceHigh},
+	{"a JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]+`), secretConfidenceHigh},
+	{"a GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`), secretConfidenceHigh},
+	{"an HCP Terraform token", regexp.MustCompile(`\b[A-Za-z0-9]{14}\.atlasv1\.[A-Za-z0-9_-]{60,}`), secretConfidenceHigh},
+	{"a URL with embedded credentials", regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`), secretConfidenceHigh},
+}
+
+// secretEntropyExempt matches identifiers that are high-entropy but not
+// secret, such as URLs and ARNs.
+var secretEntropyExempt = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*://|arn:)`)
+
+// secretKeyPattern matches variable keys that conventionally hold secrets.
+var secretKeyPattern = regexp.MustCompile(`(?i)(TOKEN|PASSWORD|PASSWD|SECRET|PRIVATE_?KEY|API_?KEY|CREDENTIAL)`)
+
+// High-entropy strings of at least this length with at least this many
+// bits of entropy per character are reported.
+const (
+	secretEntropyMinLength = 24
+	secretEntropyThreshold = 4.0
+)
+
+// secretFinding is a non-sensitive variable whose key or value looks like a
+// secret. The value itself is never included.
+type secretFinding struct {
+	Type          string   `json:"type"`
+	Name          string   `json:"name"`
+	ParentID      string   `json:"parent_id"`
+	VariableID    string   `json:"variable_id"`
+	Key           string   `json:"key"`
+	Category      string   `json:"category"`
+	Confidence    string   `json:"confidence"`
+	Reasons       []string `json:"reasons"`
+	workspaceID   string
+	variableSetID string
+}
+
+// detectSecret returns the confidence that a variable holds a secret and
+// why, or "" if nothing suggests it does. Value formats outrank key names,
+// and a secret-like key name raises a high-entropy value to high.
+func detectSecret(key, value string) (string, []string) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+
+	confidence := ""
+	var reasons []string
+	raise := func(level, reason string) {
+		if secretConfidenceRank[level] > secretConfidenceRank[confidence] {
+			confidence = level
+		}
+		reasons = append(reasons, reason)
+	}
+
+	for _, p := range secretValuePatterns {
+		if p.pattern.MatchString(value) {
+			raise(p.confidence, "value looks like "+p.name)
+		}
+	}
+
+	highEntropy := false
+	if len(value) >= secretEntropyMinLength && !strings.ContainsAny(value, " \t\n") && !secretEntropyExempt.MatchString(value) {
+		if entropy := shannonEntropy(value); entropy >= secretEntropyThreshold {
+			highEntropy = true
+			raise(secretConfidenceMedium, fmt.Sprintf("high-entropy value (%.1f bits/char)", entropy))
+		}
+	}
+
+	if secretKeyPattern.MatchString(key) {
+		level := secretConfidenceLow
+		if highEntropy {
+			level = secretConfidenceHigh
+		}
+		raise(level, "key name suggests a secret")
+	}
+
+	return confidence, reasons
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// VariableAuditSecretsCommand is a command to find secrets stored in non-sensitive variables
+type VariableAuditSecretsCommand struct {
+	Meta
+	organization  string
+	minConfidence string
+	fix           bool
+	autoApprove   bool
+	concurrency   int
+	format        string
+	workspaceSvc  workspaceLister
+	variableSvc   variableListUpdater
+	varSetSvc     variableSetLister
+	varSetVarSvc  variableSetVariableListUpdater
+}
+
+// Run executes the variable audit-secrets command
+func (c *VariableAuditSecretsCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variable audit-secrets")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.minConfidence, "min-confidence", secretConfidenceLow, "Lowest confidence to report: low, medium, or high")
+	flags.BoolVar(&c.fix, "fix", false, "Mark the reported variables as sensitive")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation for -fix")
+	flags.IntVar(&c.concurrency, "concurrency", defaultStateSearchConcurrency, "Number of workspaces to read at once")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	threshold, ok := secretConfidenceRank[c.minConfidence]
+	if !ok {
+		c.Ui.Error("Error: -min-confidence must be one of: low, medium, high")
+		return 1
+	}
+
+	if c.concurrency < 1 {
+		c.Ui.Error("Error: -concurrency must be at least 1")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	sets, err := listAllVariableSets(ctx, c.varSetService(client), c.organization, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variable sets: %s", err))
+		return 1
+	}
+
+	var (
+		mu       sync.Mutex
+		findings []*secretFinding
+		failures []string
+	)
+	report := func(f *secretFinding, value string) {
+		confidence, reasons := detectSecret(f.Key, value)
+		if confidence == "" || secretConfidenceRank[confidence] < threshold {
+			return
+		}
+		f.Confidence = confidence
+		f.Reasons = reasons
+		findings = append(findings, f)
+	}
+
+	forEachWorkspace(workspaces, c.concurrency, func(ws *tfe.Workspace) {
+		variables, err := listAllVariables(ctx, c.variableService(client), ws.ID)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("skipping workspace %s: %s", ws.Name, err))
+			return
+		}
+		for _, v := range variables {
+			if v.Sensitive {
+				continue
+			}
+			report(&secretFinding{
+				Type:        "workspace",
+				Name:        ws.Name,
+				ParentID:    ws.ID,
+				VariableID:  v.ID,
+				Key:         v.Key,
+				Category:    string(v.Category),
+				workspaceID: ws.ID,
+			}, v.Value)
+		}
+	})
+
+	for _, set := range sets {
+		variables, err := listAllVariableSetVariables(ctx, c.varSetVarService(client), set.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("skipping variable set %s: %s", set.Name, err))
+			continue
+		}
+		for _, v := range variables {
+			if v.Sensitive {
+				continue
+			}
+			report(&secretFinding{
+				Type:          "variable_set",
+				Name:          set.Name,
+				ParentID:      set.ID,
+				VariableID:    v.ID,
+				Key:           v.Key,
+				Category:      string(v.Category),
+				variableSetID: set.ID,
+			}, v.Value)
+		}
+	}
+
+	sort.Strings(failures)
+	for _, failure := range failures {
+		c.Ui.Warn(fmt.Sprintf("Warning: %s", failure))
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if ri, rj := secretConfidenceRank[findings[i].Confidence], secretConfidenceRank[findings[j].Confidence]; ri != rj {
+			return ri > rj
+		}
+		if findings[i].Type != findings[j].Type {
+			return findings[i].Type > findings[j].Type
+		}
+		if findings[i].Name != findings[j].Name {
+			return findings[i].Name < findings[j].Name
+		}
+		return findings[i].Key < findings[j].Key
+	})
+
+	if c.fix && c.Meta.DryRun {
+		if findings == nil {
+			findings = []*secretFinding{}
+		}
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":    "mark-sensitive",
+			"resource":  "variable",
+			"variables": findings,
+		})
+		return 0
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if len(findings) == 0 {
+		if c.format == "json" {
+			formatter.JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("No secrets found in non-sensitive variables of %d workspaces and %d variable sets", len(workspaces), len(sets)))
+		return 0
+	}
+
+	if c.format == "json" {
+		formatter.JSON(findings)
+	} else {
+		headers := []string{"Type", "Name", "Key", "Category", "Confidence", "Reasons"}
+		var rows [][]string
+		for _, f := range findings {
+			kind := "workspace"
+			if f.Type == "variable_set" {
+				kind = "variable set"
+			}
+			rows = append(rows, []string{kind, f.Name, f.Key, f.Category, f.Confidence, strings.Join(f.Reasons, "; ")})
+		}
+		formatter.Table(headers, rows)
+	}
+
+	if !c.fix {
+		return 0
+	}
+
+	if !c.autoApprove {
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Do you want to mark these %d variables as sensitive? This cannot be undone.", len(findings)))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Fix cancelled.")
+			return 0
+		}
+	}
+
+	failed := 0
+	for _, f := range findings {
+		var err error
+		if f.workspaceID != "" {
+			_, err = c.variableService(client).Update(ctx, f.workspaceID, f.VariableID, tfe.VariableUpdateOptions{
+				Sensitive: tfe.Bool(true),
+			})
+		} else {
+			_, err = c.varSetVarService(client).Update(ctx, f.variableSetID, f.VariableID, &tfe.VariableSetVariableUpdateOptions{
+				Sensitive: tfe.Bool(true),
+			})
+		}
+		if err != nil {
+			failed++
+			c.Ui.Error(fmt.Sprintf("Error marking %s/%s sensitive: %s", f.Name, f.Key, err))
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Marked %d variables as sensitive", len(findings)-failed))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (c *VariableAuditSecretsCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *VariableAuditSecretsCommand) variableService(client *client.Client) variableListUpdater {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+func (c *VariableAuditSecretsCommand) varSetService(client *client.Client) variableSetLister {
+	if c.varSetSvc != nil {
+		return c.varSetSvc
+	}
+	return client.VariableSets
+}
+
+func (c *VariableAuditSecretsCommand) varSetVarService(client *client.Client) variableSetVariableListUpdater {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+// Help returns help text for the variable audit-secrets command
+func (c *VariableAuditSecretsCommand) Help() string {
+	helpText := `
+Usage: hcptf variable audit-secrets [options]
+
+  Scan the non-sensitive variables of every workspace and variable set in
+  an organization for values that look like secrets. Values are never
+  printed; each finding lists why it was reported and a confidence level:
+
+    high    The value matches a known secret format (AWS access key ID,
+            private key PEM block, JWT, GitHub or HCP Terraform token, URL
+            with a password), or is a high-entropy string stored under a
+            secret-like key name.
+    medium  The value is a long high-entropy string other than a URL or
+            ARN.
+    low     Only the key name suggests a secret (TOKEN, PASSWORD, SECRET,
+            API_KEY, PRIVATE_KEY, CREDENTIAL).
+
+  With -fix, the reported variables are marked sensitive after
+  confirmation. The change is made in place, so the key, value, and ID
+  stay the same, and it cannot be undone. Rotate any credential that was
+  exposed; marking it sensitive does not remove it from earlier run logs.
+
+Options:
+
+  -organization=<name>    Organization name (required)
+  -org=<name>            Alias for -organization
+  -min-confidence=<lvl>  Lowest confidence to report: low (default),
+                         medium, or high
+  -fix                   Mark the reported variables as sensitive
+  -auto-approve          Skip confirmation for -fix
+  -concurrency=<n>       Number of workspaces to read at once (default: 8)
+  -output=<format>       Output format: table (default), json, or csv
+
+Example:
+
+  hcptf variable audit-secrets -org=my-org
+  hcptf variable audit-secrets -org=my-org -min-confidence=high -fix
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variable audit-secrets command
+func (c *VariableAuditSecretsCommand) Synopsis() string {
+	return "Find secrets stored in non-sensitive variables"
+}
@@ -3,6 +3,8 @@ package command
 import (
 	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -103,3 +105,61 @@ func TestVariableCreateOutputsJSON(t *testing.T) {
 		t.Fatalf("unexpected data: %#v", data)
 	}
 }
+
+func TestVariableCreateRejectsSensitiveValueFlag(t *testing.T) {
+	ui := cli.NewMockUi()
+	vars := &mockVariableCreateService{}
+	cmd := newVariableCreateCommand(ui, &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1"}}, vars)
+
+	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-key=password", "-value=hunter2", "-sensitive"}); code != 1 {
+		t.Fatalf("expected exit 1")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cannot be passed with -value") {
+		t.Fatalf("expected -value error, got %q", ui.ErrorWriter.String())
+	}
+	if vars.lastWorkspace != "" {
+		t.Fatalf("expected no API call")
+	}
+}
+
+func TestVariableCreateReadsValueFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := cli.NewMockUi()
+	vars := &mockVariableCreateService{response: &tfe.Variable{ID: "var-1", Key: "password", Sensitive: true}}
+	cmd := newVariableCreateCommand(ui, &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1"}}, vars)
+
+	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-key=password", "-value-file=" + path, "-sensitive"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if vars.lastOptions.Value == nil || *vars.lastOptions.Value != "hunter2" {
+		t.Fatalf("expected value from file without trailing newline, got %#v", vars.lastOptions.Value)
+	}
+	if strings.Contains(ui.OutputWriter.String(), "hunter2") {
+		t.Fatalf("value should not be printed")
+	}
+}
+
+func TestVariableCreateDryRunRedactsValue(t *testing.T) {
+	t.Setenv("TEST_VARIABLE_VALUE", "hunter2")
+
+	ui := cli.NewMockUi()
+	vars := &mockVariableCreateService{}
+	cmd := newVariableCreateCommand(ui, &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1"}}, vars)
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-key=password", "-value-env=TEST_VARIABLE_VALUE", "-sensitive", "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if strings.Contains(output, "hunter2") {
+		t.Fatalf("dry-run output contains the value: %s", output)
+	}
+	if !strings.Contains(output, redactedValue) {
+		t.Fatalf("expected redacted value in %s", output)
+	}
+}
@@ -0,0 +1,394 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	variableScopeWorkspace = "workspace"
+	variableScopeProject   = "project"
+	variableScopeGlobal    = "global"
+)
+
+// variableScopeRank orders variable set scopes from least to most specific.
+var variableScopeRank = map[string]int{
+	variableScopeGlobal:    1,
+	variableScopeProject:   2,
+	variableScopeWorkspace: 3,
+}
+
+// variableSource is where a variable value comes from: the workspace itself
+// or a variable set applied to it.
+type variableSource struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Scope    string `json:"scope"`
+	Priority bool   `json:"priority"`
+}
+
+func (s variableSource) String() string {
+	if s.Type == variableScopeWorkspace {
+		return "workspace"
+	}
+	label := fmt.Sprintf("variable set '%s' (%s", s.Name, s.Scope)
+	if s.Priority {
+		label += ", priority"
+	}
+	return label + ")"
+}
+
+// rank orders sources by HCP Terraform precedence: priority variable sets
+// override everything, then workspace variables, then the remaining
+// variable sets from most to least specific scope.
+func (s variableSource) rank() int {
+	switch {
+	case s.Priority:
+		return 20 + variableScopeRank[s.Scope]
+	case s.Type == variableScopeWorkspace:
+		return 10
+	}
+	return variableScopeRank[s.Scope]
+}
+
+// variableCandidate is one definition of a key from one source.
+type variableCandidate struct {
+	Source    variableSource `json:"source"`
+	Value     string         `json:"value"`
+	Sensitive bool           `json:"sensitive"`
+	HCL       bool           `json:"hcl"`
+}
+
+// effectiveVariable is the value a run would see for one key, and the
+// lower-precedence definitions it overrides.
+type effectiveVariable struct {
+	Key       string               `json:"key"`
+	Category  string               `json:"category"`
+	Value     string               `json:"value"`
+	Sensitive bool                 `json:"sensitive"`
+	HCL       bool                 `json:"hcl"`
+	Source    variableSource       `json:"source"`
+	Overrides []*variableCandidate `json:"overrides"`
+}
+
+// appliedVariableSet is a variable set that applies to a workspace, with
+// the most specific scope through which it applies.
+type appliedVariableSet struct {
+	set       *tfe.VariableSet
+	scope     string
+	variables []*tfe.VariableSetVariable
+}
+
+// variableSetScopeFor returns how a variable set applies to a workspace, or
+// "" if it does not.
+func variableSetScopeFor(set *tfe.VariableSet, ws *tfe.Workspace) string {
+	for _, w := range set.Workspaces {
+		if w != nil && w.ID == ws.ID {
+			return variableScopeWorkspace
+		}
+	}
+	if ws.Project != nil {
+		for _, p := range set.Projects {
+			if p != nil && p.ID == ws.Project.ID {
+				return variableScopeProject
+			}
+		}
+	}
+	if set.Global {
+		return variableScopeGlobal
+	}
+	return ""
+}
+
+// resolveEffectiveVariables merges workspace variables and applied variable
+// sets by category and key. Conflicting variable sets at the same precedence
+// are decided by set name in lexical order, as HCP Terraform does.
+func resolveEffectiveVariables(variables []*tfe.Variable, sets []*appliedVariableSet) []*effectiveVariable {
+	type candidate struct {
+		*variableCandidate
+		key      string
+		category string
+	}
+
+	candidates := map[string][]*candidate{}
+	add := func(key, category string, c *variableCandidate) {
+		id := category + "/" + key
+		candidates[id] = append(candidates[id], &candidate{variableCandidate: c, key: key, category: category})
+	}
+
+	for _, v := range variables {
+		add(v.Key, string(v.Category), &variableCandidate{
+			Source:    variableSource{Type: variableScopeWorkspace, Scope: variableScopeWorkspace},
+			Value:     v.Value,
+			Sensitive: v.Sensitive,
+			HCL:       v.HCL,
+		})
+	}
+	for _, applied := range sets {
+		source := variableSource{
+			Type:     "variable_set",
+			ID:       applied.set.ID,
+			Name:     applied.set.Name,
+			Scope:    applied.scope,
+			Priority: applied.set.Priority,
+		}
+		for _, v := range applied.variables {
+			add(v.Key, string(v.Category), &variableCandidate{
+				Source:    source,
+				Value:     v.Value,
+				Sensitive: v.Sensitive,
+				HCL:       v.HCL,
+			})
+		}
+	}
+
+	var result []*effectiveVariable
+	for _, list := range candidates {
+		sort.SliceStable(list, func(i, j int) bool {
+			if ri, rj := list[i].Source.rank(), list[j].Source.rank(); ri != rj {
+				return ri > rj
+			}
+			return list[i].Source.Name < list[j].Source.Name
+		})
+
+		winner := list[0]
+		effective := &effectiveVariable{
+			Key:       winner.key,
+			Category:  winner.category,
+			Value:     winner.Value,
+			Sensitive: winner.Sensitive,
+			HCL:       winner.HCL,
+			Source:    winner.Source,
+			Overrides: []*variableCandidate{},
+		}
+		for _, c := range list[1:] {
+			effective.Overrides = append(effective.Overrides, c.variableCandidate)
+		}
+		result = append(result, effective)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Category != result[j].Category {
+			return result[i].Category > result[j].Category
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func maskedVariableValue(value string, sensitive bool) string {
+	if sensitive {
+		return "(sensitive)"
+	}
+	return value
+}
+
+// VariableEffectiveCommand is a command to show the variables a workspace's runs will see
+type VariableEffectiveCommand struct {
+	Meta
+	organization string
+	workspace    string
+	key          string
+	format       string
+	workspaceSvc workspaceReader
+	variableSvc  variableLister
+	varSetSvc    variableSetLister
+	varSetVarSvc variableSetVariableLister
+}
+
+// Run executes the variable effective command
+func (c *VariableEffectiveCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variable effective")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.StringVar(&c.key, "key", "", "Only show this variable key")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	variables, err := listAllVariables(ctx, c.variableService(client), ws.ID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variables: %s", err))
+		return 1
+	}
+
+	sets, err := listAllVariableSets(ctx, c.varSetService(client), c.organization, &tfe.VariableSetListOptions{
+		Include: "workspaces,projects",
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variable sets: %s", err))
+		return 1
+	}
+
+	var applied []*appliedVariableSet
+	for _, set := range sets {
+		scope := variableSetScopeFor(set, ws)
+		if scope == "" {
+			continue
+		}
+		vars, err := listAllVariableSetVariables(ctx, c.varSetVarService(client), set.ID)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing variables in variable set %s: %s", set.Name, err))
+			return 1
+		}
+		applied = append(applied, &appliedVariableSet{set: set, scope: scope, variables: vars})
+	}
+
+	effective := resolveEffectiveVariables(variables, applied)
+	if c.key != "" {
+		var selected []*effectiveVariable
+		for _, v := range effective {
+			if v.Key == c.key {
+				selected = append(selected, v)
+			}
+		}
+		if len(selected) == 0 {
+			c.Ui.Error(fmt.Sprintf("Error: variable %q is not set in workspace '%s' or any variable set applied to it", c.key, ws.Name))
+			return 1
+		}
+		effective = selected
+	}
+
+	// Sensitive values are never returned by the API, but mask them
+	// explicitly so nothing is printed if that changes.
+	for _, v := range effective {
+		v.Value = maskedVariableValue(v.Value, v.Sensitive)
+		for _, o := range v.Overrides {
+			o.Value = maskedVariableValue(o.Value, o.Sensitive)
+		}
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if c.format == "json" {
+		if effective == nil {
+			effective = []*effectiveVariable{}
+		}
+		formatter.JSON(effective)
+		return 0
+	}
+
+	if len(effective) == 0 {
+		c.Ui.Output(fmt.Sprintf("No variables apply to workspace '%s'", ws.Name))
+		return 0
+	}
+
+	headers := []string{"Key", "Category", "Value", "Source", "Overrides"}
+	var rows [][]string
+	for _, v := range effective {
+		var overrides []string
+		for _, o := range v.Overrides {
+			overrides = append(overrides, fmt.Sprintf("%s = %s", o.Source, o.Value))
+		}
+		rows = append(rows, []string{v.Key, v.Category, v.Value, v.Source.String(), strings.Join(overrides, "; ")})
+	}
+	formatter.Table(headers, rows)
+	return 0
+}
+
+func (c *VariableEffectiveCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *VariableEffectiveCommand) variableService(client *client.Client) variableLister {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+func (c *VariableEffectiveCommand) varSetService(client *client.Client) variableSetLister {
+	if c.varSetSvc != nil {
+		return c.varSetSvc
+	}
+	return client.VariableSets
+}
+
+func (c *VariableEffectiveCommand) varSetVarService(client *client.Client) variableSetVariableLister {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+// Help returns help text for the variable effective command
+func (c *VariableEffectiveCommand) Help() string {
+	helpText := `
+Usage: hcptf variable effective [options]
+
+  Show the variables a run in a workspace will see, merged from workspace
+  variables and every variable set applied to the workspace, and for each
+  key the source that wins and the values it overrides.
+
+  Precedence follows HCP Terraform, from highest to lowest:
+
+    1. Priority variable sets
+    2. Workspace variables
+    3. Variable sets assigned to the workspace
+    4. Variable sets assigned to the workspace's project
+    5. Global variable sets
+
+  Priority variable sets are ordered among themselves by the same scopes.
+  When variable sets at the same level define the same key, the set whose
+  name comes first in lexical order wins. Terraform and environment
+  variables are resolved separately. Values passed on the command line or
+  through the run API are not included.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required)
+  -key=<name>          Only show this variable key
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf variable effective -org=my-org -workspace=prod
+  hcptf variable effective -org=my-org -workspace=prod -key=region
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variable effective command
+func (c *VariableEffectiveCommand) Synopsis() string {
+	return "Show the variables a workspace's runs will see and where they come from"
+}
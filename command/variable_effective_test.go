@@ -0,0 +1,121 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newVariableEffectiveCommand(ui cli.Ui) *VariableEffectiveCommand {
+	ws := &tfe.Workspace{ID: "ws-1", Name: "prod", Project: &tfe.Project{ID: "prj-1"}}
+	sets := []*tfe.VariableSet{
+		{ID: "varset-global", Name: "defaults", Global: true},
+		{ID: "varset-project", Name: "network", Projects: []*tfe.Project{{ID: "prj-1"}}},
+		{ID: "varset-ws-b", Name: "b-overrides", Workspaces: []*tfe.Workspace{{ID: "ws-1"}}},
+		{ID: "varset-ws-a", Name: "a-overrides", Workspaces: []*tfe.Workspace{{ID: "ws-1"}}},
+		{ID: "varset-guard", Name: "guardrails", Global: true, Priority: true},
+		{ID: "varset-other", Name: "other", Projects: []*tfe.Project{{ID: "prj-2"}}},
+	}
+	return &VariableEffectiveCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: &mockWorkspaceReader{workspace: ws},
+		variableSvc: &mockVariableListByWorkspaceService{variables: map[string][]*tfe.Variable{
+			"ws-1": {
+				{Key: "region", Value: "us-west-2", Category: tfe.CategoryTerraform},
+				{Key: "instance_type", Value: "t3.large", Category: tfe.CategoryTerraform},
+			},
+		}},
+		varSetSvc: &mockVariableSetListService{response: &tfe.VariableSetList{Items: sets}},
+		varSetVarSvc: &mockVariableSetVariableListService{variables: map[string][]*tfe.VariableSetVariable{
+			"varset-global": {
+				{Key: "region", Value: "us-east-1", Category: tfe.CategoryTerraform},
+				{Key: "AWS_REGION", Value: "us-east-1", Category: tfe.CategoryEnv},
+			},
+			"varset-project": {
+				{Key: "region", Value: "eu-west-1", Category: tfe.CategoryTerraform},
+				{Key: "vpc_cidr", Value: "10.0.0.0/16", Category: tfe.CategoryTerraform},
+			},
+			"varset-ws-b": {{Key: "vpc_cidr", Value: "10.2.0.0/16", Category: tfe.CategoryTerraform}},
+			"varset-ws-a": {{Key: "vpc_cidr", Value: "10.1.0.0/16", Category: tfe.CategoryTerraform}},
+			"varset-guard": {
+				{Key: "instance_type", Value: "t3.micro", Category: tfe.CategoryTerraform},
+				{Key: "api_token", Category: tfe.CategoryTerraform, Sensitive: true},
+			},
+			"varset-other": {{Key: "region", Value: "ap-south-1", Category: tfe.CategoryTerraform}},
+		}},
+	}
+}
+
+func TestVariableEffectivePrecedence(t *testing.T) {
+	ui := cli.NewMockUi()
+
+	if code := newVariableEffectiveCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var result []*effectiveVariable
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	got := map[string]*effectiveVariable{}
+	for _, v := range result {
+		got[v.Category+"/"+v.Key] = v
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected 5 effective variables, got %d", len(got))
+	}
+
+	region := got["terraform/region"]
+	if region.Value != "us-west-2" || region.Source.Type != "workspace" || len(region.Overrides) != 2 {
+		t.Fatalf("expected workspace region to win over two sets, got %+v", region)
+	}
+	if region.Overrides[0].Source.Name != "network" || region.Overrides[1].Source.Name != "defaults" {
+		t.Fatalf("expected project set before global set, got %+v %+v", region.Overrides[0].Source, region.Overrides[1].Source)
+	}
+
+	if v := got["terraform/instance_type"]; v.Value != "t3.micro" || !v.Source.Priority || v.Overrides[0].Value != "t3.large" {
+		t.Fatalf("expected priority set to override workspace variable, got %+v", v)
+	}
+
+	if v := got["terraform/vpc_cidr"]; v.Value != "10.1.0.0/16" || v.Source.Name != "a-overrides" || len(v.Overrides) != 2 {
+		t.Fatalf("expected lexically first workspace set to win, got %+v", v)
+	}
+
+	if v := got["terraform/api_token"]; v.Value != "(sensitive)" {
+		t.Fatalf("expected sensitive value to be masked, got %+v", v)
+	}
+	if v := got["env/AWS_REGION"]; v.Value != "us-east-1" || v.Source.Scope != "global" {
+		t.Fatalf("unexpected env variable %+v", v)
+	}
+}
+
+func TestVariableEffectiveKeyTable(t *testing.T) {
+	ui := cli.NewMockUi()
+
+	if code := newVariableEffectiveCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-key=region"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	output := ui.OutputWriter.String()
+	for _, want := range []string{"us-west-2", "variable set 'network' (project) = eu-west-1", "variable set 'defaults' (global) = us-east-1"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "ap-south-1") || strings.Contains(output, "vpc_cidr") {
+		t.Fatalf("unexpected variables in output:\n%s", output)
+	}
+}
+
+func TestVariableEffectiveUnknownKey(t *testing.T) {
+	ui := cli.NewMockUi()
+
+	if code := newVariableEffectiveCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-key=missing"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `"missing" is not set`) {
+		t.Fatalf("unexpected error %q", ui.ErrorWriter.String())
+	}
+}
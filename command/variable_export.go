@@ -0,0 +1,164 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// VariableExportCommand is a command to write workspace variables to a file
+type VariableExportCommand struct {
+	Meta
+	organization string
+	workspace    string
+	file         string
+	fileFormat   string
+	workspaceSvc workspaceReader
+	variableSvc  variableLister
+}
+
+// Run executes the variable export command
+func (c *VariableExportCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variable export")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.StringVar(&c.file, "file", "", "File to write (default: stdout)")
+	flags.StringVar(&c.fileFormat, "format", "", "File format: tfvars, env, or json (default: from the file name, or tfvars)")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	fileFormat := c.fileFormat
+	if fileFormat == "" && c.file == "" {
+		fileFormat = variableFileFormatTfvars
+	}
+	fileFormat, err := variableFileFormat(c.file, fileFormat)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	variables, err := listAllVariables(ctx, c.variableService(client), ws.ID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variables: %s", err))
+		return 1
+	}
+
+	categories := map[string]bool{}
+	for _, category := range variableFileCategories(fileFormat) {
+		categories[category] = true
+	}
+
+	var specs []*variableSpec
+	for _, v := range variables {
+		if !categories[string(v.Category)] {
+			continue
+		}
+		if v.Sensitive {
+			c.Ui.Warn(fmt.Sprintf("Warning: skipping sensitive %s variable %s", v.Category, v.Key))
+			continue
+		}
+		specs = append(specs, variableSpecFromVariable(v))
+	}
+	sortVariableSpecs(specs)
+
+	content, err := renderVariableFile(c.file, fileFormat, specs)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Output(strings.TrimSuffix(content, "\n"))
+		return 0
+	}
+
+	if err := os.WriteFile(c.file, []byte(content), 0644); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing file: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Exported %d variables from workspace '%s' to %s", len(specs), ws.Name, c.file))
+	return 0
+}
+
+func (c *VariableExportCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *VariableExportCommand) variableService(client *client.Client) variableLister {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+// Help returns help text for the variable export command
+func (c *VariableExportCommand) Help() string {
+	helpText := `
+Usage: hcptf variable export [options]
+
+  Write a workspace's variables to a file that variable import can read.
+  Sensitive variables are skipped, since their values cannot be read.
+
+  tfvars files hold Terraform variables, env files hold environment
+  variables, and json files hold both along with their category, HCL flag,
+  and description. A -file ending in .tfvars.json is written in JSON
+  syntax, with HCL values converted to JSON.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required)
+  -file=<path>         File to write (default: stdout)
+  -format=<format>     File format: tfvars, env, or json (default: from
+                       the file name, or tfvars for stdout)
+
+Example:
+
+  hcptf variable export -org=my-org -workspace=prod > prod.tfvars
+  hcptf variable export -org=my-org -workspace=prod -file=vars.json
+  hcptf variable export -org=my-org -workspace=prod -file=vars.json && \
+    hcptf variable import -org=my-org -workspace=staging -file=vars.json
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variable export command
+func (c *VariableExportCommand) Synopsis() string {
+	return "Write workspace variables to a tfvars, env, or JSON file"
+}
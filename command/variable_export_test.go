@@ -0,0 +1,106 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newVariableExportCommand(ui cli.Ui) *VariableExportCommand {
+	return &VariableExportCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "prod"}},
+		variableSvc: &mockVariableListByWorkspaceService{variables: map[string][]*tfe.Variable{
+			"ws-1": {
+				{ID: "var-1", Key: "region", Value: "us-east-1", Category: tfe.CategoryTerraform},
+				{ID: "var-2", Key: "zones", Value: `["a", "b"]`, Category: tfe.CategoryTerraform, HCL: true},
+				{ID: "var-3", Key: "db_password", Category: tfe.CategoryTerraform, Sensitive: true},
+				{ID: "var-4", Key: "AWS_REGION", Value: "us-east-1", Category: tfe.CategoryEnv},
+			},
+		}},
+	}
+}
+
+func TestVariableExportTfvarsToStdout(t *testing.T) {
+	ui := cli.NewMockUi()
+
+	if code := newVariableExportCommand(ui).Run([]string{"-org=my-org", "-workspace=prod"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	want := "region = \"us-east-1\"\nzones = [\"a\", \"b\"]\n"
+	if ui.OutputWriter.String() != want {
+		t.Fatalf("unexpected tfvars:\n%s", ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "skipping sensitive terraform variable db_password") {
+		t.Fatalf("expected sensitive warning, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestVariableExportJSONFileRoundTrips(t *testing.T) {
+	ui := cli.NewMockUi()
+	path := filepath.Join(t.TempDir(), "vars.json")
+
+	if code := newVariableExportCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, err := parseVariableFile(path, variableFileFormatJSON, data)
+	if err != nil {
+		t.Fatalf("exported JSON did not parse: %v", err)
+	}
+	if len(specs) != 3 || specs[2].Key != "AWS_REGION" || specs[2].Category != "env" || !specs[1].HCL {
+		t.Fatalf("unexpected round trip %+v", specs)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Exported 3 variables") {
+		t.Fatalf("unexpected output %q", ui.OutputWriter.String())
+	}
+}
+
+func TestVariableExportTfvarsJSONRoundTrips(t *testing.T) {
+	ui := cli.NewMockUi()
+	path := filepath.Join(t.TempDir(), "vars.tfvars.json")
+
+	if code := newVariableExportCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	ui = cli.NewMockUi()
+	variables := &mockVariableSyncService{variables: map[string][]*tfe.Variable{"ws-1": {}}}
+	cmd := &VariableImportCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "prod"}},
+		variableSvc:  variables,
+	}
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path, "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exported file to import, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	got := map[string]string{}
+	for _, options := range variables.created {
+		got[*options.Key] = fmt.Sprintf("%s hcl=%t", *options.Value, *options.HCL)
+	}
+	if len(got) != 2 || got["region"] != "us-east-1 hcl=false" || got["zones"] != `["a", "b"] hcl=true` {
+		t.Fatalf("unexpected imported variables %v", got)
+	}
+}
+
+func TestVariableExportEnv(t *testing.T) {
+	ui := cli.NewMockUi()
+
+	if code := newVariableExportCommand(ui).Run([]string{"-org=my-org", "-workspace=prod", "-format=env"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if ui.OutputWriter.String() != "AWS_REGION=\"us-east-1\"\n" {
+		t.Fatalf("unexpected env output %q", ui.OutputWriter.String())
+	}
+}
@@ -0,0 +1,434 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/joho/godotenv"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	variableFileFormatTfvars = "tfvars"
+	variableFileFormatEnv    = "env"
+	variableFileFormatJSON   = "json"
+)
+
+const (
+	variableChangeCreate = "create"
+	variableChangeUpdate = "update"
+	variableChangeDelete = "delete"
+)
+
+// variableSpec is a variable as read from or written to a file. ID is set
+// only for variables that already exist.
+type variableSpec struct {
+	ID          string `json:"-"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Category    string `json:"category"`
+	HCL         bool   `json:"hcl"`
+	Sensitive   bool   `json:"sensitive"`
+	Description string `json:"description,omitempty"`
+}
+
+// variableChange is one create, update, or delete needed to make the existing
+// variables match a file.
+type variableChange struct {
+	Action   string        `json:"action"`
+	Key      string        `json:"key"`
+	Category string        `json:"category"`
+	Before   string        `json:"before,omitempty"`
+	After    string        `json:"after,omitempty"`
+	Current  *variableSpec `json:"-"`
+	Desired  *variableSpec `json:"-"`
+}
+
+func variableSpecFromVariable(v *tfe.Variable) *variableSpec {
+	return &variableSpec{
+		ID:          v.ID,
+		Key:         v.Key,
+		Value:       v.Value,
+		Category:    string(v.Category),
+		HCL:         v.HCL,
+		Sensitive:   v.Sensitive,
+		Description: v.Description,
+	}
+}
+
+// variableFileFormat returns the format of a variables file from its
+// extension, unless format is already set.
+func variableFileFormat(filename, format string) (string, error) {
+	if format != "" {
+		switch format {
+		case variableFileFormatTfvars, variableFileFormatEnv, variableFileFormatJSON:
+			return format, nil
+		}
+		return "", fmt.Errorf("-format must be one of: tfvars, env, json")
+	}
+
+	base := strings.ToLower(filepath.Base(filename))
+	switch {
+	case strings.HasSuffix(base, ".tfvars"), strings.HasSuffix(base, ".tfvars.json"):
+		return variableFileFormatTfvars, nil
+	case strings.HasSuffix(base, ".json"):
+		return variableFileFormatJSON, nil
+	case base == ".env", strings.HasPrefix(base, ".env."), strings.HasSuffix(base, ".env"):
+		return variableFileFormatEnv, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s from its name; use -format=tfvars, env, or json", filename)
+}
+
+// parseVariableFile reads variables from a tfvars, dotenv, or JSON file.
+// tfvars values that are not strings, numbers, or bools are kept as HCL
+// source and marked HCL; dotenv files hold environment variables; JSON files
+// are a list of variable objects as written by variable export.
+func parseVariableFile(filename, format string, data []byte) ([]*variableSpec, error) {
+	var specs []*variableSpec
+	var err error
+	switch format {
+	case variableFileFormatTfvars:
+		specs, err = parseTfvarsFile(filename, data)
+	case variableFileFormatEnv:
+		specs, err = parseDotenvFile(data)
+	case variableFileFormatJSON:
+		specs, err = parseVariableJSONFile(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		id := spec.Category + "/" + spec.Key
+		if seen[id] {
+			return nil, fmt.Errorf("%s variable %q is defined more than once", spec.Category, spec.Key)
+		}
+		seen[id] = true
+	}
+	sortVariableSpecs(specs)
+	return specs, nil
+}
+
+func parseTfvarsFile(filename string, data []byte) ([]*variableSpec, error) {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		file, diags = hcljson.Parse(data, filename)
+	} else {
+		file, diags = hclsyntax.ParseConfig(data, filename, hcl.InitialPos)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var specs []*variableSpec
+	for name, attr := range attrs {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if value.IsNull() {
+			return nil, fmt.Errorf("%s: variable %q is null", attr.Range, name)
+		}
+
+		spec := &variableSpec{Key: name, Category: string(tfe.CategoryTerraform)}
+		switch value.Type() {
+		case cty.String:
+			spec.Value = value.AsString()
+		case cty.Number:
+			spec.Value = value.AsBigFloat().Text('f', -1)
+		case cty.Bool:
+			spec.Value = fmt.Sprintf("%t", value.True())
+		default:
+			spec.Value = strings.TrimSpace(string(attr.Expr.Range().SliceBytes(data)))
+			spec.HCL = true
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func parseDotenvFile(data []byte) ([]*variableSpec, error) {
+	values, err := godotenv.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []*variableSpec
+	for key, value := range values {
+		specs = append(specs, &variableSpec{Key: key, Value: value, Category: string(tfe.CategoryEnv)})
+	}
+	return specs, nil
+}
+
+func parseVariableJSONFile(data []byte) ([]*variableSpec, error) {
+	var specs []*variableSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, err
+	}
+	for i, spec := range specs {
+		if spec == nil || spec.Key == "" {
+			return nil, fmt.Errorf("variable %d has no key", i+1)
+		}
+		if spec.Category == "" {
+			spec.Category = string(tfe.CategoryTerraform)
+		}
+		if spec.Category != string(tfe.CategoryTerraform) && spec.Category != string(tfe.CategoryEnv) {
+			return nil, fmt.Errorf("variable %q: category must be 'terraform' or 'env'", spec.Key)
+		}
+	}
+	return specs, nil
+}
+
+// renderVariableFile writes variables in one of the file formats. tfvars
+// holds only Terraform variables and dotenv only environment variables. A
+// tfvars filename ending in .json gets JSON syntax, so it can be read back.
+func renderVariableFile(filename, format string, specs []*variableSpec) (string, error) {
+	if format == variableFileFormatTfvars && strings.HasSuffix(strings.ToLower(filename), ".json") {
+		return renderTfvarsJSON(specs)
+	}
+
+	switch format {
+	case variableFileFormatJSON:
+		if specs == nil {
+			specs = []*variableSpec{}
+		}
+		data, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case variableFileFormatTfvars, variableFileFormatEnv:
+		var b strings.Builder
+		for _, spec := range specs {
+			if format == variableFileFormatTfvars {
+				value := spec.Value
+				if !spec.HCL {
+					value = quoteHCLString(value)
+				}
+				fmt.Fprintf(&b, "%s = %s\n", spec.Key, value)
+				continue
+			}
+			fmt.Fprintf(&b, "%s=%s\n", spec.Key, quoteDotenvValue(spec.Value))
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unsupported format %q", format)
+}
+
+// renderTfvarsJSON writes Terraform variables as a .tfvars.json object. HCL
+// values are evaluated and written as the equivalent JSON. Strings in HCL
+// JSON are templates, so ${ and %{ are escaped as in native syntax.
+func renderTfvarsJSON(specs []*variableSpec) (string, error) {
+	if len(specs) == 0 {
+		return "{}\n", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i, spec := range specs {
+		value := quoteHCLString(spec.Value)
+		if spec.HCL {
+			expr, diags := hclsyntax.ParseExpression([]byte(spec.Value), spec.Key, hcl.InitialPos)
+			if diags.HasErrors() {
+				return "", fmt.Errorf("variable %q: %s", spec.Key, diags.Error())
+			}
+			v, diags := expr.Value(nil)
+			if diags.HasErrors() {
+				return "", fmt.Errorf("variable %q cannot be written as JSON: %s", spec.Key, diags.Error())
+			}
+			var vb strings.Builder
+			if err := writeTfvarsJSONValue(&vb, v); err != nil {
+				return "", fmt.Errorf("variable %q cannot be written as JSON: %w", spec.Key, err)
+			}
+			value = vb.String()
+		}
+		fmt.Fprintf(&b, "  %s: %s", quoteHCLString(spec.Key), value)
+		if i < len(specs)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// writeTfvarsJSONValue writes a known cty value as HCL JSON.
+func writeTfvarsJSONValue(b *strings.Builder, v cty.Value) error {
+	if !v.IsWhollyKnown() {
+		return fmt.Errorf("value is not known")
+	}
+	if v.IsNull() {
+		b.WriteString("null")
+		return nil
+	}
+
+	ty := v.Type()
+	switch {
+	case ty == cty.String:
+		b.WriteString(quoteHCLString(v.AsString()))
+	case ty == cty.Number:
+		b.WriteString(v.AsBigFloat().Text('f', -1))
+	case ty == cty.Bool:
+		fmt.Fprintf(b, "%t", v.True())
+	case ty.IsListType(), ty.IsSetType(), ty.IsTupleType():
+		b.WriteString("[")
+		for it, i := v.ElementIterator(), 0; it.Next(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			_, elem := it.Element()
+			if err := writeTfvarsJSONValue(b, elem); err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+	case ty.IsMapType(), ty.IsObjectType():
+		b.WriteString("{")
+		for it, i := v.ElementIterator(), 0; it.Next(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			key, elem := it.Element()
+			b.WriteString(quoteHCLString(key.AsString()))
+			b.WriteString(": ")
+			if err := writeTfvarsJSONValue(b, elem); err != nil {
+				return err
+			}
+		}
+		b.WriteString("}")
+	default:
+		return fmt.Errorf("unsupported type %s", ty.FriendlyName())
+	}
+	return nil
+}
+
+// variableFileCategories returns the categories a format can hold.
+func variableFileCategories(format string) []string {
+	switch format {
+	case variableFileFormatTfvars:
+		return []string{string(tfe.CategoryTerraform)}
+	case variableFileFormatEnv:
+		return []string{string(tfe.CategoryEnv)}
+	}
+	return []string{string(tfe.CategoryTerraform), string(tfe.CategoryEnv)}
+}
+
+// planVariableChanges compares desired variables with existing ones by
+// category and key. Existing sensitive values cannot be read, so they are
+// always updated. With prune, existing variables in the given categories
+// that are not desired are deleted.
+func planVariableChanges(desired, existing []*variableSpec, prune bool, categories []string) []*variableChange {
+	current := map[string]*variableSpec{}
+	for _, spec := range existing {
+		current[spec.Category+"/"+spec.Key] = spec
+	}
+
+	var changes []*variableChange
+	wanted := map[string]bool{}
+	for _, spec := range desired {
+		id := spec.Category + "/" + spec.Key
+		wanted[id] = true
+
+		have, ok := current[id]
+		if !ok {
+			changes = append(changes, &variableChange{
+				Action:   variableChangeCreate,
+				Key:      spec.Key,
+				Category: spec.Category,
+				After:    variableDisplayValue(spec),
+				Desired:  spec,
+			})
+			continue
+		}
+
+		if !have.Sensitive && have.Value == spec.Value && have.HCL == spec.HCL &&
+			(!spec.Sensitive || have.Sensitive) && (spec.Description == "" || have.Description == spec.Description) {
+			continue
+		}
+		changes = append(changes, &variableChange{
+			Action:   variableChangeUpdate,
+			Key:      spec.Key,
+			Category: spec.Category,
+			Before:   variableDisplayValue(have),
+			After:    variableDisplayValue(spec),
+			Current:  have,
+			Desired:  spec,
+		})
+	}
+
+	if prune {
+		inScope := map[string]bool{}
+		for _, category := range categories {
+			inScope[category] = true
+		}
+		for _, spec := range existing {
+			if !inScope[spec.Category] || wanted[spec.Category+"/"+spec.Key] {
+				continue
+			}
+			changes = append(changes, &variableChange{
+				Action:   variableChangeDelete,
+				Key:      spec.Key,
+				Category: spec.Category,
+				Before:   variableDisplayValue(spec),
+				Current:  spec,
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Category != changes[j].Category {
+			return changes[i].Category > changes[j].Category
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+func variableDisplayValue(spec *variableSpec) string {
+	if spec.Sensitive {
+		return "(sensitive)"
+	}
+	return spec.Value
+}
+
+func sortVariableSpecs(specs []*variableSpec) {
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].Category != specs[j].Category {
+			return specs[i].Category > specs[j].Category
+		}
+		return specs[i].Key < specs[j].Key
+	})
+}
+
+// renderVariableChanges prints a plan of variable changes as a table
+// followed by a summary line.
+func renderVariableChanges(m *Meta, changes []*variableChange) {
+	counts := map[string]int{}
+	headers := []string{"Action", "Key", "Category", "Current", "New"}
+	var rows [][]string
+	for _, change := range changes {
+		counts[change.Action]++
+		rows = append(rows, []string{change.Action, change.Key, change.Category, change.Before, change.After})
+	}
+	m.NewFormatter("table").Table(headers, rows)
+	m.Ui.Output("")
+	m.Ui.Output(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.",
+		counts[variableChangeCreate], counts[variableChangeUpdate], counts[variableChangeDelete]))
+}
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseTfvarsFile(t *testing.T) {
+	src := []byte(`region   = "us-east-1"
+replicas = 3
+enabled  = true
+zones    = ["a", "b"]
+tags = {
+  team = "platform"
+}
+`)
+
+	specs, err := parseVariableFile("prod.tfvars", variableFileFormatTfvars, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]*variableSpec{}
+	for _, spec := range specs {
+		if spec.Category != "terraform" {
+			t.Fatalf("expected terraform category, got %+v", spec)
+		}
+		got[spec.Key] = spec
+	}
+	if got["region"].Value != "us-east-1" || got["region"].HCL {
+		t.Fatalf("unexpected region %+v", got["region"])
+	}
+	if got["replicas"].Value != "3" || got["enabled"].Value != "true" || got["replicas"].HCL {
+		t.Fatalf("unexpected primitives %+v %+v", got["replicas"], got["enabled"])
+	}
+	if got["zones"].Value != `["a", "b"]` || !got["zones"].HCL {
+		t.Fatalf("unexpected list %+v", got["zones"])
+	}
+	if !strings.Contains(got["tags"].Value, `team = "platform"`) || !got["tags"].HCL {
+		t.Fatalf("unexpected map %+v", got["tags"])
+	}
+}
+
+func TestParseTfvarsJSONFile(t *testing.T) {
+	specs, err := parseVariableFile("prod.tfvars.json", variableFileFormatTfvars, []byte(`{"region": "eu-west-1", "zones": ["a"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 2 || specs[0].Key != "region" || specs[0].Value != "eu-west-1" || !specs[1].HCL || specs[1].Value != `["a"]` {
+		t.Fatalf("unexpected specs %+v %+v", specs[0], specs[1])
+	}
+}
+
+func TestParseTfvarsRejectsReferences(t *testing.T) {
+	if _, err := parseVariableFile("x.tfvars", variableFileFormatTfvars, []byte("region = var.other\n")); err == nil {
+		t.Fatal("expected an error for a variable reference")
+	}
+}
+
+func TestParseDotenvFile(t *testing.T) {
+	specs, err := parseVariableFile(".env", variableFileFormatEnv, []byte("# comment\nAWS_REGION=us-east-1\nexport TF_LOG=\"debug\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 2 || specs[0].Key != "AWS_REGION" || specs[1].Key != "TF_LOG" || specs[1].Value != "debug" || specs[0].Category != "env" {
+		t.Fatalf("unexpected specs %+v %+v", specs[0], specs[1])
+	}
+}
+
+func TestParseVariableJSONFileRejectsDuplicates(t *testing.T) {
+	_, err := parseVariableFile("vars.json", variableFileFormatJSON, []byte(`[{"key":"a","value":"1"},{"key":"a","value":"2","category":"terraform"}]`))
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+}
+
+func TestVariableFileFormat(t *testing.T) {
+	cases := map[string]string{
+		"prod.tfvars":      variableFileFormatTfvars,
+		"prod.tfvars.json": variableFileFormatTfvars,
+		"vars.json":        variableFileFormatJSON,
+		".env":             variableFileFormatEnv,
+		".env.production":  variableFileFormatEnv,
+		"prod.env":         variableFileFormatEnv,
+	}
+	for name, want := range cases {
+		got, err := variableFileFormat(name, "")
+		if err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", name, want, got, err)
+		}
+	}
+	if _, err := variableFileFormat("vars.txt", ""); err == nil {
+		t.Error("expected an error for an unknown extension")
+	}
+}
+
+func TestRenderVariableFileRoundTrips(t *testing.T) {
+	specs := []*variableSpec{
+		{Key: "name", Value: "say \"hi\" ${x}", Category: "terraform"},
+		{Key: "zones", Value: `["a", "b"]`, Category: "terraform", HCL: true},
+	}
+
+	content, err := renderVariableFile("x.tfvars", variableFileFormatTfvars, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parseVariableFile("x.tfvars", variableFileFormatTfvars, []byte(content))
+	if err != nil {
+		t.Fatalf("rendered tfvars did not parse: %v\n%s", err, content)
+	}
+	if parsed[0].Value != specs[0].Value || parsed[1].Value != specs[1].Value || !parsed[1].HCL {
+		t.Fatalf("round trip changed values: %+v %+v", parsed[0], parsed[1])
+	}
+
+	content, err = renderVariableFile(".env", variableFileFormatEnv, []*variableSpec{{Key: "MSG", Value: "a $b\nc", Category: "env"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err = parseVariableFile(".env", variableFileFormatEnv, []byte(content))
+	if err != nil || parsed[0].Value != "a $b\nc" {
+		t.Fatalf("dotenv round trip failed: %v %+v\n%s", err, parsed, content)
+	}
+}
+
+func TestRenderTfvarsJSONRoundTrips(t *testing.T) {
+	specs := []*variableSpec{
+		{Key: "name", Value: "say \"hi\" ${x}", Category: "terraform"},
+		{Key: "tags", Value: `{ team = "platform", path = "%{x}" }`, Category: "terraform", HCL: true},
+		{Key: "zones", Value: `["a", "b"]`, Category: "terraform", HCL: true},
+	}
+
+	content, err := renderVariableFile("x.tfvars.json", variableFileFormatTfvars, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid([]byte(content)) {
+		t.Fatalf("rendered tfvars JSON is not JSON:\n%s", content)
+	}
+	parsed, err := parseVariableFile("x.tfvars.json", variableFileFormatTfvars, []byte(content))
+	if err != nil {
+		t.Fatalf("rendered tfvars JSON did not parse: %v\n%s", err, content)
+	}
+	values := map[string]*variableSpec{}
+	for _, spec := range parsed {
+		values[spec.Key] = spec
+	}
+	if values["name"].Value != specs[0].Value || values["name"].HCL {
+		t.Fatalf("string round trip changed value: %+v", values["name"])
+	}
+	if values["tags"].Value != `{"path": "%%{x}", "team": "platform"}` || !values["tags"].HCL {
+		t.Fatalf("unexpected object value: %+v", values["tags"])
+	}
+	if values["zones"].Value != `["a", "b"]` || !values["zones"].HCL {
+		t.Fatalf("unexpected list value: %+v", values["zones"])
+	}
+
+	if _, err := renderVariableFile("x.tfvars.json", variableFileFormatTfvars, []*variableSpec{{Key: "r", Value: "var.other", HCL: true}}); err == nil {
+		t.Fatal("expected an HCL reference to be rejected")
+	}
+}
+
+func TestPlanVariableChanges(t *testing.T) {
+	existing := []*variableSpec{
+		{ID: "var-1", Key: "region", Value: "us-east-1", Category: "terraform"},
+		{ID: "var-2", Key: "size", Value: "small", Category: "terraform"},
+		{ID: "var-3", Key: "token", Category: "terraform", Sensitive: true},
+		{ID: "var-4", Key: "old", Value: "x", Category: "terraform"},
+		{ID: "var-5", Key: "AWS_REGION", Value: "us-east-1", Category: "env"},
+	}
+	desired := []*variableSpec{
+		{Key: "region", Value: "us-east-1", Category: "terraform"},
+		{Key: "size", Value: "large", Category: "terraform"},
+		{Key: "token", Value: "secret", Category: "terraform"},
+		{Key: "new", Value: "y", Category: "terraform"},
+	}
+
+	changes := planVariableChanges(desired, existing, true, []string{"terraform"})
+
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Action+":"+change.Key)
+	}
+	want := "create:new,delete:old,update:size,update:token"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("expected %s, got %s", want, strings.Join(got, ","))
+	}
+	if changes[3].Before != "(sensitive)" {
+		t.Fatalf("expected sensitive value to be masked, got %q", changes[3].Before)
+	}
+
+	if changes := planVariableChanges(desired, existing, false, []string{"terraform"}); len(changes) != 3 {
+		t.Fatalf("expected no deletes without prune, got %d changes", len(changes))
+	}
+}
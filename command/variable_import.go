@@ -0,0 +1,274 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// VariableImportCommand is a command to create or update workspace variables from a file
+type VariableImportCommand struct {
+	Meta
+	organization string
+	workspace    string
+	file         string
+	fileFormat   string
+	sensitive    bool
+	sync         bool
+	autoApprove  bool
+	format       string
+	workspaceSvc workspaceReader
+	variableSvc  variableSyncService
+}
+
+// Run executes the variable import command
+func (c *VariableImportCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variable import")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
+	flags.StringVar(&c.file, "file", "", "Variables file: .tfvars, .tfvars.json, .env, or .json (required)")
+	flags.StringVar(&c.fileFormat, "format", "", "File format: tfvars, env, or json (default: from the file name)")
+	flags.BoolVar(&c.sensitive, "sensitive", false, "Mark every imported variable as sensitive")
+	flags.BoolVar(&c.sync, "sync", false, "Delete variables of the imported categories that are not in the file")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Error("Error: -file flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	fileFormat, err := variableFileFormat(c.file, c.fileFormat)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading file: %s", err))
+		return 1
+	}
+
+	desired, err := parseVariableFile(c.file, fileFormat, data)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing %s: %s", c.file, err))
+		return 1
+	}
+	if c.sensitive {
+		for _, spec := range desired {
+			spec.Sensitive = true
+		}
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	ws, err := c.workspaceService(client).Read(ctx, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	svc := c.variableService(client)
+	variables, err := listAllVariables(ctx, svc, ws.ID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variables: %s", err))
+		return 1
+	}
+
+	var existing []*variableSpec
+	for _, v := range variables {
+		existing = append(existing, variableSpecFromVariable(v))
+	}
+
+	changes := planVariableChanges(desired, existing, c.sync, variableFileCategories(fileFormat))
+
+	if c.Meta.DryRun {
+		if changes == nil {
+			changes = []*variableChange{}
+		}
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":       "import",
+			"resource":     "variable",
+			"workspace_id": ws.ID,
+			"changes":      changes,
+		})
+		return 0
+	}
+
+	if len(changes) == 0 {
+		if c.format == "json" {
+			c.Meta.NewFormatter("json").JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("Variables in workspace '%s' already match %s", ws.Name, c.file))
+		return 0
+	}
+
+	if !c.autoApprove {
+		renderVariableChanges(&c.Meta, changes)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Do you want to apply these changes to workspace '%s'?", ws.Name))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Import cancelled.")
+			return 0
+		}
+	}
+
+	for _, change := range changes {
+		if err := applyVariableChange(ctx, svc, ws.ID, change); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: failed to %s %s variable %q: %s", change.Action, change.Category, change.Key, err))
+			return 1
+		}
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(changes)
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	c.Ui.Output(fmt.Sprintf("Imported variables into workspace '%s': %d created, %d updated, %d deleted",
+		ws.Name, counts[variableChangeCreate], counts[variableChangeUpdate], counts[variableChangeDelete]))
+	return 0
+}
+
+// applyVariableChange makes one planned change to a workspace variable.
+// Sensitive variables cannot be made non-sensitive, so Sensitive is only
+// ever set to true.
+func applyVariableChange(ctx context.Context, svc variableSyncService, workspaceID string, change *variableChange) error {
+	switch change.Action {
+	case variableChangeCreate:
+		category := tfe.CategoryType(change.Desired.Category)
+		options := tfe.VariableCreateOptions{
+			Key:       tfe.String(change.Desired.Key),
+			Value:     tfe.String(change.Desired.Value),
+			Category:  &category,
+			HCL:       tfe.Bool(change.Desired.HCL),
+			Sensitive: tfe.Bool(change.Desired.Sensitive),
+		}
+		if change.Desired.Description != "" {
+			options.Description = tfe.String(change.Desired.Description)
+		}
+		_, err := svc.Create(ctx, workspaceID, options)
+		return err
+	case variableChangeUpdate:
+		options := tfe.VariableUpdateOptions{
+			Value: tfe.String(change.Desired.Value),
+			HCL:   tfe.Bool(change.Desired.HCL),
+		}
+		if change.Desired.Sensitive {
+			options.Sensitive = tfe.Bool(true)
+		}
+		if change.Desired.Description != "" {
+			options.Description = tfe.String(change.Desired.Description)
+		}
+		_, err := svc.Update(ctx, workspaceID, change.Current.ID, options)
+		return err
+	case variableChangeDelete:
+		return svc.Delete(ctx, workspaceID, change.Current.ID)
+	}
+	return fmt.Errorf("unknown action %q", change.Action)
+}
+
+func (c *VariableImportCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *VariableImportCommand) variableService(client *client.Client) variableSyncService {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+// Help returns help text for the variable import command
+func (c *VariableImportCommand) Help() string {
+	helpText := `
+Usage: hcptf variable import [options]
+
+  Create and update workspace variables from a file. The changes are shown
+  and confirmed before they are made; use -dry-run to print them as JSON
+  without changing anything.
+
+  File formats, chosen from the file name unless -format is set:
+
+    tfvars  Terraform variables in HCL (.tfvars) or JSON (.tfvars.json).
+            Lists, maps, and objects are stored as HCL variables.
+    env     Environment variables in dotenv syntax (.env).
+    json    A list of variable objects with key, value, category, hcl,
+            sensitive, and description, as written by variable export.
+
+  With -sync, variables in the categories the file can hold that are not in
+  the file are deleted: Terraform variables for tfvars, environment
+  variables for env, and both for json. Existing sensitive values cannot be
+  read back, so they are always updated.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -workspace=<name>    Workspace name (required)
+  -file=<path>         Variables file (required)
+  -format=<format>     File format: tfvars, env, or json
+  -sensitive           Mark every imported variable as sensitive
+  -sync                Delete variables that are not in the file
+  -auto-approve        Skip confirmation
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf variable import -org=my-org -workspace=prod -file=prod.tfvars
+  hcptf variable import -org=my-org -workspace=prod -file=.env -sensitive
+  hcptf variable import -org=my-org -workspace=prod -file=vars.json -sync -dry-run
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variable import command
+func (c *VariableImportCommand) Synopsis() string {
+	return "Create and update workspace variables from a file"
+}
@@ -0,0 +1,137 @@
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newVariableImportFixture() (*mockWorkspaceReader, *mockVariableSyncService) {
+	workspaces := &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1", Name: "prod"}}
+	variables := &mockVariableSyncService{variables: map[string][]*tfe.Variable{
+		"ws-1": {
+			{ID: "var-1", Key: "region", Value: "us-east-1", Category: tfe.CategoryTerraform},
+			{ID: "var-2", Key: "size", Value: "small", Category: tfe.CategoryTerraform},
+			{ID: "var-3", Key: "legacy", Value: "x", Category: tfe.CategoryTerraform},
+			{ID: "var-4", Key: "AWS_REGION", Value: "us-east-1", Category: tfe.CategoryEnv},
+		},
+	}}
+	return workspaces, variables
+}
+
+func writeTestVariableFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVariableImportCreatesAndUpdates(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, variables := newVariableImportFixture()
+	path := writeTestVariableFile(t, "prod.tfvars", "region = \"us-east-1\"\nsize = \"large\"\nzones = [\"a\", \"b\"]\n")
+	cmd := &VariableImportCommand{Meta: newTestMeta(ui), workspaceSvc: workspaces, variableSvc: variables}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path, "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(variables.created) != 1 || *variables.created[0].Key != "zones" || !*variables.created[0].HCL {
+		t.Fatalf("expected zones to be created as HCL, got %+v", variables.created)
+	}
+	if len(variables.updated) != 1 || *variables.updated["var-2"].Value != "large" {
+		t.Fatalf("expected size to be updated, got %+v", variables.updated)
+	}
+	if len(variables.deleted) != 0 {
+		t.Fatalf("expected no deletes without -sync, got %v", variables.deleted)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "1 created, 1 updated, 0 deleted") {
+		t.Fatalf("unexpected output %q", ui.OutputWriter.String())
+	}
+}
+
+func TestVariableImportSyncDeletesOnlyImportedCategory(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("yes\n")
+	workspaces, variables := newVariableImportFixture()
+	path := writeTestVariableFile(t, "prod.tfvars", "region = \"us-east-1\"\nsize = \"small\"\n")
+	cmd := &VariableImportCommand{Meta: newTestMeta(ui), workspaceSvc: workspaces, variableSvc: variables}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path, "-sync"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(variables.deleted) != 1 || variables.deleted[0] != "var-3" {
+		t.Fatalf("expected only legacy to be deleted, got %v", variables.deleted)
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "Plan: 0 to create, 0 to update, 1 to delete.") || !strings.Contains(output, "legacy") {
+		t.Fatalf("expected plan before confirmation, got %q", output)
+	}
+}
+
+func TestVariableImportCancelled(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("no\n")
+	workspaces, variables := newVariableImportFixture()
+	path := writeTestVariableFile(t, ".env", "AWS_REGION=eu-west-1\n")
+	cmd := &VariableImportCommand{Meta: newTestMeta(ui), workspaceSvc: workspaces, variableSvc: variables}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if len(variables.updated) != 0 {
+		t.Fatalf("expected no changes after cancelling, got %+v", variables.updated)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Import cancelled.") {
+		t.Fatalf("unexpected output %q", ui.OutputWriter.String())
+	}
+}
+
+func TestVariableImportDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	workspaces, variables := newVariableImportFixture()
+	path := writeTestVariableFile(t, ".env", "AWS_REGION=eu-west-1\nTF_TOKEN=abc\n")
+	cmd := &VariableImportCommand{Meta: newTestMeta(ui), workspaceSvc: workspaces, variableSvc: variables}
+	cmd.Meta.DryRun = true
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod", "-file=" + path, "-sensitive"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(variables.created) != 0 || len(variables.updated) != 0 {
+		t.Fatal("expected no changes in dry-run mode")
+	}
+
+	var result struct {
+		Action  string            `json:"action"`
+		Changes []*variableChange `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if result.Action != "import" || len(result.Changes) != 2 {
+		t.Fatalf("unexpected dry run %+v", result)
+	}
+	if strings.Contains(ui.OutputWriter.String(), "abc") {
+		t.Fatalf("dry run leaked a sensitive value: %s", ui.OutputWriter.String())
+	}
+}
+
+func TestVariableImportRequiresFile(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &VariableImportCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=prod"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-file") {
+		t.Fatalf("expected -file error, got %q", ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,312 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+// variableSearchMatch is one variable whose key, and value if given,
+// matched the search.
+type variableSearchMatch struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Key       string `json:"key"`
+	Category  string `json:"category"`
+	Sensitive bool   `json:"sensitive"`
+	HCL       bool   `json:"hcl"`
+	Value     string `json:"value"`
+}
+
+// VariableSearchCommand is a command to find where variables are defined across an organization
+type VariableSearchCommand struct {
+	Meta
+	organization string
+	key          string
+	value        string
+	keyPattern   *regexp.Regexp
+	valuePattern *regexp.Regexp
+	projectID    string
+	tags         string
+	concurrency  int
+	format       string
+	workspaceSvc workspaceLister
+	variableSvc  variableLister
+	varSetSvc    variableSetLister
+	varSetVarSvc variableSetVariableLister
+}
+
+// Run executes the variable search command
+func (c *VariableSearchCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variable search")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.key, "key", "", "Variable key pattern, * and ? wildcards allowed (required)")
+	flags.StringVar(&c.value, "value", "", "Only match non-sensitive values matching this pattern")
+	flags.StringVar(&c.projectID, "project-id", "", "Only search workspaces in this project and variable sets that apply to them")
+	flags.StringVar(&c.tags, "tag", "", "Only search workspaces with these tags (comma-separated) and variable sets that apply to them")
+	flags.IntVar(&c.concurrency, "concurrency", defaultStateSearchConcurrency, "Number of workspaces to read at once")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.key == "" {
+		c.Ui.Error("Error: -key flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.concurrency < 1 {
+		c.Ui.Error("Error: -concurrency must be at least 1")
+		return 1
+	}
+
+	c.keyPattern = variablePattern(c.key)
+	c.valuePattern = variablePattern(c.value)
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+		Tags:      c.tags,
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	sets, err := listAllVariableSets(ctx, c.varSetService(client), c.organization, &tfe.VariableSetListOptions{
+		Include: "workspaces,projects",
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variable sets: %s", err))
+		return 1
+	}
+
+	var (
+		mu       sync.Mutex
+		matches  []*variableSearchMatch
+		failures []string
+	)
+
+	forEachWorkspace(workspaces, c.concurrency, func(ws *tfe.Workspace) {
+		variables, err := listAllVariables(ctx, c.variableService(client), ws.ID)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("skipping workspace %s: %s", ws.Name, err))
+			return
+		}
+		for _, v := range variables {
+			if c.matches(v.Key, v.Value, v.Sensitive) {
+				matches = append(matches, &variableSearchMatch{
+					Type:      "workspace",
+					Name:      ws.Name,
+					ID:        ws.ID,
+					Key:       v.Key,
+					Category:  string(v.Category),
+					Sensitive: v.Sensitive,
+					HCL:       v.HCL,
+					Value:     maskedVariableValue(v.Value, v.Sensitive),
+				})
+			}
+		}
+	})
+
+	scoped := c.projectID != "" || c.tags != ""
+	for _, set := range sets {
+		if scoped && !variableSetAppliesToAny(set, workspaces) {
+			continue
+		}
+		variables, err := listAllVariableSetVariables(ctx, c.varSetVarService(client), set.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("skipping variable set %s: %s", set.Name, err))
+			continue
+		}
+		for _, v := range variables {
+			if c.matches(v.Key, v.Value, v.Sensitive) {
+				matches = append(matches, &variableSearchMatch{
+					Type:      "variable_set",
+					Name:      set.Name,
+					ID:        set.ID,
+					Key:       v.Key,
+					Category:  string(v.Category),
+					Sensitive: v.Sensitive,
+					HCL:       v.HCL,
+					Value:     maskedVariableValue(v.Value, v.Sensitive),
+				})
+			}
+		}
+	}
+
+	sort.Strings(failures)
+	for _, failure := range failures {
+		c.Ui.Warn(fmt.Sprintf("Warning: %s", failure))
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Type != matches[j].Type {
+			return matches[i].Type > matches[j].Type
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		if matches[i].Key != matches[j].Key {
+			return matches[i].Key < matches[j].Key
+		}
+		return matches[i].Category > matches[j].Category
+	})
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if len(matches) == 0 {
+		if c.format == "json" {
+			formatter.JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("No variables matching %q in %d workspaces and %d variable sets", c.key, len(workspaces), len(sets)))
+		return 0
+	}
+
+	if c.format == "json" {
+		formatter.JSON(matches)
+		return 0
+	}
+
+	headers := []string{"Type", "Name", "Key", "Category", "Sensitive", "Value"}
+	var rows [][]string
+	for _, m := range matches {
+		kind := "workspace"
+		if m.Type == "variable_set" {
+			kind = "variable set"
+		}
+		rows = append(rows, []string{kind, m.Name, m.Key, m.Category, fmt.Sprintf("%t", m.Sensitive), m.Value})
+	}
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// matches reports whether a variable matches the key pattern and, if set,
+// the value pattern. Sensitive values cannot be read, so they never match a
+// value pattern.
+func (c *VariableSearchCommand) matches(key, value string, sensitive bool) bool {
+	if !c.keyPattern.MatchString(key) {
+		return false
+	}
+	if c.value == "" {
+		return true
+	}
+	return !sensitive && c.valuePattern.MatchString(value)
+}
+
+// variablePattern compiles a wildcard pattern to a case-insensitive regexp
+// matching the whole string. Unlike a path glob, * also matches /, so
+// patterns work against values such as ARNs and URLs.
+func variablePattern(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("(?is)^" + expr + "$")
+}
+
+// variableSetAppliesToAny reports whether a variable set applies to at least
+// one of the workspaces.
+func variableSetAppliesToAny(set *tfe.VariableSet, workspaces []*tfe.Workspace) bool {
+	for _, ws := range workspaces {
+		if variableSetScopeFor(set, ws) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *VariableSearchCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *VariableSearchCommand) variableService(client *client.Client) variableLister {
+	if c.variableSvc != nil {
+		return c.variableSvc
+	}
+	return client.Variables
+}
+
+func (c *VariableSearchCommand) varSetService(client *client.Client) variableSetLister {
+	if c.varSetSvc != nil {
+		return c.varSetSvc
+	}
+	return client.VariableSets
+}
+
+func (c *VariableSearchCommand) varSetVarService(client *client.Client) variableSetVariableLister {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+// Help returns help text for the variable search command
+func (c *VariableSearchCommand) Help() string {
+	helpText := `
+Usage: hcptf variable search [options]
+
+  Find every workspace and variable set in an organization that defines a
+  variable, for example to rotate a credential or rename an environment
+  variable everywhere it is used.
+
+  -key and -value are matched case-insensitively against the whole key or
+  value and may use * and ? wildcards. * matches any text, including /, so
+  -value='arn:aws:iam::*:role/*' finds role ARNs. Sensitive values cannot
+  be read, so they are shown masked and never match -value.
+
+  With -project-id or -tag, only matching workspaces are searched, along
+  with the variable sets that apply to at least one of them.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -key=<pattern>       Variable key pattern (required)
+  -value=<pattern>     Only match non-sensitive values matching this pattern
+  -project-id=<id>     Only search workspaces in this project
+  -tag=<tags>          Only search workspaces with these tags (comma-separated)
+  -concurrency=<n>     Number of workspaces to read at once (default: 8)
+  -output=<format>     Output format: table (default), json, or csv
+
+Example:
+
+  hcptf variable search -org=my-org -key=AWS_ACCESS_KEY_ID
+  hcptf variable search -org=my-org -key='DATADOG_*' -project-id=prj-abc123
+  hcptf variable search -org=my-org -key=region -value='us-west-*' -output=csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variable search command
+func (c *VariableSearchCommand) Synopsis() string {
+	return "Find where a variable is defined across workspaces and variable sets"
+}
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+// mockConcurrentVariableLister serves workspace variables to concurrent
+// callers.
+type mockConcurrentVariableLister struct {
+	mu        sync.Mutex
+	variables map[string][]*tfe.Variable
+	calls     int
+}
+
+func (m *mockConcurrentVariableLister) List(_ context.Context, workspaceID string, _ *tfe.VariableListOptions) (*tfe.VariableList, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return &tfe.VariableList{Items: m.variables[workspaceID]}, nil
+}
+
+func newVariableSearchCommand(ui cli.Ui, workspaces []*tfe.Workspace) (*VariableSearchCommand, *mockWorkspacePagedListService) {
+	wsSvc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{workspaces}}
+	return &VariableSearchCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: wsSvc,
+		variableSvc: &mockConcurrentVariableLister{variables: map[string][]*tfe.Variable{
+			"ws-1": {
+				{Key: "AWS_ACCESS_KEY_ID", Category: tfe.CategoryEnv, Sensitive: true},
+				{Key: "region", Value: "us-west-2", Category: tfe.CategoryTerraform},
+			},
+			"ws-2": {
+				{Key: "aws_access_key_id", Value: "AKIAEXAMPLE", Category: tfe.CategoryTerraform},
+				{Key: "region", Value: "eu-west-1", Category: tfe.CategoryTerraform},
+				{Key: "deploy_role_arn", Value: "arn:aws:iam::123456789012:role/ci/deploy", Category: tfe.CategoryTerraform},
+			},
+		}},
+		varSetSvc: &mockVariableSetListService{response: &tfe.VariableSetList{Items: []*tfe.VariableSet{
+			{ID: "varset-1", Name: "aws-creds", Global: true},
+			{ID: "varset-2", Name: "other-project", Projects: []*tfe.Project{{ID: "prj-other"}}},
+		}}},
+		varSetVarSvc: &mockVariableSetVariableListService{variables: map[string][]*tfe.VariableSetVariable{
+			"varset-1": {{Key: "AWS_ACCESS_KEY_ID", Category: tfe.CategoryEnv, Sensitive: true}},
+			"varset-2": {{Key: "AWS_ACCESS_KEY_ID", Value: "AKIAOTHER", Category: tfe.CategoryEnv}},
+		}},
+	}, wsSvc
+}
+
+func variableSearchWorkspaces() []*tfe.Workspace {
+	return []*tfe.Workspace{
+		{ID: "ws-1", Name: "app-prod", Project: &tfe.Project{ID: "prj-app"}},
+		{ID: "ws-2", Name: "app-staging", Project: &tfe.Project{ID: "prj-app"}},
+	}
+}
+
+func TestVariableSearchFindsKeyEverywhere(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newVariableSearchCommand(ui, variableSearchWorkspaces())
+
+	if code := cmd.Run([]string{"-org=my-org", "-key=aws_access_key_id", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []variableSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, ui.OutputWriter.String())
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Type+":"+m.Name+":"+m.Value)
+	}
+	want := "workspace:app-prod:(sensitive),workspace:app-staging:AKIAEXAMPLE,variable_set:aws-creds:(sensitive),variable_set:other-project:AKIAOTHER"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("expected %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestVariableSearchValuePatternSkipsSensitive(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newVariableSearchCommand(ui, variableSearchWorkspaces())
+
+	if code := cmd.Run([]string{"-org=my-org", "-key=*", "-value=AKIA*", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []variableSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 non-sensitive matches, got %+v", matches)
+	}
+	for _, m := range matches {
+		if m.Sensitive {
+			t.Fatalf("sensitive variable matched a value pattern: %+v", m)
+		}
+	}
+}
+
+func TestVariableSearchValuePatternMatchesAcrossSlashes(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, _ := newVariableSearchCommand(ui, variableSearchWorkspaces())
+
+	if code := cmd.Run([]string{"-org=my-org", "-key=*", "-value=arn:aws:iam::*:role/*", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var matches []variableSearchMatch
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &matches); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Key != "deploy_role_arn" || matches[0].Name != "app-staging" {
+		t.Fatalf("expected the ARN variable to match, got %+v", matches)
+	}
+}
+
+func TestVariablePattern(t *testing.T) {
+	cases := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"AWS_*", "aws_access_key_id", true},
+		{"region", "region_name", false},
+		{"us-west-?", "us-west-2", true},
+		{"https://*.example.com/*", "https://api.example.com/v1/health", true},
+		{"[abc]", "a", false},
+		{"[abc]", "[ABC]", true},
+	}
+	for _, tc := range cases {
+		if got := variablePattern(tc.pattern).MatchString(tc.s); got != tc.want {
+			t.Errorf("variablePattern(%q).MatchString(%q) = %v, want %v", tc.pattern, tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestVariableSearchProjectScopesVariableSets(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd, wsSvc := newVariableSearchCommand(ui, variableSearchWorkspaces())
+
+	if code := cmd.Run([]string{"-org=my-org", "-key=AWS_*", "-project-id=prj-app"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if wsSvc.lastOptions == nil || wsSvc.lastOptions.ProjectID != "prj-app" {
+		t.Fatalf("expected workspaces to be listed by project, got %+v", wsSvc.lastOptions)
+	}
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "aws-creds") || strings.Contains(output, "other-project") {
+		t.Fatalf("expected only variable sets applying to the project, got:\n%s", output)
+	}
+}
+
+func TestVariableSearchValidation(t *testing.T) {
+	cases := map[string][]string{
+		"missing key": {"-org=my-org"},
+	}
+	for name, args := range cases {
+		ui := cli.NewMockUi()
+		cmd, _ := newVariableSearchCommand(ui, nil)
+		if code := cmd.Run(args); code != 1 {
+			t.Errorf("%s: expected exit 1, got %d", name, code)
+		}
+	}
+}
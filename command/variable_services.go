@@ -14,6 +14,26 @@ type variableUpdater interface {
 	Update(ctx context.Context, workspaceID string, variableID string, options tfe.VariableUpdateOptions) (*tfe.Variable, error)
 }
 
+type variableReader interface {
+	Read(ctx context.Context, workspaceID string, variableID string) (*tfe.Variable, error)
+}
+
 type variableDeleter interface {
 	Delete(ctx context.Context, workspaceID string, variableID string) error
 }
+
+type variableLister interface {
+	List(ctx context.Context, workspaceID string, options *tfe.VariableListOptions) (*tfe.VariableList, error)
+}
+
+type variableSyncService interface {
+	variableLister
+	variableCreator
+	variableUpdater
+	variableDeleter
+}
+
+type variableListUpdater interface {
+	variableLister
+	variableUpdater
+}
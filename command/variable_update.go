@@ -15,13 +15,14 @@ type VariableUpdateCommand struct {
 	workspace    string
 	id           string
 	key          string
-	value        string
+	value        variableValueFlags
 	sensitive    string
 	hcl          string
 	description  string
 	format       string
 	workspaceSvc workspaceReader
 	variableSvc  variableUpdater
+	readSvc      variableReader
 }
 
 // Run executes the variable update command
@@ -32,7 +33,7 @@ func (c *VariableUpdateCommand) Run(args []string) int {
 	flags.StringVar(&c.workspace, "workspace", "", "Workspace name (required)")
 	flags.StringVar(&c.id, "id", "", "Variable ID (required)")
 	flags.StringVar(&c.key, "key", "", "Variable key")
-	flags.StringVar(&c.value, "value", "", "Variable value")
+	c.value.register(flags, "Variable value (not allowed for sensitive variables)")
 	flags.StringVar(&c.sensitive, "sensitive", "", "Mark variable as sensitive (true/false)")
 	flags.StringVar(&c.hcl, "hcl", "", "Parse value as HCL (true/false)")
 	flags.StringVar(&c.description, "description", "", "Variable description")
@@ -112,8 +113,29 @@ func (c *VariableUpdateCommand) Run(args []string) int {
 			options.Key = tfe.String(c.key)
 		}
 
-		if c.value != "" {
-			options.Value = tfe.String(c.value)
+		// -value is refused for sensitive variables, including ones that
+		// are already sensitive, so check the current variable first.
+		sensitive := c.sensitive == "true"
+		if c.value.value != "" && !sensitive {
+			current, err := c.variableReadService(client).Read(client.Context(), ws.ID, c.id)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading variable: %s", err))
+				return 1
+			}
+			sensitive = current.Sensitive
+		}
+
+		prompt := c.key
+		if prompt == "" {
+			prompt = c.id
+		}
+		value, ok, err := c.value.resolve(c.Ui, prompt, sensitive, false)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if ok {
+			options.Value = tfe.String(value)
 		}
 
 		if c.sensitive != "" {
@@ -142,7 +164,13 @@ func (c *VariableUpdateCommand) Run(args []string) int {
 		}
 	}
 
+	if err := checkInlineJSONSecret(&c.Meta, options.Value, options.Sensitive); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
 	if c.Meta.DryRun {
+		options.Value = redactVariableValue(options.Value)
 		formatter := c.Meta.NewFormatter("json")
 		formatter.JSON(map[string]interface{}{
 			"action":       "update",
@@ -202,6 +230,13 @@ func (c *VariableUpdateCommand) variableService(client *client.Client) variableU
 	return client.Variables
 }
 
+func (c *VariableUpdateCommand) variableReadService(client *client.Client) variableReader {
+	if c.readSvc != nil {
+		return c.readSvc
+	}
+	return client.Variables
+}
+
 // Help returns help text for the variable update command
 func (c *VariableUpdateCommand) Help() string {
 	helpText := `
@@ -209,6 +244,13 @@ Usage: hcptf variable update [options]
 
   Update a variable.
 
+  A new value can be given with exactly one of -value, -value-file,
+  -value-stdin, or -value-env. -value is refused when the variable is or
+  is being made sensitive, so secrets never appear in shell history or
+  process listings. -value-stdin prompts with input hidden when stdin is a
+  terminal. A single trailing newline is removed from files and stdin.
+  -dry-run output shows the value as (redacted).
+
 Options:
 
   -organization=<name>  Organization name (required)
@@ -216,7 +258,10 @@ Options:
   -workspace=<name>    Workspace name (required)
   -id=<id>             Variable ID (required)
   -key=<name>          Variable key
-  -value=<value>       Variable value
+  -value=<value>       Variable value (not allowed for sensitive variables)
+  -value-file=<path>   Read the value from a file
+  -value-stdin         Read the value from stdin
+  -value-env=<name>    Read the value from an environment variable
   -sensitive=<bool>    Mark variable as sensitive (true/false)
   -hcl=<bool>          Parse value as HCL (true/false)
   -description=<text>  Variable description
@@ -226,6 +271,7 @@ Example:
 
   hcptf variable update -org=my-org -workspace=prod -id=var-123 -value=us-west-2
   hcptf variable update -org=my-org -workspace=prod -id=var-456 -sensitive=true
+  hcptf variable update -org=my-org -workspace=prod -id=var-789 -value-stdin
 `
 	return strings.TrimSpace(helpText)
 }
@@ -69,7 +69,8 @@ func TestVariableUpdateHandlesAPIError(t *testing.T) {
 	vars := &mockVariableUpdateService{err: errors.New("boom")}
 	cmd := newVariableUpdateCommand(ui, ws, vars)
 
-	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-id=var-123", "-value=hi", "-sensitive=true"}); code != 1 {
+	t.Setenv("TEST_VARIABLE_VALUE", "hi")
+	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-id=var-123", "-value-env=TEST_VARIABLE_VALUE", "-sensitive=true"}); code != 1 {
 		t.Fatalf("expected exit 1")
 	}
 	if vars.lastWorkspace != "ws-1" || vars.lastID != "var-123" {
@@ -78,6 +79,9 @@ func TestVariableUpdateHandlesAPIError(t *testing.T) {
 	if vars.lastOptions.Sensitive == nil || !*vars.lastOptions.Sensitive {
 		t.Fatalf("expected sensitive true")
 	}
+	if vars.lastOptions.Value == nil || *vars.lastOptions.Value != "hi" {
+		t.Fatalf("expected value from environment")
+	}
 	if !strings.Contains(ui.ErrorWriter.String(), "boom") {
 		t.Fatalf("expected error output")
 	}
@@ -108,3 +112,43 @@ func TestVariableUpdateOutputsJSON(t *testing.T) {
 		t.Fatalf("unexpected data: %#v", data)
 	}
 }
+
+func TestVariableUpdateRejectsValueFlagForSensitiveVariable(t *testing.T) {
+	ui := cli.NewMockUi()
+	ws := &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1"}}
+	vars := &mockVariableUpdateService{}
+	cmd := newVariableUpdateCommand(ui, ws, vars)
+	read := &mockVariableReadService{response: &tfe.Variable{ID: "var-1", Key: "password", Sensitive: true}}
+	cmd.readSvc = read
+
+	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-id=var-1", "-value=hunter2"}); code != 1 {
+		t.Fatalf("expected exit 1")
+	}
+	if read.lastID != "var-1" {
+		t.Fatalf("expected current variable to be read")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cannot be passed with -value") {
+		t.Fatalf("expected -value error, got %q", ui.ErrorWriter.String())
+	}
+	if vars.lastID != "" {
+		t.Fatalf("expected no update")
+	}
+}
+
+func TestVariableUpdateReadsValueFromStdin(t *testing.T) {
+	old := variableValueStdin
+	variableValueStdin = strings.NewReader("hunter2\n")
+	defer func() { variableValueStdin = old }()
+
+	ui := cli.NewMockUi()
+	ws := &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-1"}}
+	vars := &mockVariableUpdateService{response: &tfe.Variable{ID: "var-1", Key: "password", Sensitive: true}}
+	cmd := newVariableUpdateCommand(ui, ws, vars)
+
+	if code := cmd.Run([]string{"-organization=my-org", "-workspace=prod", "-id=var-1", "-value-stdin"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if vars.lastOptions.Value == nil || *vars.lastOptions.Value != "hunter2" {
+		t.Fatalf("expected value from stdin, got %#v", vars.lastOptions.Value)
+	}
+}
@@ -0,0 +1,139 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+// redactedValue replaces variable values in -dry-run output.
+const redactedValue = "(redacted)"
+
+// variableValueStdin is where -value-stdin reads from when stdin is not a
+// terminal.
+var variableValueStdin io.Reader = os.Stdin
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+var stdinIsTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// variableValueFlags are the ways a command can be given a variable value.
+// Sensitive values may come from any of them except -value, so that secrets
+// never end up in shell history or process listings.
+type variableValueFlags struct {
+	value string
+	file  string
+	stdin bool
+	env   string
+}
+
+func (f *variableValueFlags) register(flags *flag.FlagSet, usage string) {
+	flags.StringVar(&f.value, "value", "", usage)
+	flags.StringVar(&f.file, "value-file", "", "Read the value from a file")
+	flags.BoolVar(&f.stdin, "value-stdin", false, "Read the value from stdin")
+	flags.StringVar(&f.env, "value-env", "", "Read the value from an environment variable")
+}
+
+// isSet reports whether any value source was given.
+func (f *variableValueFlags) isSet() bool {
+	return f.value != "" || f.file != "" || f.stdin || f.env != ""
+}
+
+// resolve returns the value from whichever source was given, and whether
+// one was. With no source, it prompts for the value with input hidden if
+// prompt is set and stdin is a terminal.
+func (f *variableValueFlags) resolve(ui cli.Ui, key string, sensitive, prompt bool) (string, bool, error) {
+	sources := 0
+	for _, set := range []bool{f.value != "", f.file != "", f.stdin, f.env != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", false, fmt.Errorf("only one of -value, -value-file, -value-stdin, or -value-env may be set")
+	}
+
+	switch {
+	case f.value != "":
+		if sensitive {
+			return "", false, fmt.Errorf("sensitive values cannot be passed with -value; use -value-file, -value-stdin, -value-env, or enter the value when prompted")
+		}
+		return f.value, true, nil
+	case f.file != "":
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			return "", false, fmt.Errorf("reading -value-file: %w", err)
+		}
+		return trimTrailingNewline(string(data)), true, nil
+	case f.env != "":
+		value, ok := os.LookupEnv(f.env)
+		if !ok {
+			return "", false, fmt.Errorf("environment variable %s is not set", f.env)
+		}
+		return value, true, nil
+	case f.stdin:
+		if stdinIsTerminal() {
+			value, err := promptVariableValue(ui, key)
+			return value, err == nil, err
+		}
+		data, err := io.ReadAll(variableValueStdin)
+		if err != nil {
+			return "", false, fmt.Errorf("reading value from stdin: %w", err)
+		}
+		return trimTrailingNewline(string(data)), true, nil
+	}
+
+	if prompt && stdinIsTerminal() {
+		value, err := promptVariableValue(ui, key)
+		return value, err == nil, err
+	}
+	return "", false, nil
+}
+
+func promptVariableValue(ui cli.Ui, key string) (string, error) {
+	value, err := ui.AskSecret(fmt.Sprintf("Value for %s (input is hidden):", key))
+	if err != nil {
+		return "", fmt.Errorf("reading value: %w", err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("no value entered")
+	}
+	return value, nil
+}
+
+// trimTrailingNewline removes the single line ending that files and piped
+// commands such as echo usually add.
+func trimTrailingNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		s = strings.TrimSuffix(s, "\n")
+		s = strings.TrimSuffix(s, "\r")
+	}
+	return s
+}
+
+// checkInlineJSONSecret rejects a sensitive value passed inline with
+// -json-input, which puts it on the command line like -value would.
+func checkInlineJSONSecret(m *Meta, value *string, sensitive *bool) error {
+	if m.JSONInput == "-" || strings.HasPrefix(m.JSONInput, "@") {
+		return nil
+	}
+	if value != nil && *value != "" && sensitive != nil && *sensitive {
+		return fmt.Errorf("sensitive values cannot be passed inline with -json-input; use -json-input=@file or -json-input=-")
+	}
+	return nil
+}
+
+// redactVariableValue hides a value in -dry-run output.
+func redactVariableValue(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	return tfe.String(redactedValue)
+}
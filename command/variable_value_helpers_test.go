@@ -0,0 +1,136 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func TestVariableValueFlagsResolve(t *testing.T) {
+	dir := t.TempDir()
+	crlf := filepath.Join(dir, "crlf")
+	if err := os.WriteFile(crlf, []byte("secret\r\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	multiline := filepath.Join(dir, "multiline")
+	if err := os.WriteFile(multiline, []byte("line1\nline2\n\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_VARIABLE_VALUE", "from-env")
+	t.Setenv("TEST_EMPTY_VARIABLE_VALUE", "")
+
+	tests := []struct {
+		name      string
+		flags     variableValueFlags
+		sensitive bool
+		want      string
+		wantOK    bool
+		wantErr   string
+	}{
+		{name: "none", wantOK: false},
+		{name: "value", flags: variableValueFlags{value: "plain"}, want: "plain", wantOK: true},
+		{name: "value sensitive", flags: variableValueFlags{value: "secret"}, sensitive: true, wantErr: "cannot be passed with -value"},
+		{name: "file", flags: variableValueFlags{file: crlf}, sensitive: true, want: "secret", wantOK: true},
+		{name: "file keeps inner newlines", flags: variableValueFlags{file: multiline}, want: "line1\nline2\n", wantOK: true},
+		{name: "missing file", flags: variableValueFlags{file: filepath.Join(dir, "missing")}, wantErr: "-value-file"},
+		{name: "env", flags: variableValueFlags{env: "TEST_VARIABLE_VALUE"}, sensitive: true, want: "from-env", wantOK: true},
+		{name: "empty env", flags: variableValueFlags{env: "TEST_EMPTY_VARIABLE_VALUE"}, want: "", wantOK: true},
+		{name: "unset env", flags: variableValueFlags{env: "TEST_UNSET_VARIABLE_VALUE"}, wantErr: "is not set"},
+		{name: "two sources", flags: variableValueFlags{value: "plain", env: "TEST_VARIABLE_VALUE"}, wantErr: "only one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := tt.flags.resolve(cli.NewMockUi(), "key", tt.sensitive, true)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("got (%q, %t), want (%q, %t)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVariableValueFlagsResolveStdin(t *testing.T) {
+	old := variableValueStdin
+	variableValueStdin = strings.NewReader("piped\n")
+	defer func() { variableValueStdin = old }()
+
+	flags := variableValueFlags{stdin: true}
+	got, ok, err := flags.resolve(cli.NewMockUi(), "key", true, false)
+	if err != nil || !ok || got != "piped" {
+		t.Fatalf("got (%q, %t, %v)", got, ok, err)
+	}
+}
+
+func TestVariableValueFlagsResolvePrompt(t *testing.T) {
+	old := stdinIsTerminal
+	stdinIsTerminal = func() bool { return true }
+	defer func() { stdinIsTerminal = old }()
+
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("typed\n")
+	var flags variableValueFlags
+	got, ok, err := flags.resolve(ui, "password", true, true)
+	if err != nil || !ok || got != "typed" {
+		t.Fatalf("got (%q, %t, %v)", got, ok, err)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Value for password") {
+		t.Fatalf("expected prompt, got %q", ui.OutputWriter.String())
+	}
+
+	// Without prompt, nothing is asked for.
+	got, ok, err = flags.resolve(cli.NewMockUi(), "password", true, false)
+	if err != nil || ok || got != "" {
+		t.Fatalf("got (%q, %t, %v)", got, ok, err)
+	}
+
+	// An empty answer is an error rather than an empty secret.
+	ui = cli.NewMockUi()
+	ui.InputReader = strings.NewReader("\n")
+	if _, _, err := flags.resolve(ui, "password", true, true); err == nil {
+		t.Fatalf("expected error for empty value")
+	}
+}
+
+func TestCheckInlineJSONSecret(t *testing.T) {
+	tests := []struct {
+		jsonInput string
+		sensitive *bool
+		wantErr   bool
+	}{
+		{jsonInput: `{"value":"x"}`, sensitive: tfe.Bool(true), wantErr: true},
+		{jsonInput: `{"value":"x"}`, sensitive: tfe.Bool(false)},
+		{jsonInput: `{"value":"x"}`},
+		{jsonInput: "@vars.json", sensitive: tfe.Bool(true)},
+		{jsonInput: "-", sensitive: tfe.Bool(true)},
+	}
+
+	for _, tt := range tests {
+		m := &Meta{JSONInput: tt.jsonInput}
+		err := checkInlineJSONSecret(m, tfe.String("x"), tt.sensitive)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("JSONInput %q: got error %v, want error %t", tt.jsonInput, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRedactVariableValue(t *testing.T) {
+	if redactVariableValue(nil) != nil {
+		t.Fatalf("expected nil to stay nil")
+	}
+	if got := redactVariableValue(tfe.String("secret")); got == nil || *got != redactedValue {
+		t.Fatalf("expected redacted value, got %v", got)
+	}
+}
@@ -43,3 +43,43 @@ type variableSetWorkspaceUpdater interface {
 type variableSetStackUpdater interface {
 	UpdateStacks(ctx context.Context, variableSetID string, options *tfe.VariableSetUpdateStacksOptions) (*tfe.VariableSet, error)
 }
+
+type variableSetVariableLister interface {
+	List(ctx context.Context, variableSetID string, options *tfe.VariableSetVariableListOptions) (*tfe.VariableSetVariableList, error)
+}
+
+type variableSetVariableReader interface {
+	Read(ctx context.Context, variableSetID string, variableID string) (*tfe.VariableSetVariable, error)
+}
+
+type variableSetVariableUpdater interface {
+	Update(ctx context.Context, variableSetID string, variableID string, options *tfe.VariableSetVariableUpdateOptions) (*tfe.VariableSetVariable, error)
+}
+
+type variableSetVariableListUpdater interface {
+	variableSetVariableLister
+	variableSetVariableUpdater
+}
+
+type variableSetVariableCreator interface {
+	Create(ctx context.Context, variableSetID string, options *tfe.VariableSetVariableCreateOptions) (*tfe.VariableSetVariable, error)
+}
+
+type variableSetVariableSyncService interface {
+	variableSetVariableLister
+	variableSetVariableCreator
+	variableSetVariableUpdater
+	variableSetVariableDeleter
+}
+
+type variableSetApplier interface {
+	ApplyToProjects(ctx context.Context, variableSetID string, options tfe.VariableSetApplyToProjectsOptions) error
+	ApplyToStacks(ctx context.Context, variableSetID string, options *tfe.VariableSetApplyToStacksOptions) error
+}
+
+type variableSetSyncService interface {
+	variableSetReader
+	variableSetApplier
+	variableSetRemover
+	variableSetWorkspaceUpdater
+}
@@ -0,0 +1,677 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	variableSetAssignmentAdd    = "add"
+	variableSetAssignmentRemove = "remove"
+)
+
+// variableSetFile is the decoded form of a variable set sync file.
+// Assignment lists that are left out are not reconciled.
+type variableSetFile struct {
+	Variables  []*variableSetFileVariable `hcl:"variable,block"`
+	Workspaces *[]string                  `hcl:"workspaces,optional"`
+	Projects   *[]string                  `hcl:"projects,optional"`
+	Stacks     *[]string                  `hcl:"stacks,optional"`
+}
+
+// variableSetFileVariable is one variable block. Sensitive values are read
+// from value_env or value_file so they never have to be committed.
+type variableSetFileVariable struct {
+	Key         string  `hcl:"key,label"`
+	Value       *string `hcl:"value,optional"`
+	ValueEnv    string  `hcl:"value_env,optional"`
+	ValueFile   string  `hcl:"value_file,optional"`
+	Category    string  `hcl:"category,optional"`
+	HCL         bool    `hcl:"hcl,optional"`
+	Sensitive   bool    `hcl:"sensitive,optional"`
+	Description string  `hcl:"description,optional"`
+}
+
+// variableSetTarget is a workspace, project, or stack a variable set is
+// assigned to.
+type variableSetTarget struct {
+	ID   string
+	Name string
+}
+
+// variableSetAssignmentChange is one workspace, project, or stack to assign
+// the variable set to or remove it from.
+type variableSetAssignmentChange struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+}
+
+// loadVariableSetFile reads and validates a variable set sync file.
+func loadVariableSetFile(filename string) (*variableSetFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualPath := filename
+	switch filepath.Ext(virtualPath) {
+	case ".hcl", ".json":
+	default:
+		virtualPath += ".hcl"
+	}
+
+	var file variableSetFile
+	if err := hclsimple.Decode(virtualPath, data, nil, &file); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, v := range file.Variables {
+		if v.Category == "" {
+			v.Category = string(tfe.CategoryTerraform)
+		}
+		if v.Category != string(tfe.CategoryTerraform) && v.Category != string(tfe.CategoryEnv) {
+			return nil, fmt.Errorf("variable %q: category must be 'terraform' or 'env'", v.Key)
+		}
+
+		id := v.Category + "/" + v.Key
+		if seen[id] {
+			return nil, fmt.Errorf("%s variable %q is defined more than once", v.Category, v.Key)
+		}
+		seen[id] = true
+
+		sources := 0
+		for _, set := range []bool{v.Value != nil, v.ValueEnv != "", v.ValueFile != ""} {
+			if set {
+				sources++
+			}
+		}
+		if sources > 1 {
+			return nil, fmt.Errorf("variable %q: only one of value, value_env, or value_file may be set", v.Key)
+		}
+		if v.Sensitive && v.Value != nil {
+			return nil, fmt.Errorf("variable %q: sensitive values must come from value_env or value_file, not value", v.Key)
+		}
+		if !v.Sensitive && sources == 0 {
+			return nil, fmt.Errorf("variable %q: value is required unless the variable is sensitive", v.Key)
+		}
+	}
+	return &file, nil
+}
+
+// spec returns the desired variable and whether its value is known. A
+// sensitive variable without value_env or value_file keeps its current
+// value. value_file paths are relative to the sync file.
+func (v *variableSetFileVariable) spec(dir string) (*variableSpec, bool, error) {
+	spec := &variableSpec{
+		Key:         v.Key,
+		Category:    v.Category,
+		HCL:         v.HCL,
+		Sensitive:   v.Sensitive,
+		Description: v.Description,
+	}
+
+	switch {
+	case v.Value != nil:
+		spec.Value = *v.Value
+	case v.ValueEnv != "":
+		value, ok := os.LookupEnv(v.ValueEnv)
+		if !ok {
+			return nil, false, fmt.Errorf("variable %q: environment variable %s is not set", v.Key, v.ValueEnv)
+		}
+		spec.Value = value
+	case v.ValueFile != "":
+		path := v.ValueFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("variable %q: %w", v.Key, err)
+		}
+		spec.Value = trimTrailingNewline(string(data))
+	default:
+		return spec, false, nil
+	}
+	return spec, true, nil
+}
+
+func variableSpecFromVariableSetVariable(v *tfe.VariableSetVariable) *variableSpec {
+	return &variableSpec{
+		ID:          v.ID,
+		Key:         v.Key,
+		Value:       v.Value,
+		Category:    string(v.Category),
+		HCL:         v.HCL,
+		Sensitive:   v.Sensitive,
+		Description: v.Description,
+	}
+}
+
+// planVariableSetVariableChanges plans the variable changes for a sync.
+// Variables whose value is not known are only updated when their HCL flag,
+// description, or sensitivity differ, and cannot be created.
+func planVariableSetVariableChanges(desired, existing []*variableSpec, unknown map[string]bool, prune bool) ([]*variableChange, error) {
+	changes := planVariableChanges(desired, existing, prune, []string{string(tfe.CategoryTerraform), string(tfe.CategoryEnv)})
+
+	var planned []*variableChange
+	for _, change := range changes {
+		if change.Desired == nil || !unknown[change.Category+"/"+change.Key] {
+			planned = append(planned, change)
+			continue
+		}
+		if change.Action == variableChangeCreate {
+			return nil, fmt.Errorf("%s variable %q does not exist yet; set value_env or value_file to create it", change.Category, change.Key)
+		}
+		have, want := change.Current, change.Desired
+		if have.HCL == want.HCL && have.Sensitive == want.Sensitive &&
+			(want.Description == "" || have.Description == want.Description) {
+			continue
+		}
+		planned = append(planned, change)
+	}
+	return planned, nil
+}
+
+// planVariableSetAssignments compares the targets a variable set is assigned
+// to with the desired ones. Extra targets are only removed with prune.
+func planVariableSetAssignments(kind string, current, desired []variableSetTarget, prune bool) []*variableSetAssignmentChange {
+	have := map[string]bool{}
+	for _, t := range current {
+		have[t.ID] = true
+	}
+	want := map[string]bool{}
+
+	var changes []*variableSetAssignmentChange
+	for _, t := range desired {
+		want[t.ID] = true
+		if !have[t.ID] {
+			changes = append(changes, &variableSetAssignmentChange{Action: variableSetAssignmentAdd, Type: kind, ID: t.ID, Name: t.Name})
+		}
+	}
+	if prune {
+		for _, t := range current {
+			if !want[t.ID] {
+				changes = append(changes, &variableSetAssignmentChange{Action: variableSetAssignmentRemove, Type: kind, ID: t.ID, Name: t.Name})
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Action != changes[j].Action {
+			return changes[i].Action < changes[j].Action
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes
+}
+
+// applyVariableSetVariableChange makes one planned change to a variable set
+// variable. As with workspace variables, Sensitive is only ever set to true,
+// and the value is left alone when it is not known.
+func applyVariableSetVariableChange(ctx context.Context, svc variableSetVariableSyncService, variableSetID string, change *variableChange, valueKnown bool) error {
+	switch change.Action {
+	case variableChangeCreate:
+		options := &tfe.VariableSetVariableCreateOptions{
+			Key:       tfe.String(change.Desired.Key),
+			Value:     tfe.String(change.Desired.Value),
+			Category:  tfe.Category(tfe.CategoryType(change.Desired.Category)),
+			HCL:       tfe.Bool(change.Desired.HCL),
+			Sensitive: tfe.Bool(change.Desired.Sensitive),
+		}
+		if change.Desired.Description != "" {
+			options.Description = tfe.String(change.Desired.Description)
+		}
+		_, err := svc.Create(ctx, variableSetID, options)
+		return err
+	case variableChangeUpdate:
+		options := &tfe.VariableSetVariableUpdateOptions{
+			HCL: tfe.Bool(change.Desired.HCL),
+		}
+		if valueKnown {
+			options.Value = tfe.String(change.Desired.Value)
+		}
+		if change.Desired.Sensitive {
+			options.Sensitive = tfe.Bool(true)
+		}
+		if change.Desired.Description != "" {
+			options.Description = tfe.String(change.Desired.Description)
+		}
+		_, err := svc.Update(ctx, variableSetID, change.Current.ID, options)
+		return err
+	case variableChangeDelete:
+		return svc.Delete(ctx, variableSetID, change.Current.ID)
+	}
+	return fmt.Errorf("unknown action %q", change.Action)
+}
+
+// VariableSetSyncCommand is a command to make a variable set match a file
+type VariableSetSyncCommand struct {
+	Meta
+	organization string
+	id           string
+	file         string
+	prune        bool
+	autoApprove  bool
+	format       string
+	varSetSvc    variableSetSyncService
+	varSetVarSvc variableSetVariableSyncService
+	workspaceSvc workspaceRefReader
+}
+
+// Run executes the variableset sync command
+func (c *VariableSetSyncCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("variableset sync")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name, for workspaces listed by name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.id, "id", "", "Variable set ID (required)")
+	flags.StringVar(&c.file, "file", "", "Variable set file, HCL or JSON (required)")
+	flags.BoolVar(&c.prune, "prune", false, "Delete variables and remove assignments that are not in the file")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip confirmation")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.id == "" {
+		c.Ui.Error("Error: -id flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.file == "" {
+		c.Ui.Error("Error: -file flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if !c.Meta.ValidateID(c.id, "-id") {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	file, err := loadVariableSetFile(c.file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading %s: %s", c.file, err))
+		return 1
+	}
+
+	var desired []*variableSpec
+	unknown := map[string]bool{}
+	for _, v := range file.Variables {
+		spec, known, err := v.spec(filepath.Dir(c.file))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if !known {
+			unknown[spec.Category+"/"+spec.Key] = true
+		}
+		desired = append(desired, spec)
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	set, err := c.varSetService(client).Read(ctx, c.id, &tfe.VariableSetReadOptions{
+		Include: &[]tfe.VariableSetIncludeOpt{"workspaces", "projects"},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading variable set: %s", err))
+		return 1
+	}
+
+	varSvc := c.varSetVarService(client)
+	variables, err := listAllVariableSetVariables(ctx, varSvc, set.ID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing variables: %s", err))
+		return 1
+	}
+
+	var existing []*variableSpec
+	for _, v := range variables {
+		existing = append(existing, variableSpecFromVariableSetVariable(v))
+	}
+
+	changes, err := planVariableSetVariableChanges(desired, existing, unknown, c.prune)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	assignments, err := c.planAssignments(ctx, client, set, file)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	if changes == nil {
+		changes = []*variableChange{}
+	}
+	if assignments == nil {
+		assignments = []*variableSetAssignmentChange{}
+	}
+
+	if c.Meta.DryRun {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"action":      "sync",
+			"resource":    "variableset",
+			"id":          set.ID,
+			"variables":   changes,
+			"assignments": assignments,
+		})
+		return 0
+	}
+
+	if len(changes) == 0 && len(assignments) == 0 {
+		if c.format == "json" {
+			c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+				"variables":   changes,
+				"assignments": assignments,
+			})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("Variable set '%s' already matches %s", set.Name, c.file))
+		return 0
+	}
+
+	if !c.autoApprove {
+		c.renderPlan(changes, assignments)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Do you want to apply these changes to variable set '%s'?", set.Name))
+		c.Ui.Output("Only 'yes' will be accepted to confirm.")
+		c.Ui.Output("")
+
+		response, err := c.Ui.Ask("Enter a value: ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading input: %s", err))
+			return 1
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			c.Ui.Output("Sync cancelled.")
+			return 0
+		}
+	}
+
+	for _, change := range changes {
+		known := !unknown[change.Category+"/"+change.Key]
+		if err := applyVariableSetVariableChange(ctx, varSvc, set.ID, change, known); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: failed to %s %s variable %q: %s", change.Action, change.Category, change.Key, err))
+			return 1
+		}
+	}
+
+	if err := c.applyAssignments(ctx, client, set, assignments); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter("json").JSON(map[string]interface{}{
+			"variables":   changes,
+			"assignments": assignments,
+		})
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	for _, change := range assignments {
+		counts[change.Action]++
+	}
+	c.Ui.Output(fmt.Sprintf("Synced variable set '%s': %d created, %d updated, %d deleted; %d assignments added, %d removed",
+		set.Name, counts[variableChangeCreate], counts[variableChangeUpdate], counts[variableChangeDelete],
+		counts[variableSetAssignmentAdd], counts[variableSetAssignmentRemove]))
+	return 0
+}
+
+// planAssignments plans the workspace, project, and stack assignment
+// changes for the lists present in the file.
+func (c *VariableSetSyncCommand) planAssignments(ctx context.Context, client *client.Client, set *tfe.VariableSet, file *variableSetFile) ([]*variableSetAssignmentChange, error) {
+	var changes []*variableSetAssignmentChange
+
+	if file.Workspaces != nil {
+		organization := c.organization
+		if organization == "" && set.Organization != nil {
+			organization = set.Organization.Name
+		}
+		resolved, err := resolveWorkspaceRefs(ctx, c.workspaceService(client), organization, *file.Workspaces)
+		if err != nil {
+			return nil, err
+		}
+		var current, desired []variableSetTarget
+		for _, ws := range set.Workspaces {
+			current = append(current, variableSetTarget{ID: ws.ID, Name: ws.Name})
+		}
+		for _, ws := range resolved {
+			desired = append(desired, variableSetTarget{ID: ws.ID, Name: ws.Name})
+		}
+		changes = append(changes, planVariableSetAssignments("workspace", current, desired, c.prune)...)
+	}
+
+	if file.Projects != nil {
+		var current, desired []variableSetTarget
+		for _, p := range set.Projects {
+			current = append(current, variableSetTarget{ID: p.ID, Name: p.Name})
+		}
+		for _, id := range *file.Projects {
+			desired = append(desired, variableSetTarget{ID: strings.TrimSpace(id)})
+		}
+		changes = append(changes, planVariableSetAssignments("project", current, desired, c.prune)...)
+	}
+
+	if file.Stacks != nil {
+		var current, desired []variableSetTarget
+		for _, s := range set.Stacks {
+			current = append(current, variableSetTarget{ID: s.ID, Name: s.Name})
+		}
+		for _, id := range *file.Stacks {
+			desired = append(desired, variableSetTarget{ID: strings.TrimSpace(id)})
+		}
+		changes = append(changes, planVariableSetAssignments("stack", current, desired, c.prune)...)
+	}
+
+	return changes, nil
+}
+
+// applyAssignments makes the planned assignment changes. Workspaces are
+// replaced in one call, as variableset update-workspaces does; projects and
+// stacks are applied and removed as variableset apply and remove do.
+func (c *VariableSetSyncCommand) applyAssignments(ctx context.Context, client *client.Client, set *tfe.VariableSet, changes []*variableSetAssignmentChange) error {
+	svc := c.varSetService(client)
+
+	byType := map[string]map[string][]string{}
+	for _, change := range changes {
+		if byType[change.Type] == nil {
+			byType[change.Type] = map[string][]string{}
+		}
+		byType[change.Type][change.Action] = append(byType[change.Type][change.Action], change.ID)
+	}
+
+	if ws := byType["workspace"]; ws != nil {
+		removed := map[string]bool{}
+		for _, id := range ws[variableSetAssignmentRemove] {
+			removed[id] = true
+		}
+		workspaces := []*tfe.Workspace{}
+		for _, w := range set.Workspaces {
+			if !removed[w.ID] {
+				workspaces = append(workspaces, &tfe.Workspace{ID: w.ID})
+			}
+		}
+		for _, id := range ws[variableSetAssignmentAdd] {
+			workspaces = append(workspaces, &tfe.Workspace{ID: id})
+		}
+		if _, err := svc.UpdateWorkspaces(ctx, set.ID, &tfe.VariableSetUpdateWorkspacesOptions{Workspaces: workspaces}); err != nil {
+			return fmt.Errorf("updating workspaces: %w", err)
+		}
+	}
+
+	if p := byType["project"]; p != nil {
+		if ids := p[variableSetAssignmentAdd]; len(ids) > 0 {
+			projects := make([]*tfe.Project, 0, len(ids))
+			for _, id := range ids {
+				projects = append(projects, &tfe.Project{ID: id})
+			}
+			if err := svc.ApplyToProjects(ctx, set.ID, tfe.VariableSetApplyToProjectsOptions{Projects: projects}); err != nil {
+				return fmt.Errorf("applying to projects: %w", err)
+			}
+		}
+		if ids := p[variableSetAssignmentRemove]; len(ids) > 0 {
+			projects := make([]*tfe.Project, 0, len(ids))
+			for _, id := range ids {
+				projects = append(projects, &tfe.Project{ID: id})
+			}
+			if err := svc.RemoveFromProjects(ctx, set.ID, tfe.VariableSetRemoveFromProjectsOptions{Projects: projects}); err != nil {
+				return fmt.Errorf("removing from projects: %w", err)
+			}
+		}
+	}
+
+	if s := byType["stack"]; s != nil {
+		if ids := s[variableSetAssignmentAdd]; len(ids) > 0 {
+			stacks := make([]*tfe.Stack, 0, len(ids))
+			for _, id := range ids {
+				stacks = append(stacks, &tfe.Stack{ID: id})
+			}
+			if err := svc.ApplyToStacks(ctx, set.ID, &tfe.VariableSetApplyToStacksOptions{Stacks: stacks}); err != nil {
+				return fmt.Errorf("applying to stacks: %w", err)
+			}
+		}
+		if ids := s[variableSetAssignmentRemove]; len(ids) > 0 {
+			stacks := make([]*tfe.Stack, 0, len(ids))
+			for _, id := range ids {
+				stacks = append(stacks, &tfe.Stack{ID: id})
+			}
+			if err := svc.RemoveFromStacks(ctx, set.ID, &tfe.VariableSetRemoveFromStacksOptions{Stacks: stacks}); err != nil {
+				return fmt.Errorf("removing from stacks: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderPlan prints the variable changes followed by the assignment changes.
+func (c *VariableSetSyncCommand) renderPlan(changes []*variableChange, assignments []*variableSetAssignmentChange) {
+	if len(changes) > 0 {
+		renderVariableChanges(&c.Meta, changes)
+	}
+	if len(assignments) > 0 {
+		if len(changes) > 0 {
+			c.Ui.Output("")
+		}
+		counts := map[string]int{}
+		var rows [][]string
+		for _, a := range assignments {
+			counts[a.Action]++
+			rows = append(rows, []string{a.Action, a.Type, a.ID, a.Name})
+		}
+		c.Meta.NewFormatter("table").Table([]string{"Action", "Type", "ID", "Name"}, rows)
+		c.Ui.Output("")
+		c.Ui.Output(fmt.Sprintf("Assignments: %d to add, %d to remove.",
+			counts[variableSetAssignmentAdd], counts[variableSetAssignmentRemove]))
+	}
+}
+
+func (c *VariableSetSyncCommand) varSetService(client *client.Client) variableSetSyncService {
+	if c.varSetSvc != nil {
+		return c.varSetSvc
+	}
+	return client.VariableSets
+}
+
+func (c *VariableSetSyncCommand) varSetVarService(client *client.Client) variableSetVariableSyncService {
+	if c.varSetVarSvc != nil {
+		return c.varSetVarSvc
+	}
+	return client.VariableSetVariables
+}
+
+func (c *VariableSetSyncCommand) workspaceService(client *client.Client) workspaceRefReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the variableset sync command
+func (c *VariableSetSyncCommand) Help() string {
+	helpText := `
+Usage: hcptf variableset sync [options]
+
+  Make a variable set match a declarative HCL or JSON file: create missing
+  variables, update changed ones, and assign the set to the workspaces,
+  projects, and stacks the file lists. The changes are shown and confirmed
+  before they are made; use -dry-run to print them as JSON without changing
+  anything.
+
+  With -prune, variables that are not in the file are deleted and the set is
+  removed from workspaces, projects, and stacks that are not listed. An
+  assignment list that is left out of the file is not changed.
+
+  Sensitive variables take their value from value_env or value_file, never
+  from value, so secrets stay out of the file. A sensitive variable with
+  neither keeps its current value. Existing sensitive values cannot be read
+  back, so variables with a value_env or value_file are always updated.
+
+  Example file:
+
+    workspaces = ["prod-network", "ws-abc123"]   # names or IDs
+    projects   = ["prj-abc123"]
+    stacks     = ["st-abc123"]
+
+    variable "AWS_REGION" {
+      category = "env"
+      value    = "us-east-1"
+    }
+
+    variable "AWS_SECRET_ACCESS_KEY" {
+      category  = "env"
+      sensitive = true
+      value_env = "AWS_SECRET_ACCESS_KEY"
+    }
+
+Options:
+
+  -id=<id>              Variable set ID (required)
+  -file=<path>          Variable set file, HCL or JSON (required)
+  -organization=<name>  Organization for workspace names (default: the
+                        variable set's organization)
+  -org=<name>           Alias for -organization
+  -prune                Delete variables and remove assignments that are
+                        not in the file
+  -auto-approve         Skip confirmation
+  -output=<format>      Output format: table (default) or json
+
+Example:
+
+  hcptf variableset sync -id=varset-abc123 -file=aws-credentials.hcl
+  hcptf variableset sync -id=varset-abc123 -file=aws-credentials.hcl -prune -dry-run
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the variableset sync command
+func (c *VariableSetSyncCommand) Synopsis() string {
+	return "Make a variable set's variables and assignments match a file"
+}
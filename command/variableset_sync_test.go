@@ -0,0 +1,400 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+type mockVariableSetSyncService struct {
+	set              *tfe.VariableSet
+	workspaceUpdates [][]*tfe.Workspace
+	projectApplies   [][]*tfe.Project
+	projectRemoves   [][]*tfe.Project
+	stackApplies     [][]*tfe.Stack
+	stackRemoves     [][]*tfe.Stack
+}
+
+func (m *mockVariableSetSyncService) Read(_ context.Context, _ string, _ *tfe.VariableSetReadOptions) (*tfe.VariableSet, error) {
+	return m.set, nil
+}
+
+func (m *mockVariableSetSyncService) ApplyToProjects(_ context.Context, _ string, options tfe.VariableSetApplyToProjectsOptions) error {
+	m.projectApplies = append(m.projectApplies, options.Projects)
+	return nil
+}
+
+func (m *mockVariableSetSyncService) ApplyToStacks(_ context.Context, _ string, options *tfe.VariableSetApplyToStacksOptions) error {
+	m.stackApplies = append(m.stackApplies, options.Stacks)
+	return nil
+}
+
+func (m *mockVariableSetSyncService) RemoveFromWorkspaces(_ context.Context, _ string, _ *tfe.VariableSetRemoveFromWorkspacesOptions) error {
+	return fmt.Errorf("unexpected RemoveFromWorkspaces call")
+}
+
+func (m *mockVariableSetSyncService) RemoveFromProjects(_ context.Context, _ string, options tfe.VariableSetRemoveFromProjectsOptions) error {
+	m.projectRemoves = append(m.projectRemoves, options.Projects)
+	return nil
+}
+
+func (m *mockVariableSetSyncService) RemoveFromStacks(_ context.Context, _ string, options *tfe.VariableSetRemoveFromStacksOptions) error {
+	m.stackRemoves = append(m.stackRemoves, options.Stacks)
+	return nil
+}
+
+func (m *mockVariableSetSyncService) UpdateWorkspaces(_ context.Context, _ string, options *tfe.VariableSetUpdateWorkspacesOptions) (*tfe.VariableSet, error) {
+	m.workspaceUpdates = append(m.workspaceUpdates, options.Workspaces)
+	return m.set, nil
+}
+
+type mockVariableSetVariableSyncService struct {
+	variables []*tfe.VariableSetVariable
+	created   []*tfe.VariableSetVariableCreateOptions
+	updated   map[string]*tfe.VariableSetVariableUpdateOptions
+	deleted   []string
+}
+
+func (m *mockVariableSetVariableSyncService) List(_ context.Context, _ string, _ *tfe.VariableSetVariableListOptions) (*tfe.VariableSetVariableList, error) {
+	return &tfe.VariableSetVariableList{Items: m.variables}, nil
+}
+
+func (m *mockVariableSetVariableSyncService) Create(_ context.Context, _ string, options *tfe.VariableSetVariableCreateOptions) (*tfe.VariableSetVariable, error) {
+	m.created = append(m.created, options)
+	return &tfe.VariableSetVariable{ID: fmt.Sprintf("var-new-%d", len(m.created)), Key: *options.Key}, nil
+}
+
+func (m *mockVariableSetVariableSyncService) Update(_ context.Context, _ string, variableID string, options *tfe.VariableSetVariableUpdateOptions) (*tfe.VariableSetVariable, error) {
+	if m.updated == nil {
+		m.updated = map[string]*tfe.VariableSetVariableUpdateOptions{}
+	}
+	m.updated[variableID] = options
+	return &tfe.VariableSetVariable{ID: variableID}, nil
+}
+
+func (m *mockVariableSetVariableSyncService) Delete(_ context.Context, _ string, variableID string) error {
+	m.deleted = append(m.deleted, variableID)
+	return nil
+}
+
+func writeVariableSetFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newVariableSetSyncFixture() (*mockVariableSetSyncService, *mockVariableSetVariableSyncService, *mockRemoteStateConsumerManager) {
+	sets := &mockVariableSetSyncService{set: &tfe.VariableSet{
+		ID:           "varset-1",
+		Name:         "aws-credentials",
+		Organization: &tfe.Organization{Name: "my-org"},
+		Workspaces:   []*tfe.Workspace{{ID: "ws-keep", Name: "keep"}, {ID: "ws-old", Name: "old"}},
+		Projects:     []*tfe.Project{{ID: "prj-old", Name: "legacy"}},
+		Stacks:       []*tfe.Stack{{ID: "st-keep", Name: "network"}},
+	}}
+	vars := &mockVariableSetVariableSyncService{variables: []*tfe.VariableSetVariable{
+		{ID: "var-region", Key: "AWS_REGION", Value: "us-west-2", Category: tfe.CategoryEnv},
+		{ID: "var-secret", Key: "AWS_SECRET_ACCESS_KEY", Category: tfe.CategoryEnv, Sensitive: true},
+		{ID: "var-extra", Key: "EXTRA", Value: "x", Category: tfe.CategoryEnv},
+	}}
+	workspaces := &mockRemoteStateConsumerManager{workspaces: []*tfe.Workspace{
+		{ID: "ws-keep", Name: "keep"},
+		{ID: "ws-new", Name: "new"},
+	}}
+	return sets, vars, workspaces
+}
+
+func TestLoadVariableSetFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "hcl",
+			file: "set.hcl",
+			content: `
+workspaces = ["prod"]
+variable "region" {
+  value = "us-east-1"
+}
+variable "TOKEN" {
+  category  = "env"
+  sensitive = true
+  value_env = "TOKEN"
+}
+`,
+		},
+		{
+			name:    "json",
+			file:    "set.json",
+			content: `{"projects": ["prj-1"], "variable": {"region": {"value": "us-east-1"}}}`,
+		},
+		{
+			name:    "sensitive literal",
+			file:    "set.hcl",
+			content: "variable \"TOKEN\" {\n  sensitive = true\n  value = \"abc\"\n}\n",
+			wantErr: "sensitive values must come from value_env or value_file",
+		},
+		{
+			name:    "missing value",
+			file:    "set.hcl",
+			content: "variable \"region\" {}\n",
+			wantErr: "value is required",
+		},
+		{
+			name:    "duplicate",
+			file:    "set.hcl",
+			content: "variable \"a\" {\n  value = \"1\"\n}\nvariable \"a\" {\n  value = \"2\"\n}\n",
+			wantErr: "more than once",
+		},
+		{
+			name:    "two sources",
+			file:    "set.hcl",
+			content: "variable \"a\" {\n  value = \"1\"\n  value_env = \"A\"\n}\n",
+			wantErr: "only one of",
+		},
+		{
+			name:    "bad category",
+			file:    "set.hcl",
+			content: "variable \"a\" {\n  value = \"1\"\n  category = \"policy-set\"\n}\n",
+			wantErr: "category",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := loadVariableSetFile(writeVariableSetFile(t, tt.file, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(file.Variables) == 0 || file.Variables[0].Category != "terraform" {
+				t.Fatalf("expected terraform category default, got %#v", file.Variables)
+			}
+		})
+	}
+}
+
+func TestLoadVariableSetFileLeavesOmittedListsNil(t *testing.T) {
+	file, err := loadVariableSetFile(writeVariableSetFile(t, "set.hcl", "stacks = []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Workspaces != nil || file.Projects != nil {
+		t.Fatalf("expected omitted lists to be nil")
+	}
+	if file.Stacks == nil || len(*file.Stacks) != 0 {
+		t.Fatalf("expected empty stacks list, got %#v", file.Stacks)
+	}
+}
+
+func TestPlanVariableSetAssignments(t *testing.T) {
+	current := []variableSetTarget{{ID: "ws-a"}, {ID: "ws-b"}}
+	desired := []variableSetTarget{{ID: "ws-b"}, {ID: "ws-c"}}
+
+	changes := planVariableSetAssignments("workspace", current, desired, false)
+	if len(changes) != 1 || changes[0].Action != variableSetAssignmentAdd || changes[0].ID != "ws-c" {
+		t.Fatalf("unexpected changes without prune: %#v", changes)
+	}
+
+	changes = planVariableSetAssignments("workspace", current, desired, true)
+	if len(changes) != 2 || changes[1].Action != variableSetAssignmentRemove || changes[1].ID != "ws-a" {
+		t.Fatalf("unexpected changes with prune: %#v", changes)
+	}
+}
+
+func TestVariableSetSyncAppliesPlan(t *testing.T) {
+	t.Setenv("TEST_AWS_SECRET", "rotated")
+	path := writeVariableSetFile(t, "set.hcl", `
+workspaces = ["keep", "ws-new"]
+projects   = ["prj-new"]
+stacks     = ["st-keep"]
+
+variable "AWS_REGION" {
+  category = "env"
+  value    = "us-east-1"
+}
+
+variable "AWS_SECRET_ACCESS_KEY" {
+  category  = "env"
+  sensitive = true
+  value_env = "TEST_AWS_SECRET"
+}
+
+variable "bucket" {
+  value = "logs"
+}
+`)
+
+	sets, vars, workspaces := newVariableSetSyncFixture()
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("yes\n")
+	cmd := &VariableSetSyncCommand{
+		Meta:         newTestMeta(ui),
+		varSetSvc:    sets,
+		varSetVarSvc: vars,
+		workspaceSvc: workspaces,
+	}
+
+	if code := cmd.Run([]string{"-id=varset-1", "-file=" + path, "-prune"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(vars.created) != 1 || *vars.created[0].Key != "bucket" {
+		t.Fatalf("expected bucket to be created, got %#v", vars.created)
+	}
+	if opts := vars.updated["var-region"]; opts == nil || *opts.Value != "us-east-1" {
+		t.Fatalf("expected region update, got %#v", vars.updated)
+	}
+	if opts := vars.updated["var-secret"]; opts == nil || *opts.Value != "rotated" || opts.Sensitive == nil || !*opts.Sensitive {
+		t.Fatalf("expected secret update, got %#v", vars.updated["var-secret"])
+	}
+	if len(vars.deleted) != 1 || vars.deleted[0] != "var-extra" {
+		t.Fatalf("expected EXTRA to be deleted, got %#v", vars.deleted)
+	}
+
+	if len(sets.workspaceUpdates) != 1 {
+		t.Fatalf("expected one workspace update, got %d", len(sets.workspaceUpdates))
+	}
+	var ids []string
+	for _, ws := range sets.workspaceUpdates[0] {
+		ids = append(ids, ws.ID)
+	}
+	if strings.Join(ids, ",") != "ws-keep,ws-new" {
+		t.Fatalf("unexpected workspaces: %v", ids)
+	}
+	if len(sets.projectApplies) != 1 || sets.projectApplies[0][0].ID != "prj-new" {
+		t.Fatalf("expected prj-new to be applied, got %#v", sets.projectApplies)
+	}
+	if len(sets.projectRemoves) != 1 || sets.projectRemoves[0][0].ID != "prj-old" {
+		t.Fatalf("expected prj-old to be removed, got %#v", sets.projectRemoves)
+	}
+	if len(sets.stackApplies) != 0 || len(sets.stackRemoves) != 0 {
+		t.Fatalf("expected no stack changes")
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "Plan: 1 to create, 2 to update, 1 to delete.") {
+		t.Fatalf("expected variable plan, got %q", out)
+	}
+	if !strings.Contains(out, "Assignments: 2 to add, 2 to remove.") {
+		t.Fatalf("expected assignment plan, got %q", out)
+	}
+	if strings.Contains(out, "rotated") {
+		t.Fatalf("sensitive value printed: %q", out)
+	}
+}
+
+func TestVariableSetSyncWithoutPruneOnlyAdds(t *testing.T) {
+	path := writeVariableSetFile(t, "set.hcl", `
+workspaces = ["new"]
+projects   = []
+
+variable "AWS_REGION" {
+  category = "env"
+  value    = "us-west-2"
+}
+
+variable "AWS_SECRET_ACCESS_KEY" {
+  category  = "env"
+  sensitive = true
+}
+`)
+
+	sets, vars, workspaces := newVariableSetSyncFixture()
+	ui := cli.NewMockUi()
+	cmd := &VariableSetSyncCommand{
+		Meta:         newTestMeta(ui),
+		varSetSvc:    sets,
+		varSetVarSvc: vars,
+		workspaceSvc: workspaces,
+	}
+
+	if code := cmd.Run([]string{"-id=varset-1", "-file=" + path, "-auto-approve"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(vars.created) != 0 || len(vars.updated) != 0 || len(vars.deleted) != 0 {
+		t.Fatalf("expected no variable changes, got created=%v updated=%v deleted=%v", vars.created, vars.updated, vars.deleted)
+	}
+	if len(sets.workspaceUpdates) != 1 || len(sets.workspaceUpdates[0]) != 3 {
+		t.Fatalf("expected workspaces to be extended, got %#v", sets.workspaceUpdates)
+	}
+	if len(sets.projectRemoves) != 0 {
+		t.Fatalf("expected no project removals without -prune")
+	}
+}
+
+func TestVariableSetSyncRequiresValueForNewSensitiveVariable(t *testing.T) {
+	path := writeVariableSetFile(t, "set.hcl", "variable \"NEW_TOKEN\" {\n  category = \"env\"\n  sensitive = true\n}\n")
+
+	sets, vars, workspaces := newVariableSetSyncFixture()
+	ui := cli.NewMockUi()
+	cmd := &VariableSetSyncCommand{
+		Meta:         newTestMeta(ui),
+		varSetSvc:    sets,
+		varSetVarSvc: vars,
+		workspaceSvc: workspaces,
+	}
+
+	if code := cmd.Run([]string{"-id=varset-1", "-file=" + path}); code != 1 {
+		t.Fatalf("expected exit 1")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "does not exist yet") {
+		t.Fatalf("unexpected error: %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestVariableSetSyncDryRun(t *testing.T) {
+	path := writeVariableSetFile(t, "set.json", `{"stacks": ["st-new"], "variable": {"bucket": {"value": "logs"}}}`)
+
+	sets, vars, workspaces := newVariableSetSyncFixture()
+	ui := cli.NewMockUi()
+	cmd := &VariableSetSyncCommand{
+		Meta:         newTestMeta(ui),
+		varSetSvc:    sets,
+		varSetVarSvc: vars,
+		workspaceSvc: workspaces,
+	}
+
+	output, code := captureStdout(t, func() int {
+		return cmd.Run([]string{"-id=varset-1", "-file=" + path, "-dry-run"})
+	})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(vars.created) != 0 || len(sets.stackApplies) != 0 {
+		t.Fatalf("expected no API changes during dry-run")
+	}
+
+	var result struct {
+		Variables   []map[string]interface{} `json:"variables"`
+		Assignments []map[string]interface{} `json:"assignments"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if len(result.Variables) != 1 || result.Variables[0]["key"] != "bucket" {
+		t.Fatalf("unexpected variables: %#v", result.Variables)
+	}
+	if len(result.Assignments) != 1 || result.Assignments[0]["id"] != "st-new" {
+		t.Fatalf("unexpected assignments: %#v", result.Assignments)
+	}
+}
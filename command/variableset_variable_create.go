@@ -12,7 +12,7 @@ type VariableSetVariableCreateCommand struct {
 	Meta
 	variableSetID string
 	key           string
-	value         string
+	value         variableValueFlags
 	category      string
 	sensitive     bool
 	hcl           bool
@@ -25,7 +25,7 @@ func (c *VariableSetVariableCreateCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet("variableset variable create")
 	flags.StringVar(&c.variableSetID, "variableset-id", "", "Variable set ID (required)")
 	flags.StringVar(&c.key, "key", "", "Variable key/name (required)")
-	flags.StringVar(&c.value, "value", "", "Variable value (required)")
+	c.value.register(flags, "Variable value (not allowed with -sensitive)")
 	flags.StringVar(&c.category, "category", "terraform", "Variable category: terraform or env")
 	flags.BoolVar(&c.sensitive, "sensitive", false, "Mark variable as sensitive")
 	flags.BoolVar(&c.hcl, "hcl", false, "Parse variable as HCL")
@@ -49,12 +49,6 @@ func (c *VariableSetVariableCreateCommand) Run(args []string) int {
 		return 1
 	}
 
-	if c.Meta.JSONInput == "" && c.value == "" && !c.sensitive {
-		c.Ui.Error("Error: -value flag is required")
-		c.Ui.Error(c.Help())
-		return 1
-	}
-
 	// Validate category
 	if c.Meta.JSONInput == "" && c.category != "terraform" && c.category != "env" {
 		c.Ui.Error("Error: -category must be 'terraform' or 'env'")
@@ -62,6 +56,21 @@ func (c *VariableSetVariableCreateCommand) Run(args []string) int {
 		return 1
 	}
 
+	var value string
+	if c.Meta.JSONInput == "" {
+		resolved, ok, err := c.value.resolve(c.Ui, c.key, c.sensitive, true)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if !ok && !c.sensitive {
+			c.Ui.Error("Error: a value is required; use -value, -value-file, -value-stdin, or -value-env")
+			c.Ui.Error(c.Help())
+			return 1
+		}
+		value = resolved
+	}
+
 	// Get API client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -84,7 +93,7 @@ func (c *VariableSetVariableCreateCommand) Run(args []string) int {
 	} else {
 		options = tfe.VariableSetVariableCreateOptions{
 			Key:       tfe.String(c.key),
-			Value:     tfe.String(c.value),
+			Value:     tfe.String(value),
 			Category:  tfe.Category(tfe.CategoryType(c.category)),
 			Sensitive: tfe.Bool(c.sensitive),
 			HCL:       tfe.Bool(c.hcl),
@@ -107,8 +116,13 @@ func (c *VariableSetVariableCreateCommand) Run(args []string) int {
 		c.Ui.Error(c.Help())
 		return 1
 	}
+	if err := checkInlineJSONSecret(&c.Meta, options.Value, options.Sensitive); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
 
 	if c.Meta.DryRun {
+		options.Value = redactVariableValue(options.Value)
 		formatter := c.Meta.NewFormatter("json")
 		formatter.JSON(map[string]interface{}{
 			"action":         "create",
@@ -157,11 +171,21 @@ Usage: hcptf variableset variable create [options]
 
   Add a variable to a variable set.
 
+  The value can be given with exactly one of -value, -value-file,
+  -value-stdin, or -value-env. If none is given and stdin is a terminal,
+  the value is prompted for with input hidden. Sensitive values cannot be
+  passed with -value, so they never appear in shell history or process
+  listings. A single trailing newline is removed from files and stdin.
+  -dry-run output shows the value as (redacted).
+
 Options:
 
   -variableset-id=<id>  Variable set ID (required)
   -key=<key>            Variable key/name (required)
-  -value=<value>        Variable value (required unless sensitive)
+  -value=<value>        Variable value (not allowed with -sensitive)
+  -value-file=<path>    Read the value from a file
+  -value-stdin          Read the value from stdin
+  -value-env=<name>     Read the value from an environment variable
   -category=<type>      Variable category: terraform (default) or env
   -sensitive            Mark variable as sensitive (default: false)
   -hcl                  Parse variable as HCL (default: false)
@@ -171,7 +195,7 @@ Options:
 Example:
 
   hcptf variableset variable create -variableset-id=varset-12345 -key=region -value=us-east-1
-  hcptf variableset variable create -variableset-id=varset-12345 -key=password -value=secret -sensitive
+  hcptf variableset variable create -variableset-id=varset-12345 -key=password -sensitive -value-env=DB_PASSWORD
   hcptf variableset variable create -variableset-id=varset-12345 -key=PATH -value=/usr/bin -category=env
 `
 	return strings.TrimSpace(helpText)
@@ -151,7 +151,7 @@ func TestVariableSetVariableCreateFlagParsing(t *testing.T) {
 			flags := cmd.Meta.FlagSet("variableset-variable create")
 			flags.StringVar(&cmd.variableSetID, "variableset-id", "", "Variable set ID")
 			flags.StringVar(&cmd.key, "key", "", "Variable key")
-			flags.StringVar(&cmd.value, "value", "", "Variable value")
+			cmd.value.register(flags, "Variable value")
 			flags.StringVar(&cmd.category, "category", "terraform", "Category")
 			flags.BoolVar(&cmd.sensitive, "sensitive", false, "Sensitive")
 
@@ -167,8 +167,8 @@ func TestVariableSetVariableCreateFlagParsing(t *testing.T) {
 				t.Errorf("expected key %q, got %q", tt.expectedKey, cmd.key)
 			}
 
-			if cmd.value != tt.expectedValue {
-				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value)
+			if cmd.value.value != tt.expectedValue {
+				t.Errorf("expected value %q, got %q", tt.expectedValue, cmd.value.value)
 			}
 
 			if cmd.category != tt.expectedCategory {
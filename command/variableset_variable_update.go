@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
 )
 
 // VariableSetVariableUpdateCommand is a command to update a variable in a variable set
@@ -13,11 +14,12 @@ type VariableSetVariableUpdateCommand struct {
 	variableSetID string
 	variableID    string
 	key           string
-	value         string
+	value         variableValueFlags
 	sensitive     string
 	hcl           string
 	description   string
 	format        string
+	readSvc       variableSetVariableReader
 }
 
 // Run executes the variable set variable update command
@@ -26,7 +28,7 @@ func (c *VariableSetVariableUpdateCommand) Run(args []string) int {
 	flags.StringVar(&c.variableSetID, "variableset-id", "", "Variable set ID (required)")
 	flags.StringVar(&c.variableID, "variable-id", "", "Variable ID (required)")
 	flags.StringVar(&c.key, "key", "", "Variable key/name")
-	flags.StringVar(&c.value, "value", "", "Variable value")
+	c.value.register(flags, "Variable value (not allowed for sensitive variables)")
 	flags.StringVar(&c.sensitive, "sensitive", "", "Mark variable as sensitive (true or false)")
 	flags.StringVar(&c.hcl, "hcl", "", "Parse variable as HCL (true or false)")
 	flags.StringVar(&c.description, "description", "", "Variable description")
@@ -77,8 +79,29 @@ func (c *VariableSetVariableUpdateCommand) Run(args []string) int {
 			options.Key = tfe.String(c.key)
 		}
 
-		if c.value != "" {
-			options.Value = tfe.String(c.value)
+		// -value is refused for sensitive variables, including ones that
+		// are already sensitive, so check the current variable first.
+		sensitive := c.sensitive == "true"
+		if c.value.value != "" && !sensitive {
+			current, err := c.variableReadService(client).Read(client.Context(), c.variableSetID, c.variableID)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error reading variable: %s", err))
+				return 1
+			}
+			sensitive = current.Sensitive
+		}
+
+		prompt := c.key
+		if prompt == "" {
+			prompt = c.variableID
+		}
+		value, ok, err := c.value.resolve(c.Ui, prompt, sensitive, false)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error: %s", err))
+			return 1
+		}
+		if ok {
+			options.Value = tfe.String(value)
 		}
 
 		if c.sensitive != "" {
@@ -118,8 +141,13 @@ func (c *VariableSetVariableUpdateCommand) Run(args []string) int {
 		c.Ui.Error(c.Help())
 		return 1
 	}
+	if err := checkInlineJSONSecret(&c.Meta, options.Value, options.Sensitive); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error: %s", err))
+		return 1
+	}
 
 	if c.Meta.DryRun {
+		options.Value = redactVariableValue(options.Value)
 		formatter := c.Meta.NewFormatter("json")
 		formatter.JSON(map[string]interface{}{
 			"action":         "update",
@@ -163,6 +191,13 @@ func (c *VariableSetVariableUpdateCommand) Run(args []string) int {
 	return 0
 }
 
+func (c *VariableSetVariableUpdateCommand) variableReadService(client *client.Client) variableSetVariableReader {
+	if c.readSvc != nil {
+		return c.readSvc
+	}
+	return client.VariableSetVariables
+}
+
 // Help returns help text for the variable set variable update command
 func (c *VariableSetVariableUpdateCommand) Help() string {
 	helpText := `
@@ -170,12 +205,22 @@ Usage: hcptf variableset variable update [options]
 
   Update a variable in a variable set.
 
+  A new value can be given with exactly one of -value, -value-file,
+  -value-stdin, or -value-env. -value is refused when the variable is or
+  is being made sensitive, so secrets never appear in shell history or
+  process listings. -value-stdin prompts with input hidden when stdin is a
+  terminal. A single trailing newline is removed from files and stdin.
+  -dry-run output shows the value as (redacted).
+
 Options:
 
   -variableset-id=<id>  Variable set ID (required)
   -variable-id=<id>     Variable ID (required)
   -key=<key>            Variable key/name
-  -value=<value>        Variable value
+  -value=<value>        Variable value (not allowed for sensitive variables)
+  -value-file=<path>    Read the value from a file
+  -value-stdin          Read the value from stdin
+  -value-env=<name>     Read the value from an environment variable
   -sensitive=<bool>     Mark variable as sensitive (true or false)
   -hcl=<bool>           Parse variable as HCL (true or false)
   -description=<text>   Variable description
@@ -187,6 +232,7 @@ Example:
 
   hcptf variableset variable update -variableset-id=varset-12345 -variable-id=var-abc123 -value=us-west-2
   hcptf variableset variable update -variableset-id=varset-12345 -variable-id=var-abc123 -sensitive=true
+  hcptf variableset variable update -variableset-id=varset-12345 -variable-id=var-abc123 -value-stdin
   hcptf variableset variable update -variableset-id=varset-12345 -variable-id=var-abc123 -key=new_name -description="Updated variable"
 `
 	return strings.TrimSpace(helpText)
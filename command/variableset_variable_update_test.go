@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/mitchellh/cli"
 )
 
@@ -109,3 +110,22 @@ func TestVariableSetVariableUpdateFlagParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestVariableSetVariableUpdateRejectsValueFlagForSensitiveVariable(t *testing.T) {
+	ui := cli.NewMockUi()
+	read := &mockVariableSetVariableReadService{response: &tfe.VariableSetVariable{ID: "var-1", Key: "password", Sensitive: true}}
+	cmd := &VariableSetVariableUpdateCommand{
+		Meta:    newTestMeta(ui),
+		readSvc: read,
+	}
+
+	if code := cmd.Run([]string{"-variableset-id=varset-1", "-variable-id=var-1", "-value=hunter2"}); code != 1 {
+		t.Fatalf("expected exit 1")
+	}
+	if read.lastID != "var-1" {
+		t.Fatalf("expected current variable to be read")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cannot be passed with -value") {
+		t.Fatalf("expected -value error, got %q", ui.ErrorWriter.String())
+	}
+}
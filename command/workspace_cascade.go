@@ -0,0 +1,540 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	cascadeOutcomeApplied    = "applied"
+	cascadeOutcomeNoChanges  = "no changes"
+	cascadeOutcomeSkipped    = "skipped"
+	cascadeOutcomeNotStarted = "not started"
+)
+
+// cascadeRunTriggerSource is the source of runs queued by a run trigger.
+const cascadeRunTriggerSource = tfe.RunSource("tfe-run-trigger")
+
+var errCascadeDeclined = errors.New("apply declined")
+
+// WorkspaceCascadeCommand is a command to apply a workspace and follow its run triggers
+type WorkspaceCascadeCommand struct {
+	Meta
+	organization  string
+	from          string
+	message       string
+	approve       bool
+	autoApprove   bool
+	pollInterval  time.Duration
+	timeout       time.Duration
+	format        string
+	workspaceSvc  workspaceReader
+	runTriggerSvc runTriggerLister
+	runSvc        runOrchestrator
+	planSvc       planReader
+}
+
+// cascadeResult records the run that was followed for one workspace.
+type cascadeResult struct {
+	Workspace string `json:"workspace"`
+	RunID     string `json:"run_id"`
+	Status    string `json:"status"`
+	Outcome   string `json:"outcome"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Run executes the workspace cascade command
+func (c *WorkspaceCascadeCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("workspace cascade")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.from, "from", "", "Workspace to start the cascade from (required)")
+	flags.StringVar(&c.message, "message", "Queued by hcptf workspace cascade", "Run message for the starting workspace")
+	flags.BoolVar(&c.approve, "approve", false, "Approve runs that wait for confirmation after showing the plan summary")
+	flags.BoolVar(&c.autoApprove, "auto-approve", false, "Skip the confirmation prompt when approving runs")
+	flags.DurationVar(&c.pollInterval, "poll-interval", 10*time.Second, "How often to poll run status")
+	flags.DurationVar(&c.timeout, "timeout", time.Hour, "Maximum time to wait for each run")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.from == "" {
+		c.Ui.Error("Error: -from flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if !c.Meta.ValidateName(c.organization, "-organization") {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+	if !c.Meta.ValidateName(c.from, "-from") {
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+
+	start, err := c.workspaceService(client).Read(ctx, c.organization, c.from)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	graph, workspaceIDs, err := c.buildTriggerGraph(client, start)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error building run trigger graph: %s", err))
+		return 1
+	}
+
+	order, err := graph.topologicalOrder()
+	if err != nil {
+		for _, cycle := range graph.cycles() {
+			c.Ui.Error(fmt.Sprintf("Error: run trigger cycle detected: %s -> %s", strings.Join(cycle, " -> "), cycle[0]))
+		}
+		return 1
+	}
+
+	if c.Meta.DryRun {
+		formatter := c.Meta.NewFormatter("json")
+		formatter.JSON(map[string]interface{}{
+			"action":       "cascade",
+			"resource":     "workspace",
+			"organization": c.organization,
+			"from":         start.Name,
+			"order":        order,
+			"edges":        graph.sortedEdges(),
+		})
+		return 0
+	}
+
+	c.progress(fmt.Sprintf("Cascade order: %s", strings.Join(order, " -> ")))
+
+	results := c.runCascade(client, start, graph, workspaceIDs, order)
+
+	failed := false
+	for _, result := range results {
+		if !cascadeSucceeded(result.Outcome) {
+			failed = true
+		}
+	}
+
+	if c.format == "json" {
+		c.Meta.NewFormatter(c.format).JSON(results)
+	} else {
+		headers := []string{"Workspace", "Run ID", "Status", "Outcome"}
+		var rows [][]string
+		for _, result := range results {
+			outcome := result.Outcome
+			if result.Error != "" {
+				outcome = fmt.Sprintf("%s (%s)", outcome, result.Error)
+			}
+			rows = append(rows, []string{result.Workspace, result.RunID, result.Status, outcome})
+		}
+		c.Ui.Output("")
+		c.Meta.NewFormatter(c.format).Table(headers, rows)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// buildTriggerGraph follows outbound run triggers from the starting
+// workspace and returns the graph plus workspace IDs keyed by name.
+func (c *WorkspaceCascadeCommand) buildTriggerGraph(client *client.Client, start *tfe.Workspace) (*workspaceGraph, map[string]string, error) {
+	ctx := client.Context()
+	graph := newWorkspaceGraph()
+	graph.addNode(&workspaceGraphNode{ID: start.ID, Name: start.Name})
+	workspaceIDs := map[string]string{start.Name: start.ID}
+
+	queue := []string{start.Name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		triggers, err := listAllRunTriggers(ctx, c.runTriggerService(client), workspaceIDs[current], tfe.RunTriggerFilterOp("outbound"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing run triggers for %s: %w", current, err)
+		}
+
+		for _, rt := range triggers {
+			target := rt.WorkspaceName
+			targetID := ""
+			if rt.Workspace != nil {
+				targetID = rt.Workspace.ID
+				if target == "" {
+					target = rt.Workspace.Name
+				}
+			}
+			if target == "" || targetID == "" {
+				continue
+			}
+
+			graph.addEdge(current, target, workspaceEdgeRunTrigger)
+			if _, seen := workspaceIDs[target]; !seen {
+				workspaceIDs[target] = targetID
+				graph.nodes[target].ID = targetID
+				queue = append(queue, target)
+			}
+		}
+	}
+
+	return graph, workspaceIDs, nil
+}
+
+// runCascade queues the starting run and follows each triggered run in
+// order, stopping at the first failure.
+func (c *WorkspaceCascadeCommand) runCascade(client *client.Client, start *tfe.Workspace, graph *workspaceGraph, workspaceIDs map[string]string, order []string) []cascadeResult {
+	ctx := client.Context()
+	results := make([]cascadeResult, 0, len(order))
+	outcomes := make(map[string]string, len(order))
+	runs := make(map[string]*tfe.Run, len(order))
+	stopped := false
+
+	for _, name := range order {
+		result := cascadeResult{Workspace: name}
+		if stopped {
+			result.Outcome = cascadeOutcomeNotStarted
+			results = append(results, result)
+			continue
+		}
+
+		var run *tfe.Run
+		var err error
+		if name == start.Name {
+			c.progress(fmt.Sprintf("Queueing run in %s", name))
+			run, err = c.runService(client).Create(ctx, tfe.RunCreateOptions{
+				Workspace: start,
+				Message:   tfe.String(c.message),
+			})
+		} else {
+			// Run triggers only fire after an apply, so a workspace is only
+			// expected to run when at least one upstream workspace applied,
+			// and its run is queued after the latest of those applies.
+			var after time.Time
+			triggered := false
+			for _, upstream := range graph.predecessors(name) {
+				if outcomes[upstream] == cascadeOutcomeApplied {
+					triggered = true
+					if applied := cascadeAppliedAt(runs[upstream]); applied.After(after) {
+						after = applied
+					}
+				}
+			}
+			if !triggered {
+				result.Outcome = cascadeOutcomeSkipped
+				outcomes[name] = cascadeOutcomeSkipped
+				results = append(results, result)
+				continue
+			}
+
+			c.progress(fmt.Sprintf("Waiting for run trigger to queue a run in %s", name))
+			run, err = c.waitForTriggeredRun(ctx, client, workspaceIDs[name], after)
+		}
+
+		if err == nil {
+			result.RunID = run.ID
+			c.progress(fmt.Sprintf("Following run %s in %s", run.ID, name))
+			run, err = c.waitForRun(ctx, client, name, run)
+		}
+
+		if run != nil {
+			result.RunID = run.ID
+			result.Status = string(run.Status)
+			result.Outcome = cascadeRunOutcome(run.Status)
+			runs[name] = run
+		}
+		if err != nil {
+			result.Error = err.Error()
+			if result.Outcome == "" || cascadeSucceeded(result.Outcome) {
+				result.Outcome = "failed"
+			}
+		}
+
+		outcomes[name] = result.Outcome
+		results = append(results, result)
+		c.progress(fmt.Sprintf("%s: %s", name, result.Outcome))
+
+		if !cascadeSucceeded(result.Outcome) {
+			stopped = true
+		}
+	}
+
+	return results
+}
+
+// cascadeAppliedAt returns when a run finished applying, falling back to
+// its creation time when the API did not report the apply timestamp.
+func cascadeAppliedAt(run *tfe.Run) time.Time {
+	if run.StatusTimestamps != nil && !run.StatusTimestamps.AppliedAt.IsZero() {
+		return run.StatusTimestamps.AppliedAt
+	}
+	return run.CreatedAt
+}
+
+// waitForTriggeredRun polls a workspace until a run queued by a run trigger
+// after the upstream apply appears, returning the earliest such run. Runs
+// queued by people or other integrations in the meantime are ignored.
+func (c *WorkspaceCascadeCommand) waitForTriggeredRun(ctx context.Context, client *client.Client, workspaceID string, after time.Time) (*tfe.Run, error) {
+	deadline := time.Now().Add(c.timeout)
+	for {
+		found, err := c.findTriggeredRun(ctx, client, workspaceID, after)
+		if err != nil {
+			return nil, fmt.Errorf("listing runs: %w", err)
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for triggered run")
+		}
+		time.Sleep(c.pollInterval)
+	}
+}
+
+// findTriggeredRun pages through a workspace's runs, newest first, until it
+// reaches runs created before the given time, and returns the earliest run
+// queued by a run trigger after it.
+func (c *WorkspaceCascadeCommand) findTriggeredRun(ctx context.Context, client *client.Client, workspaceID string, after time.Time) (*tfe.Run, error) {
+	opts := &tfe.RunListOptions{
+		ListOptions: tfe.ListOptions{
+			PageNumber: 1,
+			PageSize:   defaultListPageSize,
+		},
+		Source: string(cascadeRunTriggerSource),
+	}
+
+	var found *tfe.Run
+	for {
+		list, err := c.runService(client).List(ctx, workspaceID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		older := false
+		for _, run := range list.Items {
+			if run.CreatedAt.Before(after) {
+				older = true
+				continue
+			}
+			if run.Source == cascadeRunTriggerSource && (found == nil || run.CreatedAt.Before(found.CreatedAt)) {
+				found = run
+			}
+		}
+
+		if older || !hasNextPage(list.Pagination, opts.PageNumber) {
+			return found, nil
+		}
+		opts.PageNumber = list.Pagination.NextPage
+	}
+}
+
+// waitForRun polls a run until it reaches a final status, approving it along
+// the way when requested.
+func (c *WorkspaceCascadeCommand) waitForRun(ctx context.Context, client *client.Client, workspace string, run *tfe.Run) (*tfe.Run, error) {
+	deadline := time.Now().Add(c.timeout)
+	confirmed := false
+	announced := false
+
+	for {
+		current, err := c.runService(client).Read(ctx, run.ID)
+		if err != nil {
+			return run, fmt.Errorf("reading run: %w", err)
+		}
+		run = current
+
+		if cascadeRunFinal(run.Status) {
+			return run, nil
+		}
+
+		if !confirmed && run.Actions != nil && run.Actions.IsConfirmable {
+			if !c.approve {
+				if !announced {
+					c.progress(fmt.Sprintf("Run %s in %s is waiting for confirmation", run.ID, workspace))
+					announced = true
+				}
+			} else {
+				if err := c.confirmRun(ctx, client, workspace, run); err != nil {
+					return run, err
+				}
+				confirmed = true
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return run, fmt.Errorf("timed out waiting for run")
+		}
+		time.Sleep(c.pollInterval)
+	}
+}
+
+// confirmRun shows the plan summary and applies the run once approved.
+func (c *WorkspaceCascadeCommand) confirmRun(ctx context.Context, client *client.Client, workspace string, run *tfe.Run) error {
+	if run.Plan != nil && run.Plan.ID != "" {
+		plan, err := c.planService(client).Read(ctx, run.Plan.ID)
+		if err != nil {
+			return fmt.Errorf("reading plan: %w", err)
+		}
+		c.Ui.Output(fmt.Sprintf("Plan for %s (%s): %d to add, %d to change, %d to destroy.",
+			workspace, run.ID, plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions))
+	}
+
+	if !c.autoApprove {
+		response, err := c.Ui.Ask(fmt.Sprintf("Apply run %s in workspace '%s'? Only 'yes' will be accepted: ", run.ID, workspace))
+		if err != nil {
+			return fmt.Errorf("reading confirmation: %w", err)
+		}
+		if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+			return errCascadeDeclined
+		}
+	}
+
+	comment := fmt.Sprintf("Approved by hcptf workspace cascade from %s", c.from)
+	if err := c.runService(client).Apply(ctx, run.ID, tfe.RunApplyOptions{Comment: tfe.String(comment)}); err != nil {
+		return fmt.Errorf("applying run: %w", err)
+	}
+	return nil
+}
+
+// progress prints a status line unless machine-readable output was requested.
+func (c *WorkspaceCascadeCommand) progress(message string) {
+	if c.format == "json" {
+		return
+	}
+	c.Ui.Info(message)
+}
+
+// cascadeRunFinal reports whether a run can no longer change status without
+// user intervention.
+func cascadeRunFinal(status tfe.RunStatus) bool {
+	switch status {
+	case tfe.RunApplied, tfe.RunPlannedAndFinished, tfe.RunErrored, tfe.RunCanceled,
+		tfe.RunForceCanceled, tfe.RunDiscarded, tfe.RunPolicySoftFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func cascadeRunOutcome(status tfe.RunStatus) string {
+	switch status {
+	case tfe.RunApplied:
+		return cascadeOutcomeApplied
+	case tfe.RunPlannedAndFinished:
+		return cascadeOutcomeNoChanges
+	default:
+		return string(status)
+	}
+}
+
+func cascadeSucceeded(outcome string) bool {
+	switch outcome {
+	case cascadeOutcomeApplied, cascadeOutcomeNoChanges, cascadeOutcomeSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *WorkspaceCascadeCommand) workspaceService(client *client.Client) workspaceReader {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *WorkspaceCascadeCommand) runTriggerService(client *client.Client) runTriggerLister {
+	if c.runTriggerSvc != nil {
+		return c.runTriggerSvc
+	}
+	return client.RunTriggers
+}
+
+func (c *WorkspaceCascadeCommand) runService(client *client.Client) runOrchestrator {
+	if c.runSvc != nil {
+		return c.runSvc
+	}
+	return client.Runs
+}
+
+func (c *WorkspaceCascadeCommand) planService(client *client.Client) planReader {
+	if c.planSvc != nil {
+		return c.planSvc
+	}
+	return client.Plans
+}
+
+// Help returns help text for the workspace cascade command
+func (c *WorkspaceCascadeCommand) Help() string {
+	helpText := `
+Usage: hcptf workspace cascade [options]
+
+  Queue a run on a workspace and follow the run-trigger chain downstream.
+
+  The starting run is created and followed until it finishes. Each workspace
+  it triggers is then followed in dependency order until its run finishes.
+  Only runs queued by a run trigger after the upstream apply finished are
+  followed, so runs queued by hand in the meantime are never approved.
+  Runs waiting for confirmation are approved with -approve after the plan
+  summary is shown; otherwise the cascade waits for someone to approve them.
+  The chain stops at the first run that errors, is canceled or discarded, or
+  times out. Workspaces downstream of a run with no changes are skipped,
+  because run triggers only fire after an apply.
+
+  A final report lists each workspace's run ID and outcome. The command
+  exits non-zero if any run did not succeed.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -from=<name>         Workspace to start the cascade from (required)
+  -message=<text>      Run message for the starting workspace
+  -approve             Approve runs that wait for confirmation
+  -auto-approve        Skip the confirmation prompt when approving runs
+  -poll-interval=<d>   How often to poll run status (default: 10s)
+  -timeout=<d>         Maximum time to wait for each run (default: 1h)
+  -output=<format>     Output format: table (default) or json
+
+Examples:
+
+  # Apply network and follow everything it triggers
+  hcptf workspace cascade -org=my-org -from=network
+
+  # Review and approve each plan along the chain
+  hcptf workspace cascade -org=my-org -from=network -approve
+
+  # Show the order without queuing any runs
+  hcptf workspace cascade -org=my-org -from=network -dry-run
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the workspace cascade command
+func (c *WorkspaceCascadeCommand) Synopsis() string {
+	return "Apply a workspace and follow its run-trigger chain"
+}
@@ -0,0 +1,388 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+// mockCascadeRunService replays a sequence of statuses for each run. Run
+// lists are newest first, split into pages of pageSize when it is set.
+type mockCascadeRunService struct {
+	created    *tfe.Run
+	createErr  error
+	statuses   map[string][]tfe.RunStatus
+	appliedAt  map[string]time.Time
+	runs       map[string][]*tfe.Run
+	pageSize   int
+	listCalls  int
+	reads      map[string]int
+	applied    []string
+	lastCreate tfe.RunCreateOptions
+}
+
+func (m *mockCascadeRunService) Create(_ context.Context, options tfe.RunCreateOptions) (*tfe.Run, error) {
+	m.lastCreate = options
+	return m.created, m.createErr
+}
+
+func (m *mockCascadeRunService) Read(_ context.Context, runID string) (*tfe.Run, error) {
+	if m.reads == nil {
+		m.reads = map[string]int{}
+	}
+	statuses := m.statuses[runID]
+	if len(statuses) == 0 {
+		return nil, errors.New("run not found")
+	}
+	idx := m.reads[runID]
+	if idx >= len(statuses) {
+		idx = len(statuses) - 1
+	}
+	m.reads[runID]++
+
+	status := statuses[idx]
+	return &tfe.Run{
+		ID:      runID,
+		Status:  status,
+		Actions: &tfe.RunActions{IsConfirmable: status == tfe.RunPlanned},
+		Plan:    &tfe.Plan{ID: "plan-" + runID},
+
+		StatusTimestamps: &tfe.RunStatusTimestamps{AppliedAt: m.appliedAt[runID]},
+	}, nil
+}
+
+func (m *mockCascadeRunService) List(_ context.Context, workspaceID string, options *tfe.RunListOptions) (*tfe.RunList, error) {
+	m.listCalls++
+	items := m.runs[workspaceID]
+	if m.pageSize == 0 {
+		return &tfe.RunList{Items: items}, nil
+	}
+
+	page := options.PageNumber
+	start, end := (page-1)*m.pageSize, page*m.pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	pagination := &tfe.Pagination{CurrentPage: page}
+	if end < len(items) {
+		pagination.NextPage = page + 1
+	}
+	return &tfe.RunList{Items: items[start:end], Pagination: pagination}, nil
+}
+
+func (m *mockCascadeRunService) Apply(_ context.Context, runID string, _ tfe.RunApplyOptions) error {
+	m.applied = append(m.applied, runID)
+	return nil
+}
+
+func cascadeTestTriggers() *mockRunTriggerByWorkspaceService {
+	return &mockRunTriggerByWorkspaceService{
+		outbound: map[string][]*tfe.RunTrigger{
+			"ws-network": {
+				{WorkspaceName: "compute", Workspace: &tfe.Workspace{ID: "ws-compute"}},
+			},
+			"ws-compute": {
+				{WorkspaceName: "app", Workspace: &tfe.Workspace{ID: "ws-app"}},
+			},
+		},
+	}
+}
+
+func newWorkspaceCascadeCommand(ui cli.Ui, runSvc runOrchestrator, planSvc planReader) *WorkspaceCascadeCommand {
+	return &WorkspaceCascadeCommand{
+		Meta:          newTestMeta(ui),
+		workspaceSvc:  &mockWorkspaceReader{workspace: &tfe.Workspace{ID: "ws-network", Name: "network"}},
+		runTriggerSvc: cascadeTestTriggers(),
+		runSvc:        runSvc,
+		planSvc:       planSvc,
+	}
+}
+
+// cascadeTestRuns applies network at +30s. Compute has its triggered run at
+// +1m, plus a hand-queued run and a run-trigger run from before network's
+// apply finished, neither of which may be followed.
+func cascadeTestRuns(appStatus tfe.RunStatus) *mockCascadeRunService {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &mockCascadeRunService{
+		created: &tfe.Run{ID: "run-network", CreatedAt: start},
+		statuses: map[string][]tfe.RunStatus{
+			"run-network": {tfe.RunPlanning, tfe.RunApplied},
+			"run-compute": {tfe.RunApplied},
+			"run-app":     {appStatus},
+		},
+		appliedAt: map[string]time.Time{
+			"run-network": start.Add(30 * time.Second),
+			"run-compute": start.Add(90 * time.Second),
+		},
+		runs: map[string][]*tfe.Run{
+			"ws-compute": {
+				{ID: "run-compute", CreatedAt: start.Add(time.Minute), Source: cascadeRunTriggerSource},
+				{ID: "run-manual", CreatedAt: start.Add(45 * time.Second), Source: tfe.RunSource("tfe-ui")},
+				{ID: "run-early", CreatedAt: start.Add(10 * time.Second), Source: cascadeRunTriggerSource},
+				{ID: "run-old", CreatedAt: start.Add(-time.Hour), Source: cascadeRunTriggerSource},
+			},
+			"ws-app": {
+				{ID: "run-app", CreatedAt: start.Add(2 * time.Minute), Source: cascadeRunTriggerSource},
+			},
+		},
+	}
+}
+
+func TestWorkspaceCascadeRequiresOrganization(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceCascadeCommand(ui, nil, nil)
+
+	if code := cmd.Run([]string{"-from=network"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-organization") {
+		t.Fatalf("expected organization error, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeRequiresFrom(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceCascadeCommand(ui, nil, nil)
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "-from") {
+		t.Fatalf("expected from error, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeFollowsChain(t *testing.T) {
+	ui := cli.NewMockUi()
+	runs := cascadeTestRuns(tfe.RunApplied)
+	cmd := newWorkspaceCascadeCommand(ui, runs, nil)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-poll-interval=1ms", "-output=json"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if runs.lastCreate.Workspace == nil || runs.lastCreate.Workspace.ID != "ws-network" {
+		t.Fatalf("expected run created on starting workspace, got %#v", runs.lastCreate.Workspace)
+	}
+
+	var results []cascadeResult
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &results); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, ui.OutputWriter.String())
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %#v", results)
+	}
+	wantRuns := []string{"run-network", "run-compute", "run-app"}
+	for i, result := range results {
+		if result.RunID != wantRuns[i] || result.Outcome != cascadeOutcomeApplied {
+			t.Fatalf("unexpected result %d: %#v", i, result)
+		}
+	}
+}
+
+func TestWorkspaceCascadeFindsTriggeredRunOnLaterPage(t *testing.T) {
+	ui := cli.NewMockUi()
+	runs := cascadeTestRuns(tfe.RunApplied)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs.runs["ws-compute"] = append([]*tfe.Run{
+		{ID: "run-newer-manual", CreatedAt: start.Add(3 * time.Minute), Source: tfe.RunSource("tfe-api")},
+	}, runs.runs["ws-compute"]...)
+	runs.pageSize = 1
+	cmd := newWorkspaceCascadeCommand(ui, runs, nil)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-poll-interval=1ms", "-output=json"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var results []cascadeResult
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &results); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if results[1].RunID != "run-compute" {
+		t.Fatalf("expected run-compute from the second page, got %#v", results[1])
+	}
+}
+
+func TestWorkspaceCascadePagesRunTriggers(t *testing.T) {
+	ui := cli.NewMockUi()
+	triggers := cascadeTestTriggers()
+	triggers.outbound["ws-network"] = append(triggers.outbound["ws-network"],
+		&tfe.RunTrigger{WorkspaceName: "dns", Workspace: &tfe.Workspace{ID: "ws-dns"}})
+	triggers.pageSize = 1
+	cmd := newWorkspaceCascadeCommand(ui, cascadeTestRuns(tfe.RunApplied), nil)
+	cmd.runTriggerSvc = triggers
+
+	if code := cmd.Run([]string{"-org=my-org", "-from=network", "-dry-run"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"dns"`) {
+		t.Fatalf("expected workspace from the second page of run triggers, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeStopsOnFailure(t *testing.T) {
+	ui := cli.NewMockUi()
+	runs := cascadeTestRuns(tfe.RunApplied)
+	runs.statuses["run-compute"] = []tfe.RunStatus{tfe.RunErrored}
+	cmd := newWorkspaceCascadeCommand(ui, runs, nil)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-poll-interval=1ms"})
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "errored") {
+		t.Fatalf("expected errored outcome, got %q", out)
+	}
+	if !strings.Contains(out, cascadeOutcomeNotStarted) {
+		t.Fatalf("expected app to be reported as not started, got %q", out)
+	}
+	if runs.reads["run-app"] != 0 {
+		t.Fatalf("expected app run not to be followed")
+	}
+}
+
+func TestWorkspaceCascadeSkipsAfterNoChanges(t *testing.T) {
+	ui := cli.NewMockUi()
+	runs := cascadeTestRuns(tfe.RunApplied)
+	runs.statuses["run-compute"] = []tfe.RunStatus{tfe.RunPlannedAndFinished}
+	cmd := newWorkspaceCascadeCommand(ui, runs, nil)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-poll-interval=1ms", "-output=json"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var results []cascadeResult
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &results); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if results[1].Outcome != cascadeOutcomeNoChanges || results[2].Outcome != cascadeOutcomeSkipped {
+		t.Fatalf("unexpected outcomes: %#v", results)
+	}
+}
+
+func TestWorkspaceCascadeApprovesWithPlanSummary(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("yes\n")
+	runs := cascadeTestRuns(tfe.RunApplied)
+	runs.statuses["run-network"] = []tfe.RunStatus{tfe.RunPlanned, tfe.RunApplying, tfe.RunApplied}
+	plans := &mockPlanService{response: &tfe.Plan{ResourceAdditions: 2, ResourceChanges: 1}}
+	cmd := newWorkspaceCascadeCommand(ui, runs, plans)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-approve", "-poll-interval=1ms"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(runs.applied) != 1 || runs.applied[0] != "run-network" {
+		t.Fatalf("expected run-network to be applied, got %v", runs.applied)
+	}
+	if plans.lastID != "plan-run-network" {
+		t.Fatalf("expected plan to be read, got %q", plans.lastID)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "2 to add, 1 to change, 0 to destroy") {
+		t.Fatalf("expected plan summary, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeDeclineStops(t *testing.T) {
+	ui := cli.NewMockUi()
+	ui.InputReader = strings.NewReader("no\n")
+	runs := cascadeTestRuns(tfe.RunApplied)
+	runs.statuses["run-network"] = []tfe.RunStatus{tfe.RunPlanned}
+	cmd := newWorkspaceCascadeCommand(ui, runs, &mockPlanService{response: &tfe.Plan{}})
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-approve", "-poll-interval=1ms"})
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if len(runs.applied) != 0 {
+		t.Fatalf("expected no apply, got %v", runs.applied)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), errCascadeDeclined.Error()) {
+		t.Fatalf("expected declined error in report, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	runs := cascadeTestRuns(tfe.RunApplied)
+	cmd := newWorkspaceCascadeCommand(ui, runs, nil)
+
+	code := cmd.Run([]string{"-org=my-org", "-from=network", "-dry-run"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if runs.lastCreate.Workspace != nil {
+		t.Fatalf("expected no run to be created in dry-run")
+	}
+	if !strings.Contains(ui.OutputWriter.String(), `"compute"`) {
+		t.Fatalf("expected cascade order in output, got %q", ui.OutputWriter.String())
+	}
+}
+
+func TestWorkspaceCascadeDetectsCycle(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceCascadeCommand(ui, cascadeTestRuns(tfe.RunApplied), nil)
+	triggers := cascadeTestTriggers()
+	triggers.outbound["ws-app"] = []*tfe.RunTrigger{
+		{WorkspaceName: "network", Workspace: &tfe.Workspace{ID: "ws-network"}},
+	}
+	cmd.runTriggerSvc = triggers
+
+	if code := cmd.Run([]string{"-org=my-org", "-from=network"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cycle") {
+		t.Fatalf("expected cycle error, got %q", ui.ErrorWriter.String())
+	}
+}
+
+func TestWorkspaceGraphTopologicalOrder(t *testing.T) {
+	graph := newWorkspaceGraph()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		graph.addNode(&workspaceGraphNode{Name: name})
+	}
+	graph.addEdge("a", "c", workspaceEdgeRunTrigger)
+	graph.addEdge("b", "c", workspaceEdgeRunTrigger)
+	graph.addEdge("c", "d", workspaceEdgeRunTrigger)
+
+	order, err := graph.topologicalOrder()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "a,b,c,d" {
+		t.Fatalf("unexpected order %q", got)
+	}
+
+	graph.addEdge("d", "a", workspaceEdgeRunTrigger)
+	if _, err := graph.topologicalOrder(); err == nil {
+		t.Fatalf("expected cycle error")
+	}
+}
+
+func TestWorkspaceCascadeHelp(t *testing.T) {
+	cmd := &WorkspaceCascadeCommand{}
+	help := cmd.Help()
+	for _, want := range []string{"workspace cascade", "-from", "-approve", "-auto-approve"} {
+		if !strings.Contains(help, want) {
+			t.Fatalf("expected help to contain %q", want)
+		}
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
@@ -0,0 +1,529 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	workspaceEdgeRunTrigger  = "run-trigger"
+	workspaceEdgeRemoteState = "remote-state"
+)
+
+// WorkspaceGraphCommand is a command to show the workspace dependency graph
+type WorkspaceGraphCommand struct {
+	Meta
+	organization    string
+	projectID       string
+	from            string
+	skipRemoteState bool
+	format          string
+	workspaceSvc    workspaceLister
+	runTriggerSvc   runTriggerLister
+	remoteStateSvc  workspaceRemoteStateConsumerLister
+}
+
+// workspaceGraphNode is a workspace in the dependency graph.
+type workspaceGraphNode struct {
+	ID                string `json:"id,omitempty"`
+	Name              string `json:"name"`
+	GlobalRemoteState bool   `json:"global_remote_state"`
+}
+
+// workspaceGraphEdge points from an upstream workspace to a workspace that
+// is affected by it, either because it is triggered or reads its state.
+type workspaceGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// workspaceGraph is a directed graph of workspaces keyed by workspace name.
+type workspaceGraph struct {
+	nodes map[string]*workspaceGraphNode
+	edges []workspaceGraphEdge
+	seen  map[workspaceGraphEdge]bool
+}
+
+func newWorkspaceGraph() *workspaceGraph {
+	return &workspaceGraph{
+		nodes: make(map[string]*workspaceGraphNode),
+		seen:  make(map[workspaceGraphEdge]bool),
+	}
+}
+
+func (g *workspaceGraph) addNode(node *workspaceGraphNode) {
+	if existing, ok := g.nodes[node.Name]; ok {
+		if existing.ID == "" {
+			existing.ID = node.ID
+		}
+		existing.GlobalRemoteState = existing.GlobalRemoteState || node.GlobalRemoteState
+		return
+	}
+	g.nodes[node.Name] = node
+}
+
+func (g *workspaceGraph) addEdge(from, to, edgeType string) {
+	if from == "" || to == "" {
+		return
+	}
+	edge := workspaceGraphEdge{From: from, To: to, Type: edgeType}
+	if g.seen[edge] {
+		return
+	}
+	g.seen[edge] = true
+	g.addNode(&workspaceGraphNode{Name: from})
+	g.addNode(&workspaceGraphNode{Name: to})
+	g.edges = append(g.edges, edge)
+}
+
+// nodeNames returns all node names in sorted order.
+func (g *workspaceGraph) nodeNames() []string {
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedEdges returns edges ordered by source, target and type.
+func (g *workspaceGraph) sortedEdges() []workspaceGraphEdge {
+	edges := append([]workspaceGraphEdge(nil), g.edges...)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Type < edges[j].Type
+	})
+	return edges
+}
+
+// successors returns the sorted downstream neighbours of a node, optionally
+// limited to the given edge types.
+func (g *workspaceGraph) successors(name string, edgeTypes ...string) []string {
+	seen := make(map[string]bool)
+	var next []string
+	for _, edge := range g.edges {
+		if edge.From != name || seen[edge.To] {
+			continue
+		}
+		if len(edgeTypes) > 0 && !stringInSlice(edgeTypes, edge.Type) {
+			continue
+		}
+		seen[edge.To] = true
+		next = append(next, edge.To)
+	}
+	sort.Strings(next)
+	return next
+}
+
+// downstream returns the subgraph reachable from the named workspace.
+func (g *workspaceGraph) downstream(name string) *workspaceGraph {
+	reachable := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g.successors(current) {
+			if !reachable[next] {
+				reachable[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	sub := newWorkspaceGraph()
+	for n := range reachable {
+		node := *g.nodes[n]
+		sub.addNode(&node)
+	}
+	for _, edge := range g.edges {
+		if reachable[edge.From] && reachable[edge.To] {
+			sub.addEdge(edge.From, edge.To, edge.Type)
+		}
+	}
+	return sub
+}
+
+// predecessors returns the sorted upstream neighbours of a node.
+func (g *workspaceGraph) predecessors(name string) []string {
+	seen := make(map[string]bool)
+	var prev []string
+	for _, edge := range g.edges {
+		if edge.To != name || seen[edge.From] {
+			continue
+		}
+		seen[edge.From] = true
+		prev = append(prev, edge.From)
+	}
+	sort.Strings(prev)
+	return prev
+}
+
+// topologicalOrder returns the nodes ordered so every workspace comes after
+// all of its upstream workspaces. Ties are broken by name.
+func (g *workspaceGraph) topologicalOrder() ([]string, error) {
+	inDegree := make(map[string]int, len(g.nodes))
+	for name := range g.nodes {
+		inDegree[name] = len(g.predecessors(name))
+	}
+
+	var ready []string
+	for _, name := range g.nodeNames() {
+		if inDegree[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(g.nodes))
+	for len(ready) > 0 {
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, current)
+
+		for _, next := range g.successors(current) {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+				sort.Strings(ready)
+			}
+		}
+	}
+
+	if len(order) != len(g.nodes) {
+		return nil, fmt.Errorf("workspace graph contains a cycle")
+	}
+	return order, nil
+}
+
+// cycles returns every strongly connected component that forms a cycle,
+// including workspaces that depend on themselves.
+func (g *workspaceGraph) cycles() [][]string {
+	index := 0
+	indexes := make(map[string]int)
+	lowlinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var result [][]string
+
+	var connect func(string)
+	connect = func(name string) {
+		indexes[name] = index
+		lowlinks[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, next := range g.successors(name) {
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				if lowlinks[next] < lowlinks[name] {
+					lowlinks[name] = lowlinks[next]
+				}
+			} else if onStack[next] && indexes[next] < lowlinks[name] {
+				lowlinks[name] = indexes[next]
+			}
+		}
+
+		if lowlinks[name] != indexes[name] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == name {
+				break
+			}
+		}
+
+		if len(component) > 1 || stringInSlice(g.successors(name), name) {
+			sort.Strings(component)
+			result = append(result, component)
+		}
+	}
+
+	for _, name := range g.nodeNames() {
+		if _, visited := indexes[name]; !visited {
+			connect(name)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i][0] < result[j][0]
+	})
+	return result
+}
+
+// Run executes the workspace graph command
+func (c *WorkspaceGraphCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("workspace graph")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "project-id", "", "Limit the graph to workspaces in a project")
+	flags.StringVar(&c.from, "from", "", "Only show workspaces downstream of this workspace")
+	flags.BoolVar(&c.skipRemoteState, "skip-remote-state", false, "Only follow run triggers")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, dot, mermaid, or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	switch c.format {
+	case "table", "dot", "mermaid", "json":
+	default:
+		c.Ui.Error("Error: -output must be one of: table, dot, mermaid, json")
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	graph, err := c.buildGraph(client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error building workspace graph: %s", err))
+		return 1
+	}
+
+	if c.from != "" {
+		if _, ok := graph.nodes[c.from]; !ok {
+			c.Ui.Error(fmt.Sprintf("Error: workspace %q not found in graph", c.from))
+			return 1
+		}
+		graph = graph.downstream(c.from)
+	}
+
+	cycles := graph.cycles()
+	if c.format != "json" {
+		for _, cycle := range cycles {
+			path := strings.Join(cycle, " -> ") + " -> " + cycle[0]
+			c.Ui.Warn(fmt.Sprintf("Warning: dependency cycle detected: %s", path))
+		}
+	}
+
+	switch c.format {
+	case "dot":
+		c.Ui.Output(renderWorkspaceGraphDOT(graph))
+	case "mermaid":
+		c.Ui.Output(renderWorkspaceGraphMermaid(graph))
+	case "json":
+		nodes := make([]*workspaceGraphNode, 0, len(graph.nodes))
+		for _, name := range graph.nodeNames() {
+			nodes = append(nodes, graph.nodes[name])
+		}
+		if cycles == nil {
+			cycles = [][]string{}
+		}
+		formatter := c.Meta.NewFormatter("json")
+		formatter.JSON(map[string]interface{}{
+			"nodes":  nodes,
+			"edges":  graph.sortedEdges(),
+			"cycles": cycles,
+		})
+	default:
+		if len(graph.edges) == 0 {
+			c.Ui.Output("No workspace dependencies found")
+			return 0
+		}
+
+		headers := []string{"From", "To", "Type"}
+		var rows [][]string
+		for _, edge := range graph.sortedEdges() {
+			rows = append(rows, []string{edge.From, edge.To, edge.Type})
+		}
+		c.Meta.NewFormatter(c.format).Table(headers, rows)
+	}
+
+	return 0
+}
+
+// buildGraph collects run trigger and remote state sharing edges for every
+// workspace in scope.
+func (c *WorkspaceGraphCommand) buildGraph(client *client.Client) (*workspaceGraph, error) {
+	ctx := client.Context()
+	workspaces, err := listAllWorkspaces(ctx, c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing workspaces: %w", err)
+	}
+
+	graph := newWorkspaceGraph()
+	for _, ws := range workspaces {
+		graph.addNode(&workspaceGraphNode{
+			ID:                ws.ID,
+			Name:              ws.Name,
+			GlobalRemoteState: ws.GlobalRemoteState,
+		})
+	}
+
+	for _, ws := range workspaces {
+		triggers, err := listAllRunTriggers(ctx, c.runTriggerService(client), ws.ID, tfe.RunTriggerFilterOp("inbound"))
+		if err != nil {
+			return nil, fmt.Errorf("listing run triggers for %s: %w", ws.Name, err)
+		}
+		for _, rt := range triggers {
+			source := rt.SourceableName
+			if source == "" && rt.Sourceable != nil {
+				source = rt.Sourceable.Name
+			}
+			graph.addEdge(source, ws.Name, workspaceEdgeRunTrigger)
+		}
+
+		// Workspaces sharing state globally have no explicit consumer list.
+		if c.skipRemoteState || ws.GlobalRemoteState {
+			continue
+		}
+
+		consumers, err := listAllRemoteStateConsumers(ctx, c.remoteStateService(client), ws.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing remote state consumers for %s: %w", ws.Name, err)
+		}
+		for _, consumer := range consumers {
+			graph.addEdge(ws.Name, consumer.Name, workspaceEdgeRemoteState)
+		}
+	}
+
+	return graph, nil
+}
+
+func renderWorkspaceGraphDOT(graph *workspaceGraph) string {
+	var b strings.Builder
+	b.WriteString("digraph workspaces {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, name := range graph.nodeNames() {
+		if graph.nodes[name].GlobalRemoteState {
+			fmt.Fprintf(&b, "  %q [style=bold, tooltip=\"global remote state\"];\n", name)
+		} else {
+			fmt.Fprintf(&b, "  %q;\n", name)
+		}
+	}
+	for _, edge := range graph.sortedEdges() {
+		style := ""
+		if edge.Type == workspaceEdgeRemoteState {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q%s];\n", edge.From, edge.To, edge.Type, style)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func renderWorkspaceGraphMermaid(graph *workspaceGraph) string {
+	ids := make(map[string]string, len(graph.nodes))
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for i, name := range graph.nodeNames() {
+		ids[name] = fmt.Sprintf("ws%d", i)
+		label := strings.ReplaceAll(name, `"`, "#quot;")
+		if graph.nodes[name].GlobalRemoteState {
+			fmt.Fprintf(&b, "  %s[[\"%s\"]]\n", ids[name], label)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[name], label)
+		}
+	}
+	for _, edge := range graph.sortedEdges() {
+		arrow := "-->"
+		if edge.Type == workspaceEdgeRemoteState {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", ids[edge.From], arrow, edge.Type, ids[edge.To])
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func stringInSlice(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *WorkspaceGraphCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+func (c *WorkspaceGraphCommand) runTriggerService(client *client.Client) runTriggerLister {
+	if c.runTriggerSvc != nil {
+		return c.runTriggerSvc
+	}
+	return client.RunTriggers
+}
+
+func (c *WorkspaceGraphCommand) remoteStateService(client *client.Client) workspaceRemoteStateConsumerLister {
+	if c.remoteStateSvc != nil {
+		return c.remoteStateSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the workspace graph command
+func (c *WorkspaceGraphCommand) Help() string {
+	helpText := `
+Usage: hcptf workspace graph [options]
+
+  Show the dependency graph between workspaces. Edges point from an upstream
+  workspace to the workspaces it affects:
+
+  - run-trigger:  a completed apply in the source queues a run in the target
+  - remote-state: the target is an explicit remote state consumer of the source
+
+  Workspaces that share state with the whole organization (global remote
+  state) are highlighted instead of being connected to every workspace.
+  Dependency cycles are reported as warnings.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -project-id=<id>     Limit the graph to workspaces in a project
+  -from=<name>         Only show workspaces downstream of this workspace
+  -skip-remote-state   Only follow run triggers
+  -output=<format>     Output format: table (default), dot, mermaid, or json
+
+Examples:
+
+  # What will changing the network workspace trigger, and who reads its state?
+  hcptf workspace graph -org=my-org -from=network
+
+  # Render the whole organization with Graphviz
+  hcptf workspace graph -org=my-org -output=dot | dot -Tsvg > graph.svg
+
+  # Embed a project graph in Markdown
+  hcptf workspace graph -org=my-org -project-id=prj-abc123 -output=mermaid
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the workspace graph command
+func (c *WorkspaceGraphCommand) Synopsis() string {
+	return "Show the dependency graph between workspaces"
+}
@@ -0,0 +1,247 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func newWorkspaceGraphCommand(ui cli.Ui, ws workspaceLister, rt runTriggerLister, rs workspaceRemoteStateConsumerLister) *WorkspaceGraphCommand {
+	return &WorkspaceGraphCommand{
+		Meta:           newTestMeta(ui),
+		workspaceSvc:   ws,
+		runTriggerSvc:  rt,
+		remoteStateSvc: rs,
+	}
+}
+
+func graphTestServices() (*mockWorkspacePagedListService, *mockRunTriggerByWorkspaceService, *mockRemoteStateConsumerService) {
+	wsSvc := &mockWorkspacePagedListService{
+		pages: [][]*tfe.Workspace{{
+			{ID: "ws-net", Name: "network"},
+			{ID: "ws-app", Name: "app"},
+			{ID: "ws-db", Name: "database"},
+			{ID: "ws-shared", Name: "shared", GlobalRemoteState: true},
+		}},
+	}
+	rtSvc := &mockRunTriggerByWorkspaceService{
+		inbound: map[string][]*tfe.RunTrigger{
+			"ws-app": {{ID: "rt-1", SourceableName: "network", WorkspaceName: "app"}},
+			"ws-db":  {{ID: "rt-2", SourceableName: "network", WorkspaceName: "database"}},
+		},
+	}
+	rsSvc := &mockRemoteStateConsumerService{
+		consumers: map[string][]*tfe.Workspace{
+			"ws-db": {{ID: "ws-app", Name: "app"}},
+		},
+	}
+	return wsSvc, rtSvc, rsSvc
+}
+
+func TestWorkspaceGraphRequiresOrganization(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceGraphCommand(ui, nil, nil, nil)
+
+	if code := cmd.Run(nil); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-organization") {
+		t.Fatalf("expected organization error, got %q", out)
+	}
+}
+
+func TestWorkspaceGraphValidatesOutput(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceGraphCommand(ui, nil, nil, nil)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=svg"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "mermaid") {
+		t.Fatalf("expected output validation error, got %q", out)
+	}
+}
+
+func TestWorkspaceGraphHandlesAPIError(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceGraphCommand(ui, &mockWorkspacePagedListService{err: errors.New("boom")}, nil, nil)
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "boom") {
+		t.Fatalf("expected API error, got %q", out)
+	}
+}
+
+func TestWorkspaceGraphDOTOutput(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc, rtSvc, rsSvc := graphTestServices()
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, rsSvc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=dot"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	for _, want := range []string{
+		"digraph workspaces {",
+		`"network" -> "app" [label="run-trigger"];`,
+		`"database" -> "app" [label="remote-state", style=dashed];`,
+		`"shared" [style=bold`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+
+	for _, id := range rsSvc.calls {
+		if id == "ws-shared" {
+			t.Fatal("expected global remote state workspace to be skipped")
+		}
+	}
+}
+
+func TestWorkspaceGraphPagesRunTriggers(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc, rtSvc, rsSvc := graphTestServices()
+	rtSvc.inbound["ws-app"] = append(rtSvc.inbound["ws-app"], &tfe.RunTrigger{ID: "rt-3", SourceableName: "database", WorkspaceName: "app"})
+	rtSvc.pageSize = 1
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, rsSvc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=dot"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, `"database" -> "app" [label="run-trigger"];`) {
+		t.Fatalf("expected run trigger from the second page, got:\n%s", out)
+	}
+}
+
+func TestWorkspaceGraphMermaidOutput(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc, rtSvc, rsSvc := graphTestServices()
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, rsSvc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=mermaid"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.HasPrefix(out, "graph LR") {
+		t.Fatalf("expected mermaid header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "-->|run-trigger|") || !strings.Contains(out, "-.->|remote-state|") {
+		t.Fatalf("expected both edge types, got:\n%s", out)
+	}
+}
+
+func TestWorkspaceGraphFromFiltersDownstream(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc, rtSvc, rsSvc := graphTestServices()
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, rsSvc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-from=database", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+
+	var result struct {
+		Nodes []workspaceGraphNode `json:"nodes"`
+		Edges []workspaceGraphEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+
+	var names []string
+	for _, node := range result.Nodes {
+		names = append(names, node.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"app", "database"}) {
+		t.Fatalf("expected only downstream workspaces, got %v", names)
+	}
+	if len(result.Edges) != 1 || result.Edges[0].Type != workspaceEdgeRemoteState {
+		t.Fatalf("unexpected edges: %#v", result.Edges)
+	}
+}
+
+func TestWorkspaceGraphFromUnknownWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc, rtSvc, rsSvc := graphTestServices()
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, rsSvc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-from=missing"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "missing") {
+		t.Fatalf("expected not found error, got %q", out)
+	}
+}
+
+func TestWorkspaceGraphReportsCycles(t *testing.T) {
+	ui := cli.NewMockUi()
+	wsSvc := &mockWorkspacePagedListService{
+		pages: [][]*tfe.Workspace{{
+			{ID: "ws-a", Name: "a"},
+			{ID: "ws-b", Name: "b"},
+		}},
+	}
+	rtSvc := &mockRunTriggerByWorkspaceService{
+		inbound: map[string][]*tfe.RunTrigger{
+			"ws-a": {{SourceableName: "b"}},
+			"ws-b": {{SourceableName: "a"}},
+		},
+	}
+	cmd := newWorkspaceGraphCommand(ui, wsSvc, rtSvc, &mockRemoteStateConsumerService{})
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "a -> b -> a") {
+		t.Fatalf("expected cycle warning, got %q", out)
+	}
+}
+
+func TestWorkspaceGraphCycles(t *testing.T) {
+	graph := newWorkspaceGraph()
+	graph.addEdge("a", "b", workspaceEdgeRunTrigger)
+	graph.addEdge("b", "c", workspaceEdgeRunTrigger)
+	graph.addEdge("c", "a", workspaceEdgeRemoteState)
+	graph.addEdge("c", "d", workspaceEdgeRunTrigger)
+	graph.addEdge("e", "e", workspaceEdgeRunTrigger)
+
+	cycles := graph.cycles()
+	expected := [][]string{{"a", "b", "c"}, {"e"}}
+	if !reflect.DeepEqual(cycles, expected) {
+		t.Fatalf("expected cycles %v, got %v", expected, cycles)
+	}
+}
+
+func TestWorkspaceGraphAddEdgeDeduplicates(t *testing.T) {
+	graph := newWorkspaceGraph()
+	graph.addEdge("a", "b", workspaceEdgeRunTrigger)
+	graph.addEdge("a", "b", workspaceEdgeRunTrigger)
+	graph.addEdge("a", "b", workspaceEdgeRemoteState)
+
+	if len(graph.edges) != 2 {
+		t.Fatalf("expected 2 edges, got %d", len(graph.edges))
+	}
+}
+
+func TestWorkspaceGraphHelp(t *testing.T) {
+	cmd := &WorkspaceGraphCommand{}
+
+	help := cmd.Help()
+	for _, want := range []string{"hcptf workspace graph", "-organization", "-from", "dot", "mermaid"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help should contain %q", want)
+		}
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("Synopsis should not be empty")
+	}
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+)
+
+// resolveWorkspaceRef reads a workspace given either its name or its ID.
+// Values that look like IDs are read by ID first and fall back to a name
+// lookup, since a workspace may legitimately be named "ws-something".
+func resolveWorkspaceRef(ctx context.Context, svc workspaceRefReader, organization, ref string) (*tfe.Workspace, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("empty workspace reference")
+	}
+
+	if strings.HasPrefix(ref, "ws-") {
+		ws, err := svc.ReadByID(ctx, ref)
+		if err == nil {
+			return ws, nil
+		}
+		if !errors.Is(err, tfe.ErrResourceNotFound) || organization == "" {
+			return nil, fmt.Errorf("workspace %q: %w", ref, err)
+		}
+	}
+
+	if organization == "" {
+		return nil, fmt.Errorf("workspace %q: -organization is required to look up workspaces by name", ref)
+	}
+
+	ws, err := svc.Read(ctx, organization, ref)
+	if err != nil {
+		return nil, fmt.Errorf("workspace %q: %w", ref, err)
+	}
+	return ws, nil
+}
+
+// resolveWorkspaceRefs resolves a list of workspace names or IDs, dropping
+// duplicates that refer to the same workspace.
+func resolveWorkspaceRefs(ctx context.Context, svc workspaceRefReader, organization string, refs []string) ([]*tfe.Workspace, error) {
+	var workspaces []*tfe.Workspace
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		ws, err := resolveWorkspaceRef(ctx, svc, organization, ref)
+		if err != nil {
+			return nil, err
+		}
+		if seen[ws.ID] {
+			continue
+		}
+		seen[ws.ID] = true
+		workspaces = append(workspaces, ws)
+	}
+	return workspaces, nil
+}
+
+// workspaceNames returns the names of the given workspaces in order.
+func workspaceNames(workspaces []*tfe.Workspace) []string {
+	names := make([]string, 0, len(workspaces))
+	for _, ws := range workspaces {
+		names = append(names, ws.Name)
+	}
+	return names
+}
@@ -0,0 +1,287 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	workspaceCheckStale        = "stale"
+	workspaceCheckErrored      = "errored"
+	workspaceCheckLocked       = "locked"
+	workspaceCheckNoVCS        = "no-vcs"
+	workspaceCheckNoOwner      = "no-owner"
+	workspaceCheckOldTerraform = "old-terraform"
+	workspaceCheckEmptyState   = "empty-state"
+	workspaceCheckAutoDestroy  = "auto-destroy"
+)
+
+// WorkspaceReportCommand is a command to report workspace hygiene issues
+type WorkspaceReportCommand struct {
+	Meta
+	organization        string
+	projectID           string
+	staleDays           int
+	lockHours           int
+	minTerraformVersion string
+	ownerTag            string
+	autoDestroyDays     int
+	format              string
+	now                 func() time.Time
+	workspaceSvc        workspaceLister
+}
+
+// workspaceFinding is a single hygiene issue found on a workspace.
+type workspaceFinding struct {
+	Workspace   string `json:"workspace"`
+	WorkspaceID string `json:"workspace_id"`
+	Check       string `json:"check"`
+	Detail      string `json:"detail"`
+}
+
+// Run executes the workspace report command
+func (c *WorkspaceReportCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("workspace report")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name (required)")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.projectID, "project-id", "", "Only report on workspaces in this project")
+	flags.IntVar(&c.staleDays, "stale-days", 30, "Flag workspaces with no runs in this many days")
+	flags.IntVar(&c.lockHours, "lock-hours", 24, "Flag workspaces locked for more than this many hours")
+	flags.StringVar(&c.minTerraformVersion, "min-terraform-version", "", "Flag workspaces pinned below this Terraform version")
+	flags.StringVar(&c.ownerTag, "owner-tag", "owner", "Tag key that identifies a workspace owner")
+	flags.IntVar(&c.autoDestroyDays, "auto-destroy-days", 7, "Flag workspaces set to auto-destroy within this many days")
+	flags.StringVar(&c.format, "output", "table", "Output format: table, json, or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.organization == "" {
+		c.Ui.Error("Error: -organization flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	if c.format != "table" && c.format != "json" && c.format != "csv" {
+		c.Ui.Error("Error: -output must be one of: table, json, csv")
+		return 1
+	}
+
+	if c.staleDays < 0 || c.lockHours < 0 || c.autoDestroyDays < 0 {
+		c.Ui.Error("Error: -stale-days, -lock-hours, and -auto-destroy-days must not be negative")
+		return 1
+	}
+
+	if c.minTerraformVersion != "" {
+		if _, ok := parseTerraformVersion(c.minTerraformVersion); !ok {
+			c.Ui.Error(fmt.Sprintf("Error: invalid -min-terraform-version %q", c.minTerraformVersion))
+			return 1
+		}
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	workspaces, err := listAllWorkspaces(client.Context(), c.workspaceService(client), c.organization, &tfe.WorkspaceListOptions{
+		ProjectID: c.projectID,
+		Include:   []tfe.WSIncludeOpt{tfe.WSCurrentRun, tfe.WSEffectiveTagBindings},
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing workspaces: %s", err))
+		return 1
+	}
+
+	findings := c.inspect(workspaces)
+
+	formatter := c.Meta.NewFormatter(c.format)
+	if c.format == "json" {
+		if findings == nil {
+			findings = []workspaceFinding{}
+		}
+		formatter.JSON(findings)
+		return 0
+	}
+
+	// CSV always gets a header so downstream tools can parse an empty
+	// report.
+	if len(findings) == 0 && c.format == "table" {
+		c.Ui.Output(fmt.Sprintf("No hygiene issues found in %d workspaces", len(workspaces)))
+		return 0
+	}
+
+	headers := []string{"Workspace", "ID", "Check", "Detail"}
+	rows := make([][]string, 0, len(findings))
+	for _, finding := range findings {
+		rows = append(rows, []string{finding.Workspace, finding.WorkspaceID, finding.Check, finding.Detail})
+	}
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// inspect runs every hygiene check against the workspaces and returns the
+// findings sorted by workspace name.
+func (c *WorkspaceReportCommand) inspect(workspaces []*tfe.Workspace) []workspaceFinding {
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+
+	sorted := make([]*tfe.Workspace, len(workspaces))
+	copy(sorted, workspaces)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var findings []workspaceFinding
+	for _, ws := range sorted {
+		add := func(check, detail string) {
+			findings = append(findings, workspaceFinding{
+				Workspace:   ws.Name,
+				WorkspaceID: ws.ID,
+				Check:       check,
+				Detail:      detail,
+			})
+		}
+
+		run := ws.CurrentRun
+		if run == nil || run.CreatedAt.IsZero() {
+			add(workspaceCheckStale, "no runs")
+		} else if age := now.Sub(run.CreatedAt); age > time.Duration(c.staleDays)*24*time.Hour {
+			add(workspaceCheckStale, fmt.Sprintf("last run %d days ago", int(age.Hours()/24)))
+		}
+
+		if run != nil && run.Status == tfe.RunErrored {
+			add(workspaceCheckErrored, fmt.Sprintf("latest run %s errored", run.ID))
+		}
+
+		// The API does not expose when a lock was taken, so the lock age is
+		// measured from the workspace's last update.
+		if ws.Locked {
+			if age := now.Sub(ws.UpdatedAt); ws.UpdatedAt.IsZero() || age > time.Duration(c.lockHours)*time.Hour {
+				detail := "locked"
+				if !ws.UpdatedAt.IsZero() {
+					detail = fmt.Sprintf("locked for at least %d hours", int(age.Hours()))
+				}
+				add(workspaceCheckLocked, detail)
+			}
+		}
+
+		if ws.VCSRepo == nil {
+			add(workspaceCheckNoVCS, "no VCS connection")
+		}
+
+		if c.ownerTag != "" && !hasOwnerTag(ws, c.ownerTag) {
+			add(workspaceCheckNoOwner, fmt.Sprintf("no %q tag", c.ownerTag))
+		}
+
+		if c.minTerraformVersion != "" {
+			if cmp, ok := compareTerraformVersions(ws.TerraformVersion, c.minTerraformVersion); ok && cmp < 0 {
+				add(workspaceCheckOldTerraform, fmt.Sprintf("Terraform %s is older than %s", ws.TerraformVersion, c.minTerraformVersion))
+			}
+		}
+
+		if ws.ResourceCount == 0 {
+			add(workspaceCheckEmptyState, "no resources in state")
+		}
+
+		if ws.AutoDestroyAt.IsSpecified() && !ws.AutoDestroyAt.IsNull() {
+			if at, err := ws.AutoDestroyAt.Get(); err == nil && !at.IsZero() {
+				if until := at.Sub(now); until <= time.Duration(c.autoDestroyDays)*24*time.Hour {
+					add(workspaceCheckAutoDestroy, fmt.Sprintf("auto-destroy at %s", at.UTC().Format(time.RFC3339)))
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// hasOwnerTag reports whether a workspace has the tag key, such as the
+// owner tag or a lint rule's required tag, either as a legacy tag name or
+// as a key/value tag binding set on the workspace or inherited from its
+// project.
+func hasOwnerTag(ws *tfe.Workspace, key string) bool {
+	if hasTagKey(ws.TagNames, key) {
+		return true
+	}
+	for _, binding := range ws.EffectiveTagBindings {
+		if binding.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTagKey reports whether tags contains key on its own or as a key:value
+// or key=value pair.
+func hasTagKey(tags []string, key string) bool {
+	for _, tag := range tags {
+		if tag == key || strings.HasPrefix(tag, key+":") || strings.HasPrefix(tag, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *WorkspaceReportCommand) workspaceService(client *client.Client) workspaceLister {
+	if c.workspaceSvc != nil {
+		return c.workspaceSvc
+	}
+	return client.Workspaces
+}
+
+// Help returns help text for the workspace report command
+func (c *WorkspaceReportCommand) Help() string {
+	helpText := `
+Usage: hcptf workspace report [options]
+
+  Report workspace hygiene issues across an organization.
+
+  Each workspace is checked for:
+
+    stale          No runs in -stale-days days
+    errored        The latest run errored
+    locked         Locked for more than -lock-hours hours
+    no-vcs         No VCS connection
+    no-owner       No -owner-tag tag, as "owner", "owner:<value>", or a
+                   key/value tag on the workspace or its project
+    old-terraform  Pinned below -min-terraform-version
+    empty-state    No resources in state
+    auto-destroy   Set to auto-destroy within -auto-destroy-days days
+
+  The API does not expose when a lock was taken, so lock age is measured
+  from the workspace's last update.
+
+Options:
+
+  -organization=<name>  Organization name (required)
+  -org=<name>          Alias for -organization
+  -project-id=<id>     Only report on workspaces in this project
+  -stale-days=<n>      Days without a run before a workspace is stale (default: 30)
+  -lock-hours=<n>      Hours a workspace may stay locked (default: 24)
+  -min-terraform-version=<v> Oldest acceptable Terraform version
+  -owner-tag=<key>     Tag key that identifies an owner (default: owner)
+  -auto-destroy-days=<n> Days ahead to flag auto-destroy (default: 7)
+  -output=<format>     Output format: table (default), json, or csv
+
+Example:
+
+  hcptf workspace report -org=my-org
+  hcptf workspace report -org=my-org -stale-days=90 -min-terraform-version=1.5.0
+  hcptf workspace report -org=my-org -output=csv > hygiene.csv
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the workspace report command
+func (c *WorkspaceReportCommand) Synopsis() string {
+	return "Report workspace hygiene issues"
+}
@@ -0,0 +1,208 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+var workspaceReportNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newWorkspaceReportCommand(ui cli.Ui, svc workspaceLister) *WorkspaceReportCommand {
+	return &WorkspaceReportCommand{
+		Meta:         newTestMeta(ui),
+		now:          func() time.Time { return workspaceReportNow },
+		workspaceSvc: svc,
+	}
+}
+
+func healthyReportWorkspace() *tfe.Workspace {
+	return &tfe.Workspace{
+		ID:               "ws-good",
+		Name:             "good",
+		TerraformVersion: "1.9.0",
+		TagNames:         []string{"owner:platform"},
+		VCSRepo:          &tfe.VCSRepo{Identifier: "org/repo"},
+		ResourceCount:    12,
+		CurrentRun: &tfe.Run{
+			ID:        "run-good",
+			Status:    tfe.RunApplied,
+			CreatedAt: workspaceReportNow.Add(-24 * time.Hour),
+		},
+	}
+}
+
+func TestWorkspaceReportRequiresOrganization(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceReportCommand(ui, nil)
+
+	if code := cmd.Run(nil); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-organization") {
+		t.Fatalf("expected organization error, got %q", out)
+	}
+}
+
+func TestWorkspaceReportRejectsUnknownOutput(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceReportCommand(ui, nil)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=xml"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestWorkspaceReportHandlesAPIError(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := newWorkspaceReportCommand(ui, &mockWorkspacePagedListService{err: errors.New("boom")})
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "boom") {
+		t.Fatalf("expected API error, got %q", out)
+	}
+}
+
+func TestWorkspaceReportHealthyWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{healthyReportWorkspace()}}}
+	cmd := newWorkspaceReportCommand(ui, svc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-min-terraform-version=1.5.0"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No hygiene issues found in 1 workspaces") {
+		t.Fatalf("expected no issues, got %q", out)
+	}
+}
+
+func TestWorkspaceReportFlagsEveryCheck(t *testing.T) {
+	ui := cli.NewMockUi()
+	bad := &tfe.Workspace{
+		ID:               "ws-bad",
+		Name:             "bad",
+		TerraformVersion: "1.3.9",
+		Locked:           true,
+		UpdatedAt:        workspaceReportNow.Add(-48 * time.Hour),
+		AutoDestroyAt:    tfe.NullableTime(workspaceReportNow.Add(48 * time.Hour)),
+		CurrentRun: &tfe.Run{
+			ID:        "run-bad",
+			Status:    tfe.RunErrored,
+			CreatedAt: workspaceReportNow.Add(-60 * 24 * time.Hour),
+		},
+	}
+	svc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{healthyReportWorkspace()}, {bad}}}
+	cmd := newWorkspaceReportCommand(ui, svc)
+
+	code := cmd.Run([]string{"-org=my-org", "-min-terraform-version=1.5.0", "-output=json"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var findings []workspaceFinding
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &findings); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, ui.OutputWriter.String())
+	}
+
+	got := map[string]bool{}
+	for _, finding := range findings {
+		if finding.Workspace != "bad" {
+			t.Fatalf("unexpected finding for healthy workspace: %#v", finding)
+		}
+		got[finding.Check] = true
+	}
+	for _, check := range []string{
+		workspaceCheckStale, workspaceCheckErrored, workspaceCheckLocked, workspaceCheckNoVCS,
+		workspaceCheckNoOwner, workspaceCheckOldTerraform, workspaceCheckEmptyState, workspaceCheckAutoDestroy,
+	} {
+		if !got[check] {
+			t.Errorf("expected %s finding, got %#v", check, findings)
+		}
+	}
+}
+
+func TestWorkspaceReportThresholds(t *testing.T) {
+	ui := cli.NewMockUi()
+	ws := healthyReportWorkspace()
+	ws.CurrentRun.CreatedAt = workspaceReportNow.Add(-10 * 24 * time.Hour)
+	ws.Locked = true
+	ws.UpdatedAt = workspaceReportNow.Add(-2 * time.Hour)
+	svc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{ws}}}
+	cmd := newWorkspaceReportCommand(ui, svc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-stale-days=7", "-lock-hours=1", "-output=csv"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	out := ui.OutputWriter.String()
+	if !strings.HasPrefix(out, "Workspace,ID,Check,Detail\n") {
+		t.Fatalf("expected CSV header, got %q", out)
+	}
+	if !strings.Contains(out, "good,ws-good,stale,last run 10 days ago") {
+		t.Fatalf("expected stale finding, got %q", out)
+	}
+	if !strings.Contains(out, "good,ws-good,locked,locked for at least 2 hours") {
+		t.Fatalf("expected locked finding, got %q", out)
+	}
+}
+
+func TestWorkspaceReportOwnerTagBinding(t *testing.T) {
+	ui := cli.NewMockUi()
+	ws := healthyReportWorkspace()
+	ws.TagNames = nil
+	ws.EffectiveTagBindings = []*tfe.EffectiveTagBinding{{Key: "owner", Value: "platform"}}
+	svc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{ws}}}
+	cmd := newWorkspaceReportCommand(ui, svc)
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No hygiene issues found in 1 workspaces") {
+		t.Fatalf("expected the tag binding to count as an owner, got %q", out)
+	}
+}
+
+func TestWorkspaceReportEmptyCSVHasHeader(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := &mockWorkspacePagedListService{pages: [][]*tfe.Workspace{{healthyReportWorkspace()}}}
+	cmd := newWorkspaceReportCommand(ui, svc)
+
+	if code := cmd.Run([]string{"-org=my-org", "-output=csv"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); out != "Workspace,ID,Check,Detail\n" {
+		t.Fatalf("expected only the CSV header, got %q", out)
+	}
+}
+
+func TestHasTagKey(t *testing.T) {
+	if !hasTagKey([]string{"env:prod", "owner"}, "owner") {
+		t.Fatal("expected bare tag to match")
+	}
+	if !hasTagKey([]string{"owner=team-a"}, "owner") {
+		t.Fatal("expected key=value tag to match")
+	}
+	if hasTagKey([]string{"owners:team-a"}, "owner") {
+		t.Fatal("expected prefix-only tag not to match")
+	}
+}
+
+func TestWorkspaceReportHelp(t *testing.T) {
+	cmd := &WorkspaceReportCommand{}
+	help := cmd.Help()
+	for _, want := range []string{"workspace report", "-stale-days", "-min-terraform-version", "csv"} {
+		if !strings.Contains(help, want) {
+			t.Fatalf("expected help to contain %q", want)
+		}
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
@@ -25,3 +25,35 @@ type workspaceUpdater interface {
 type workspaceDeleter interface {
 	Delete(ctx context.Context, organization, workspace string) error
 }
+
+type workspaceRemoteStateConsumerLister interface {
+	RemoteStateConsumers(ctx context.Context, workspaceID string, options *tfe.RemoteStateConsumersListOptions) (*tfe.WorkspaceList, error)
+}
+
+type workspaceIDReader interface {
+	ReadByID(ctx context.Context, workspaceID string) (*tfe.Workspace, error)
+}
+
+type workspaceRefReader interface {
+	workspaceReader
+	workspaceIDReader
+}
+
+type workspaceRemoteStateConsumerManager interface {
+	workspaceRefReader
+	workspaceRemoteStateConsumerLister
+	AddRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceAddRemoteStateConsumersOptions) error
+	RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceRemoveRemoteStateConsumersOptions) error
+	UpdateRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceUpdateRemoteStateConsumersOptions) error
+}
+
+type workspaceStateLocker interface {
+	workspaceReader
+	workspaceLocker
+	workspaceUnlocker
+}
+
+type workspaceListRefReader interface {
+	workspaceLister
+	workspaceRefReader
+}
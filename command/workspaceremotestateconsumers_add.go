@@ -0,0 +1,78 @@
+package command
+
+import (
+	"strings"
+)
+
+// WorkspaceRemoteStateConsumersAddCommand is a command to allow more workspaces to read a workspace's state
+type WorkspaceRemoteStateConsumersAddCommand struct {
+	Meta
+	organization string
+	workspace    string
+	consumers    string
+	format       string
+	workspaceSvc workspaceRemoteStateConsumerManager
+}
+
+// Run executes the workspace remote-state-consumers add command
+func (c *WorkspaceRemoteStateConsumersAddCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("workspace remote-state-consumers add")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name or ID (required)")
+	flags.StringVar(&c.consumers, "consumers", "", "Comma-separated workspace names or IDs to allow (required)")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	refs := splitCommaList(c.consumers)
+	if len(refs) == 0 {
+		c.Ui.Error("Error: -consumers flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	return changeRemoteStateConsumers(&c.Meta, c.workspaceSvc, remoteStateConsumersAdd, c.organization, c.workspace, refs, c.format)
+}
+
+// Help returns help text for the workspace remote-state-consumers add command
+func (c *WorkspaceRemoteStateConsumersAddCommand) Help() string {
+	helpText := `
+Usage: hcptf workspace remote-state-consumers add [options]
+
+  Allow more workspaces to read a workspace's state. Existing consumers
+  are kept.
+
+  Workspaces can be given by name or ID. Use -dry-run to print the consumers
+  that would be added and removed without changing anything.
+
+Options:
+
+  -organization=<name>  Organization name (required when using names)
+  -org=<name>          Alias for -organization
+  -workspace=<ref>     Workspace whose state is shared, name or ID (required)
+  -consumers=<refs>    Comma-separated workspace names or IDs to allow (required)
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf workspace remote-state-consumers add -org=my-org -workspace=network \
+    -consumers=app-prod,app-staging
+  hcptf workspace remote-state-consumers add -workspace=ws-ABC123 -consumers=ws-DEF456
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the workspace remote-state-consumers add command
+func (c *WorkspaceRemoteStateConsumersAddCommand) Synopsis() string {
+	return "Allow workspaces to read a workspace's state"
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestWorkspaceRemoteStateConsumersAddRequiresConsumers(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-consumers") {
+		t.Fatalf("expected consumers error, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersAddNamesAndIDs(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=app,api,ws-batch"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(svc.addCalls) != 1 {
+		t.Fatalf("expected one add call, got %d", len(svc.addCalls))
+	}
+	if got := strings.Join(workspaceNames(svc.addCalls[0]), ","); got != "api,batch" {
+		t.Fatalf("expected only new consumers to be added, got %q", got)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "Added remote state consumers to 'network': api, batch") {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersAddNoChanges(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=app"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	if len(svc.addCalls) != 0 {
+		t.Fatalf("expected no API call, got %d", len(svc.addCalls))
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No changes") {
+		t.Fatalf("expected no changes message, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersAddDryRun(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=api", "-dry-run"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(svc.addCalls) != 0 {
+		t.Fatalf("expected no API call in dry-run")
+	}
+
+	var plan map[string]interface{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &plan); err != nil {
+		t.Fatalf("failed to parse dry-run output: %v", err)
+	}
+	if plan["action"] != "add" || plan["workspace_id"] != "ws-network" {
+		t.Fatalf("unexpected dry-run output: %#v", plan)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersAddRejectsSelf(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{Meta: newTestMeta(ui), workspaceSvc: newMockRemoteStateConsumerManager()}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=ws-network"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "itself") {
+		t.Fatalf("expected self-reference error, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersAddHelp(t *testing.T) {
+	cmd := &WorkspaceRemoteStateConsumersAddCommand{}
+	if !strings.Contains(cmd.Help(), "-consumers") {
+		t.Fatal("expected -consumers in help")
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/hcptf-cli/internal/client"
+)
+
+const (
+	remoteStateConsumersAdd     = "add"
+	remoteStateConsumersRemove  = "remove"
+	remoteStateConsumersReplace = "replace"
+)
+
+// remoteStateConsumerService returns the injected service or the client's
+// workspaces service.
+func remoteStateConsumerService(svc workspaceRemoteStateConsumerManager, client *client.Client) workspaceRemoteStateConsumerManager {
+	if svc != nil {
+		return svc
+	}
+	return client.Workspaces
+}
+
+// planRemoteStateConsumerChange returns the consumers that an add, remove, or
+// replace of requested would add to and remove from current.
+func planRemoteStateConsumerChange(mode string, current, requested []*tfe.Workspace) (added, removed []*tfe.Workspace) {
+	currentIDs := make(map[string]bool, len(current))
+	for _, ws := range current {
+		currentIDs[ws.ID] = true
+	}
+	requestedIDs := make(map[string]bool, len(requested))
+	for _, ws := range requested {
+		requestedIDs[ws.ID] = true
+	}
+
+	switch mode {
+	case remoteStateConsumersAdd:
+		for _, ws := range requested {
+			if !currentIDs[ws.ID] {
+				added = append(added, ws)
+			}
+		}
+	case remoteStateConsumersRemove:
+		for _, ws := range requested {
+			if currentIDs[ws.ID] {
+				removed = append(removed, ws)
+			}
+		}
+	case remoteStateConsumersReplace:
+		for _, ws := range requested {
+			if !currentIDs[ws.ID] {
+				added = append(added, ws)
+			}
+		}
+		for _, ws := range current {
+			if !requestedIDs[ws.ID] {
+				removed = append(removed, ws)
+			}
+		}
+	}
+	return added, removed
+}
+
+// changeRemoteStateConsumers resolves the target workspace and the requested
+// consumers, then adds, removes, or replaces consumers. It returns the exit
+// code for the calling command.
+func changeRemoteStateConsumers(m *Meta, svc workspaceRemoteStateConsumerManager, mode, organization, workspace string, refs []string, format string) int {
+	client, err := m.Client()
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+	svc = remoteStateConsumerService(svc, client)
+
+	target, err := resolveWorkspaceRef(ctx, svc, organization, workspace)
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	requested, err := resolveWorkspaceRefs(ctx, svc, organization, refs)
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error reading consumer workspace: %s", err))
+		return 1
+	}
+	for _, ws := range requested {
+		if ws.ID == target.ID {
+			m.Ui.Error(fmt.Sprintf("Error: workspace '%s' cannot be a remote state consumer of itself", target.Name))
+			return 1
+		}
+	}
+
+	current, err := listAllRemoteStateConsumers(ctx, svc, target.ID)
+	if err != nil {
+		m.Ui.Error(fmt.Sprintf("Error listing remote state consumers: %s", err))
+		return 1
+	}
+
+	added, removed := planRemoteStateConsumerChange(mode, current, requested)
+
+	if target.GlobalRemoteState {
+		m.Ui.Warn(fmt.Sprintf("Warning: workspace '%s' shares state with every workspace in the organization; the consumer list has no effect until global remote state is disabled", target.Name))
+	}
+
+	if m.DryRun {
+		m.NewFormatter("json").JSON(map[string]interface{}{
+			"action":       mode,
+			"resource":     "remote-state-consumers",
+			"workspace":    target.Name,
+			"workspace_id": target.ID,
+			"add":          workspaceNames(added),
+			"remove":       workspaceNames(removed),
+		})
+		return 0
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		switch mode {
+		case remoteStateConsumersAdd:
+			err = svc.AddRemoteStateConsumers(ctx, target.ID, tfe.WorkspaceAddRemoteStateConsumersOptions{Workspaces: added})
+		case remoteStateConsumersRemove:
+			err = svc.RemoveRemoteStateConsumers(ctx, target.ID, tfe.WorkspaceRemoveRemoteStateConsumersOptions{Workspaces: removed})
+		case remoteStateConsumersReplace:
+			err = svc.UpdateRemoteStateConsumers(ctx, target.ID, tfe.WorkspaceUpdateRemoteStateConsumersOptions{Workspaces: requested})
+		}
+		if err != nil {
+			m.Ui.Error(fmt.Sprintf("Error updating remote state consumers: %s", err))
+			return 1
+		}
+	}
+
+	if format == "json" {
+		m.NewFormatter(format).JSON(map[string]interface{}{
+			"workspace":    target.Name,
+			"workspace_id": target.ID,
+			"added":        workspaceNames(added),
+			"removed":      workspaceNames(removed),
+		})
+		return 0
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		m.Ui.Output(fmt.Sprintf("No changes to remote state consumers of workspace '%s'", target.Name))
+		return 0
+	}
+	if len(added) > 0 {
+		m.Ui.Output(fmt.Sprintf("Added remote state consumers to '%s': %s", target.Name, strings.Join(workspaceNames(added), ", ")))
+	}
+	if len(removed) > 0 {
+		m.Ui.Output(fmt.Sprintf("Removed remote state consumers from '%s': %s", target.Name, strings.Join(workspaceNames(removed), ", ")))
+	}
+	return 0
+}
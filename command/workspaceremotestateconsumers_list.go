@@ -0,0 +1,109 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkspaceRemoteStateConsumersListCommand is a command to list the
+// workspaces allowed to read a workspace's state
+type WorkspaceRemoteStateConsumersListCommand struct {
+	Meta
+	organization string
+	workspace    string
+	format       string
+	workspaceSvc workspaceRemoteStateConsumerManager
+}
+
+// Run executes the workspace remote-state-consumers list command
+func (c *WorkspaceRemoteStateConsumersListCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet("workspace remote-state-consumers list")
+	flags.StringVar(&c.organization, "organization", c.Meta.DefaultOrganization(), "Organization name")
+	flags.StringVar(&c.organization, "org", c.Meta.DefaultOrganization(), "Organization name (alias)")
+	flags.StringVar(&c.workspace, "workspace", "", "Workspace name or ID (required)")
+	flags.StringVar(&c.format, "output", "table", "Output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Validate required flags
+	if c.workspace == "" {
+		c.Ui.Error("Error: -workspace flag is required")
+		c.Ui.Error(c.Help())
+		return 1
+	}
+
+	// Get API client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	ctx := client.Context()
+	svc := remoteStateConsumerService(c.workspaceSvc, client)
+
+	target, err := resolveWorkspaceRef(ctx, svc, c.organization, c.workspace)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workspace: %s", err))
+		return 1
+	}
+
+	consumers, err := listAllRemoteStateConsumers(ctx, svc, target.ID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing remote state consumers: %s", err))
+		return 1
+	}
+
+	formatter := c.Meta.NewFormatter(c.format)
+
+	if target.GlobalRemoteState && c.format != "json" {
+		c.Ui.Warn(fmt.Sprintf("Warning: workspace '%s' shares state with every workspace in the organization", target.Name))
+	}
+
+	if len(consumers) == 0 {
+		if c.format == "json" {
+			formatter.JSON([]interface{}{})
+			return 0
+		}
+		c.Ui.Output(fmt.Sprintf("No remote state consumers for workspace '%s'", target.Name))
+		return 0
+	}
+
+	headers := []string{"ID", "Name"}
+	var rows [][]string
+	for _, ws := range consumers {
+		rows = append(rows, []string{ws.ID, ws.Name})
+	}
+
+	formatter.Table(headers, rows)
+	return 0
+}
+
+// Help returns help text for the workspace remote-state-consumers list command
+func (c *WorkspaceRemoteStateConsumersListCommand) Help() string {
+	helpText := `
+Usage: hcptf workspace remote-state-consumers list [options]
+
+  List the workspaces allowed to read a workspace's state. The list only
+  takes effect while global remote state sharing is disabled.
+
+Options:
+
+  -organization=<name>  Organization name (required when -workspace is a name)
+  -org=<name>          Alias for -organization
+  -workspace=<ref>     Workspace name or ID (required)
+  -output=<format>     Output format: table (default) or json
+
+Example:
+
+  hcptf workspace remote-state-consumers list -org=my-org -workspace=network
+  hcptf workspace remote-state-consumers list -workspace=ws-ABC123 -output=json
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis returns a short synopsis for the workspace remote-state-consumers list command
+func (c *WorkspaceRemoteStateConsumersListCommand) Synopsis() string {
+	return "List workspaces allowed to read a workspace's state"
+}
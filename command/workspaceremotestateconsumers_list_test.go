@@ -0,0 +1,108 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestWorkspaceRemoteStateConsumersListRequiresWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersListCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-org=my-org"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-workspace") {
+		t.Fatalf("expected workspace error, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListByName(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersListCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: newMockRemoteStateConsumerManager(),
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "ws-app") {
+		t.Fatalf("expected consumer in output, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListByIDJSON(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersListCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: newMockRemoteStateConsumerManager(),
+	}
+
+	if code := cmd.Run([]string{"-workspace=ws-network", "-output=json"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	var rows []map[string]string
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &rows); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["Name"] != "app" {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListEmpty(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersListCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: newMockRemoteStateConsumerManager(),
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=app"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No remote state consumers") {
+		t.Fatalf("expected empty message, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListHandlesError(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	svc.err = errors.New("boom")
+	cmd := &WorkspaceRemoteStateConsumersListCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "boom") {
+		t.Fatalf("expected API error, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListUnknownWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersListCommand{
+		Meta:         newTestMeta(ui),
+		workspaceSvc: newMockRemoteStateConsumerManager(),
+	}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=missing"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersListHelp(t *testing.T) {
+	cmd := &WorkspaceRemoteStateConsumersListCommand{}
+	if !strings.Contains(cmd.Help(), "remote-state-consumers list") {
+		t.Fatal("expected usage in help")
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestWorkspaceRemoteStateConsumersRemoveRequiresWorkspace(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersRemoveCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-consumers=app"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersRemoveIgnoresNonConsumers(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersRemoveCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	code := cmd.Run([]string{"-org=my-org", "-workspace=ws-network", "-consumers=app,api", "-output=json"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(svc.removeCalls) != 1 || len(svc.removeCalls[0]) != 1 || svc.removeCalls[0][0].ID != "ws-app" {
+		t.Fatalf("expected only app to be removed, got %#v", svc.removeCalls)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &result); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	removed, _ := result["removed"].([]interface{})
+	if len(removed) != 1 || removed[0] != "app" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersRemoveUnknownConsumer(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersRemoveCommand{Meta: newTestMeta(ui), workspaceSvc: newMockRemoteStateConsumerManager()}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=missing"}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "missing") {
+		t.Fatalf("expected lookup error, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersRemoveHelp(t *testing.T) {
+	cmd := &WorkspaceRemoteStateConsumersRemoveCommand{}
+	if !strings.Contains(cmd.Help(), "remote-state-consumers remove") {
+		t.Fatal("expected usage in help")
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
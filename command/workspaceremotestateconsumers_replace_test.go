@@ -0,0 +1,106 @@
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/mitchellh/cli"
+)
+
+func TestWorkspaceRemoteStateConsumersReplaceRequiresConsumers(t *testing.T) {
+	ui := cli.NewMockUi()
+	cmd := &WorkspaceRemoteStateConsumersReplaceCommand{Meta: newTestMeta(ui)}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers= , "}); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersReplace(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersReplaceCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=api,batch"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+
+	if len(svc.updateCalls) != 1 || strings.Join(workspaceNames(svc.updateCalls[0]), ",") != "api,batch" {
+		t.Fatalf("unexpected update calls: %#v", svc.updateCalls)
+	}
+	out := ui.OutputWriter.String()
+	if !strings.Contains(out, "Added remote state consumers to 'network': api, batch") {
+		t.Fatalf("expected added consumers in output, got %q", out)
+	}
+	if !strings.Contains(out, "Removed remote state consumers from 'network': app") {
+		t.Fatalf("expected removed consumers in output, got %q", out)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersReplaceDryRunShowsDiff(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	cmd := &WorkspaceRemoteStateConsumersReplaceCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=network", "-consumers=app,api", "-dry-run"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if len(svc.updateCalls) != 0 {
+		t.Fatalf("expected no API call in dry-run")
+	}
+
+	var plan struct {
+		Add    []string `json:"add"`
+		Remove []string `json:"remove"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &plan); err != nil {
+		t.Fatalf("failed to parse dry-run output: %v", err)
+	}
+	if strings.Join(plan.Add, ",") != "api" || len(plan.Remove) != 0 {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersReplaceWarnsOnGlobalRemoteState(t *testing.T) {
+	ui := cli.NewMockUi()
+	svc := newMockRemoteStateConsumerManager()
+	svc.workspaces = append(svc.workspaces, &tfe.Workspace{ID: "ws-shared", Name: "shared", GlobalRemoteState: true})
+	cmd := &WorkspaceRemoteStateConsumersReplaceCommand{Meta: newTestMeta(ui), workspaceSvc: svc}
+
+	if code := cmd.Run([]string{"-org=my-org", "-workspace=shared", "-consumers=app"}); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "global remote state") {
+		t.Fatalf("expected global remote state warning, got %q", out)
+	}
+}
+
+func TestPlanRemoteStateConsumerChange(t *testing.T) {
+	a := &tfe.Workspace{ID: "ws-a", Name: "a"}
+	b := &tfe.Workspace{ID: "ws-b", Name: "b"}
+	c := &tfe.Workspace{ID: "ws-c", Name: "c"}
+	current := []*tfe.Workspace{a, b}
+
+	added, removed := planRemoteStateConsumerChange(remoteStateConsumersReplace, current, []*tfe.Workspace{b, c})
+	if strings.Join(workspaceNames(added), ",") != "c" || strings.Join(workspaceNames(removed), ",") != "a" {
+		t.Fatalf("unexpected replace plan: added=%v removed=%v", workspaceNames(added), workspaceNames(removed))
+	}
+
+	added, removed = planRemoteStateConsumerChange(remoteStateConsumersRemove, current, []*tfe.Workspace{c})
+	if len(added) != 0 || len(removed) != 0 {
+		t.Fatalf("expected no-op removal, got added=%v removed=%v", workspaceNames(added), workspaceNames(removed))
+	}
+}
+
+func TestWorkspaceRemoteStateConsumersReplaceHelp(t *testing.T) {
+	cmd := &WorkspaceRemoteStateConsumersReplaceCommand{}
+	if !strings.Contains(cmd.Help(), "remote-state-consumers replace") {
+		t.Fatal("expected usage in help")
+	}
+	if cmd.Synopsis() == "" {
+		t.Fatal("expected synopsis")
+	}
+}
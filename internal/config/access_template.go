@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// AccessTemplate is a named set of project team access grants, applied to
+// new projects with "project create -access-template" and to existing ones
+// with "project apply-access-template"
+type AccessTemplate struct {
+	Name  string                `hcl:"name,label"`
+	Teams []*AccessTemplateTeam `hcl:"team,block"`
+}
+
+// AccessTemplateTeam is the project access one team receives from a
+// template. The permission attributes only apply when Access is "custom".
+type AccessTemplateTeam struct {
+	Name            string `hcl:"name,label"`
+	Access          string `hcl:"access"`
+	ProjectSettings string `hcl:"project_settings,optional"`
+	ProjectTeams    string `hcl:"project_teams,optional"`
+	Runs            string `hcl:"runs,optional"`
+	Variables       string `hcl:"variables,optional"`
+	StateVersions   string `hcl:"state_versions,optional"`
+	SentinelMocks   string `hcl:"sentinel_mocks,optional"`
+	CreateWorkspace bool   `hcl:"create_workspace,optional"`
+	DeleteWorkspace bool   `hcl:"delete_workspace,optional"`
+	MoveWorkspace   bool   `hcl:"move_workspace,optional"`
+	Locking         bool   `hcl:"locking,optional"`
+	RunTasks        bool   `hcl:"run_tasks,optional"`
+}
+
+type accessTemplateFile struct {
+	AccessTemplates []*AccessTemplate `hcl:"access_template,block"`
+}
+
+// LoadAccessTemplates loads access templates from a file, such as one kept
+// in a repository alongside the projects it describes. The file holds only
+// access_template blocks, in HCL or JSON.
+func LoadAccessTemplates(path string) (map[string]*AccessTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access templates: %w", err)
+	}
+
+	virtualPath := path
+	switch filepath.Ext(virtualPath) {
+	case ".hcl", ".json":
+	default:
+		virtualPath += ".hcl"
+	}
+
+	var file accessTemplateFile
+	if err := hclsimple.Decode(virtualPath, data, nil, &file); err != nil {
+		return nil, fmt.Errorf("failed to load access templates: %w", err)
+	}
+
+	return accessTemplateMap(file.AccessTemplates)
+}
+
+// accessTemplateMap indexes templates by name, rejecting duplicate
+// templates and duplicate teams within a template.
+func accessTemplateMap(templates []*AccessTemplate) (map[string]*AccessTemplate, error) {
+	result := make(map[string]*AccessTemplate, len(templates))
+	for _, tmpl := range templates {
+		if _, ok := result[tmpl.Name]; ok {
+			return nil, fmt.Errorf("access template %q is defined more than once", tmpl.Name)
+		}
+		teams := make(map[string]bool, len(tmpl.Teams))
+		for _, team := range tmpl.Teams {
+			if teams[team.Name] {
+				return nil, fmt.Errorf("access template %q: team %q is listed more than once", tmpl.Name, team.Name)
+			}
+			teams[team.Name] = true
+		}
+		result[tmpl.Name] = tmpl
+	}
+	return result, nil
+}
@@ -22,12 +22,16 @@ type Config struct {
 
 	// OutputFormat is the default output format (table, json)
 	OutputFormat string `hcl:"output_format,optional"`
+
+	// AccessTemplates is a map of template name to the team access it grants
+	AccessTemplates map[string]*AccessTemplate `hcl:"access_template,block"`
 }
 
 type fileConfig struct {
-	Credentials         []*Credential `hcl:"credentials,block"`
-	DefaultOrganization string        `hcl:"default_organization,optional"`
-	OutputFormat        string        `hcl:"output_format,optional"`
+	Credentials         []*Credential     `hcl:"credentials,block"`
+	DefaultOrganization string            `hcl:"default_organization,optional"`
+	OutputFormat        string            `hcl:"output_format,optional"`
+	AccessTemplates     []*AccessTemplate `hcl:"access_template,block"`
 }
 
 // Credential represents credentials for a specific Terraform instance
@@ -56,6 +60,7 @@ func Load() (*Config, error) {
 
 	var config Config
 	config.Credentials = make(map[string]*Credential)
+	config.AccessTemplates = make(map[string]*AccessTemplate)
 	config.OutputFormat = "table"
 
 	// Try to load from hcptfrc first
@@ -88,6 +93,12 @@ func Load() (*Config, error) {
 		if diskConfig.OutputFormat != "" {
 			config.OutputFormat = diskConfig.OutputFormat
 		}
+
+		templates, err := accessTemplateMap(diskConfig.AccessTemplates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		config.AccessTemplates = templates
 	}
 
 	if org := GetDefaultOrganizationEnv(); org != "" {
@@ -186,6 +197,22 @@ func GetConfigPath() string {
 	return filepath.Join(home, ".hcptfrc")
 }
 
+// GetCacheDir returns the directory for locally cached API data. It is
+// HCPTF_CACHE_DIR when set, otherwise an hcptf directory under the user's
+// cache directory.
+func GetCacheDir() string {
+	if dir := os.Getenv("HCPTF_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "hcptf")
+}
+
 // GetTerraformCredentialsPath returns the path to the Terraform CLI credentials file
 func GetTerraformCredentialsPath() string {
 	home, err := os.UserHomeDir()
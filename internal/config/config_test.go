@@ -131,6 +131,94 @@ func TestLoadDefaultsWhenConfigMissing(t *testing.T) {
 	}
 }
 
+func TestLoadReadsAccessTemplates(t *testing.T) {
+	unsetEnv(t, EnvFileVariable, "TFE_ORG", "HCPTF_ORG")
+	home := t.TempDir()
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", home)
+
+	writeFile(t, filepath.Join(home, ".hcptfrc"), `
+access_template "standard" {
+  team "platform" {
+    access = "admin"
+  }
+
+  team "developers" {
+    access           = "custom"
+    runs             = "apply"
+    variables        = "write"
+    create_workspace = true
+  }
+}
+`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tmpl, ok := cfg.AccessTemplates["standard"]
+	if !ok {
+		t.Fatalf("expected access template standard, got %v", cfg.AccessTemplates)
+	}
+	if len(tmpl.Teams) != 2 {
+		t.Fatalf("expected 2 teams, got %d", len(tmpl.Teams))
+	}
+	dev := tmpl.Teams[1]
+	if dev.Name != "developers" || dev.Access != "custom" || dev.Runs != "apply" || dev.Variables != "write" || !dev.CreateWorkspace {
+		t.Fatalf("unexpected developers team: %+v", dev)
+	}
+	if dev.DeleteWorkspace || dev.StateVersions != "" {
+		t.Fatalf("expected unset permissions to stay empty, got %+v", dev)
+	}
+}
+
+func TestLoadRejectsDuplicateAccessTemplateTeams(t *testing.T) {
+	unsetEnv(t, EnvFileVariable, "TFE_ORG", "HCPTF_ORG")
+	home := t.TempDir()
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", home)
+
+	writeFile(t, filepath.Join(home, ".hcptfrc"), `
+access_template "standard" {
+  team "platform" {
+    access = "admin"
+  }
+  team "platform" {
+    access = "read"
+  }
+}
+`)
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), `team "platform" is listed more than once`) {
+		t.Fatalf("expected duplicate team error, got %v", err)
+	}
+}
+
+func TestLoadAccessTemplatesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access-templates.hcl")
+	writeFile(t, path, `
+access_template "restricted" {
+  team "auditors" {
+    access = "read"
+  }
+}
+`)
+
+	templates, err := LoadAccessTemplates(path)
+	if err != nil {
+		t.Fatalf("LoadAccessTemplates() error = %v", err)
+	}
+	if got := templates["restricted"].Teams[0].Name; got != "auditors" {
+		t.Fatalf("expected auditors team, got %s", got)
+	}
+
+	if _, err := LoadAccessTemplates(filepath.Join(t.TempDir(), "missing.hcl")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
 func TestLoadUsesDefaultOrganizationFromDotEnv(t *testing.T) {
 	unsetEnv(t, EnvFileVariable, "TFE_ORG", "HCPTF_ORG")
 	home := t.TempDir()
@@ -488,3 +576,27 @@ func TestGetConfigPathEnvOverride(t *testing.T) {
 		t.Fatalf("expected HCPTF_CONFIG to override path, got %s", path)
 	}
 }
+
+func TestGetCacheDirUsesEnvironmentOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HCPTF_CACHE_DIR", dir)
+
+	if got := GetCacheDir(); got != dir {
+		t.Fatalf("expected %s, got %s", dir, got)
+	}
+}
+
+func TestGetCacheDirDefaultsToUserCacheDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HCPTF_CACHE_DIR", "")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", "")
+
+	want, err := os.UserCacheDir()
+	if err != nil {
+		t.Skipf("no user cache dir: %v", err)
+	}
+	if got := GetCacheDir(); got != filepath.Join(want, "hcptf") {
+		t.Fatalf("expected %s, got %s", filepath.Join(want, "hcptf"), got)
+	}
+}
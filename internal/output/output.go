@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,9 @@ const (
 
 	// FormatJSON outputs data in JSON format
 	FormatJSON Format = "json"
+
+	// FormatCSV outputs tabular data as comma-separated values
+	FormatCSV Format = "csv"
 )
 
 // Formatter handles output formatting
@@ -49,7 +53,7 @@ func NewFormatterWithWriters(format string, out io.Writer, err io.Writer) *Forma
 	}
 
 	f := Format(format)
-	if f != FormatTable && f != FormatJSON {
+	if f != FormatTable && f != FormatJSON && f != FormatCSV {
 		f = FormatTable // Default to table
 	}
 
@@ -142,6 +146,11 @@ func (f *Formatter) Table(headers []string, rows [][]string) {
 		return
 	}
 
+	if f.format == FormatCSV {
+		f.csv(filteredHeaders, rows, indexes)
+		return
+	}
+
 	table := tablewriter.NewTable(f.out, tablewriter.WithHeaderAutoFormat(tw.Off))
 	table.Header(filteredHeaders)
 	for _, row := range rows {
@@ -169,9 +178,27 @@ func (f *Formatter) TableWithFullRows(headers []string, displayRows [][]string,
 		return
 	}
 
+	if f.format == FormatCSV {
+		f.Table(headers, fullRows)
+		return
+	}
+
 	f.Table(headers, displayRows)
 }
 
+// csv writes the selected columns of each row as CSV with a header line.
+func (f *Formatter) csv(headers []string, rows [][]string, indexes []int) {
+	w := csv.NewWriter(f.out)
+	_ = w.Write(headers)
+	for _, row := range rows {
+		_ = w.Write(f.filterRow(row, indexes))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Fprintf(f.err, "Error writing CSV: %v\n", err)
+	}
+}
+
 // JSON outputs data in JSON format
 func (f *Formatter) JSON(data interface{}) {
 	if f.format == FormatTable {
@@ -214,6 +241,15 @@ func (f *Formatter) KeyValue(data map[string]interface{}) {
 		sort.Strings(keys)
 	}
 
+	if f.format == FormatCSV {
+		rows := make([][]string, 0, len(keys))
+		for _, k := range keys {
+			rows = append(rows, []string{k, formatValue(filtered[k])})
+		}
+		f.csv([]string{"key", "value"}, rows, []int{0, 1})
+		return
+	}
+
 	var maxKeyLen int
 	for _, k := range keys {
 		if len(k) > maxKeyLen {
@@ -391,3 +391,38 @@ func TestListRespectsFormats(t *testing.T) {
 		t.Fatalf("unexpected list data: %v", decoded)
 	}
 }
+
+func TestTableCSV(t *testing.T) {
+	out := &bytes.Buffer{}
+	formatter := NewFormatterWithWriters("csv", out, &bytes.Buffer{})
+	formatter.SetFields([]string{"Name", "Notes"})
+	formatter.Table([]string{"Name", "ID", "Notes"}, [][]string{
+		{"prod", "ws-1", "locked, errored"},
+		{"staging", "ws-2", ""},
+	})
+
+	want := "Name,Notes\nprod,\"locked, errored\"\nstaging,\n"
+	if out.String() != want {
+		t.Fatalf("unexpected csv output: %q", out.String())
+	}
+}
+
+func TestTableWithFullRowsUsesFullValuesForCSV(t *testing.T) {
+	out := &bytes.Buffer{}
+	formatter := NewFormatterWithWriters("csv", out, &bytes.Buffer{})
+	formatter.TableWithFullRows([]string{"Name"}, [][]string{{"pro..."}}, [][]string{{"production"}})
+
+	if out.String() != "Name\nproduction\n" {
+		t.Fatalf("unexpected csv output: %q", out.String())
+	}
+}
+
+func TestKeyValueCSV(t *testing.T) {
+	out := &bytes.Buffer{}
+	formatter := NewFormatterWithWriters("csv", out, &bytes.Buffer{})
+	formatter.KeyValue(map[string]interface{}{"Name": "prod", "ID": "ws-1"})
+
+	if out.String() != "key,value\nID,ws-1\nName,prod\n" {
+		t.Fatalf("unexpected csv output: %q", out.String())
+	}
+}